@@ -4,7 +4,9 @@ import (
 	"errors"
 	"os"
 
+	"github.com/EdgeNet-project/edgenet/pkg/access"
 	admissioncontrol "github.com/EdgeNet-project/edgenet/pkg/admissioncontrol"
+	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -29,6 +31,13 @@ func init() {
 }
 
 func main() {
+	// The webhook consults the API server through SubjectAccessReviews to authorize the requests
+	kubeclientset, err := bootstrap.CreateClientset("serviceaccount")
+	if err != nil {
+		klog.Fatalf("Error creating clientset: %s", err.Error())
+	}
+	access.Clientset = kubeclientset
+
 	webhook := admissioncontrol.Webhook{}
 	webhook.CertFile = tlsCert
 	webhook.KeyFile = tlsKey
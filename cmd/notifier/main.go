@@ -20,11 +20,13 @@ import (
 	"flag"
 	"log"
 
+	"github.com/EdgeNet-project/edgenet/pkg/access"
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/core/v1alpha1/notifier"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
 	"github.com/EdgeNet-project/edgenet/pkg/signals"
 
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/klog"
 )
 
@@ -47,6 +49,7 @@ func main() {
 	}
 
 	// Start the controller to provide the functionalities of notifier controller
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeclientset, 0)
 	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, 0)
 
 	controller := notifier.NewController(
@@ -54,8 +57,12 @@ func main() {
 		edgenetclientset,
 		edgenetInformerFactory.Registration().V1alpha1().TenantRequests(),
 		edgenetInformerFactory.Registration().V1alpha1().RoleRequests(),
-		edgenetInformerFactory.Registration().V1alpha1().ClusterRoleRequests())
+		edgenetInformerFactory.Registration().V1alpha1().ClusterRoleRequests(),
+		edgenetInformerFactory.Registration().V1alpha1().QuotaRequests())
+	// Authorization decisions are cached until an RBAC object changes
+	access.WatchAuthorizationCache(kubeInformerFactory)
 
+	kubeInformerFactory.Start(stopCh)
 	edgenetInformerFactory.Start(stopCh)
 
 	if err = controller.Run(2, stopCh); err != nil {
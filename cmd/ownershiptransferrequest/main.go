@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/registration/v1alpha1/ownershiptransferrequest"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+	"github.com/EdgeNet-project/edgenet/pkg/signals"
+
+	"k8s.io/klog"
+)
+
+func main() {
+	klog.InitFlags(nil)
+	acceptanceTimeout := flag.Duration("acceptance-timeout", 72*time.Hour, "Period within which a request needs to be accepted before it expires")
+	retention := flag.Duration("retention", 0, "Period for which expired requests are kept before deletion")
+	flag.Parse()
+
+	stopCh := signals.SetupSignalHandler()
+	// TODO: Pass an argument to select using kubeconfig or service account for clients
+	// bootstrap.SetKubeConfig()
+	kubeclientset, err := bootstrap.CreateClientset("serviceaccount")
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	edgenetclientset, err := bootstrap.CreateEdgeNetClientset("serviceaccount")
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	// Start the controller to provide the functionalities of ownershiptransferrequest resource
+	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, 0)
+
+	controller := ownershiptransferrequest.NewController(kubeclientset,
+		edgenetclientset,
+		edgenetInformerFactory.Registration().V1alpha1().OwnershipTransferRequests(),
+		*acceptanceTimeout,
+		*retention)
+
+	edgenetInformerFactory.Start(stopCh)
+
+	if err = controller.Run(2, stopCh); err != nil {
+		klog.Fatalf("Error running controller: %s", err.Error())
+	}
+}
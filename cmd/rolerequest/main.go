@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"log"
+	"time"
 
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/registration/v1alpha1/rolerequest"
@@ -14,6 +15,8 @@ import (
 
 func main() {
 	klog.InitFlags(nil)
+	approvalTimeout := flag.Duration("approval-timeout", 72*time.Hour, "Period within which a request needs to be approved before it expires")
+	retention := flag.Duration("retention", 0, "Period for which expired and denied requests are kept before deletion")
 	flag.Parse()
 
 	stopCh := signals.SetupSignalHandler()
@@ -34,7 +37,9 @@ func main() {
 
 	controller := rolerequest.NewController(kubeclientset,
 		edgenetclientset,
-		edgenetInformerFactory.Registration().V1alpha1().RoleRequests())
+		edgenetInformerFactory.Registration().V1alpha1().RoleRequests(),
+		*approvalTimeout,
+		*retention)
 
 	edgenetInformerFactory.Start(stopCh)
 
@@ -33,18 +33,27 @@ func main() {
 		log.Println(err.Error())
 		panic(err.Error())
 	}
+	dynamicclientset, err := bootstrap.CreateDynamicClientset("serviceaccount")
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
 	// Start the controller to provide the functionalities of subnamespace resource
 	var listOptionsFunc = func(labelSelector string) internalinterfaces.TweakListOptionsFunc {
 		return func(listOptions *metav1.ListOptions) {
 			listOptions.LabelSelector = labelSelector
 		}
 	}
+	// The inherited objects in parent namespaces rarely carry tenant labels, hence they are all watched, and the tenant namespaces
+	// they belong to are told apart through the namespaces with tenant labels
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeclientset, time.Second*30)
 	informerOption := kubeinformers.WithTweakListOptions(listOptionsFunc("edge-net.io/tenant"))
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeclientset, time.Second*30, informerOption)
+	namespaceInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeclientset, time.Second*30, informerOption)
 	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, time.Second*30)
 
 	controller := subnamespace.NewController(kubeclientset,
 		edgenetclientset,
+		dynamicclientset,
 		kubeInformerFactory.Rbac().V1().Roles(),
 		kubeInformerFactory.Rbac().V1().RoleBindings(),
 		kubeInformerFactory.Networking().V1().NetworkPolicies(),
@@ -52,9 +61,11 @@ func main() {
 		kubeInformerFactory.Core().V1().Secrets(),
 		kubeInformerFactory.Core().V1().ConfigMaps(),
 		kubeInformerFactory.Core().V1().ServiceAccounts(),
+		namespaceInformerFactory.Core().V1().Namespaces(),
 		edgenetInformerFactory.Core().V1alpha1().SubNamespaces())
 
 	kubeInformerFactory.Start(stopCh)
+	namespaceInformerFactory.Start(stopCh)
 	edgenetInformerFactory.Start(stopCh)
 
 	if err = controller.Run(2, stopCh); err != nil {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
 	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
@@ -27,12 +28,17 @@ import (
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 type TestGroup struct {
@@ -288,32 +294,109 @@ func TestPermissionSystem(t *testing.T) {
 		_, err := g.client.RbacV1().ClusterRoleBindings().Get(context.TODO(), fmt.Sprintf("edgenet:%s:tenants:%s-admin", tenant.GetName(), tenant.GetName()), metav1.GetOptions{})
 		util.OK(t, err)
 	})
+}
 
-	permissionCases := map[string]struct {
-		user         map[string]string
-		namespace    string
-		resource     string
-		resourceName string
-		scope        string
-		expected     bool
+func TestCheckAuthorization(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	reviews, status, err := recordSubjectAccessReviews(g.client.(*testclient.Clientset))
+
+	resourceAttributes := authorizationv1.ResourceAttributes{Namespace: g.namespace.GetName(), Verb: "update", Group: "core.edgenet.io", Resource: "subnamespaces"}
+	cases := map[string]struct {
+		status   authorizationv1.SubjectAccessReviewStatus
+		err      error
+		expected bool
 	}{
-		"owner/authorized for subnamespace":          {owner, g.namespace.GetName(), "subnamespaces", "", "namespace", true},
-		"collaborator/authorized for subnamespace":   {collaborator, g.namespace.GetName(), "subnamespaces", "", "namespace", false},
-		"owner/authorized for roles":                 {owner, g.namespace.GetName(), "roles", "", "namespace", true},
-		"collaborator/authorized for roles":          {collaborator, g.namespace.GetName(), "roles", "", "namespace", false},
-		"owner/authorized for role bindings":         {owner, g.namespace.GetName(), "rolebindings", "", "namespace", true},
-		"admin/authorized for role bindings":         {admin, g.namespace.GetName(), "rolebindings", "", "namespace", true},
-		"owner/authorized for cluster role bindings": {owner, "", "clusterrolebindings", "", "cluster", false},
-		"owner/authorized for tenant object":         {owner, "", "tenants", tenant.GetName(), "cluster", true},
-		"collaborator/authorized for tenant object":  {collaborator, "", "tenants", tenant.GetName(), "cluster", false},
-		"admin/authorized for tenant object":         {admin, "", "tenants", tenant.GetName(), "cluster", false},
+		"allowed":        {authorizationv1.SubjectAccessReviewStatus{Allowed: true}, nil, true},
+		"not allowed":    {authorizationv1.SubjectAccessReviewStatus{}, nil, false},
+		"denied":         {authorizationv1.SubjectAccessReviewStatus{Allowed: true, Denied: true}, nil, false},
+		"review failure": {authorizationv1.SubjectAccessReviewStatus{Allowed: true}, fmt.Errorf("unavailable"), false},
 	}
-	for k, tc := range permissionCases {
+	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			authorized := CheckAuthorization(tc.namespace, tc.user["Email"], tc.resource, tc.resourceName, tc.scope)
-			util.Equals(t, tc.expected, authorized)
+			*status, *err = tc.status, tc.err
+			util.Equals(t, tc.expected, CheckAuthorization("john.doe@edge-net.org", []string{"lab-members"}, resourceAttributes))
 		})
 	}
+	t.Run("review", func(t *testing.T) {
+		util.Equals(t, len(cases), len(*reviews))
+		for _, review := range *reviews {
+			util.Equals(t, "john.doe@edge-net.org", review.User)
+			util.Equals(t, []string{"lab-members"}, review.Groups)
+			util.Equals(t, resourceAttributes, *review.ResourceAttributes)
+		}
+	})
+}
+
+func TestAuthorizationCache(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	user := "john.doe@edge-net.org"
+	reviews, status, _ := recordSubjectAccessReviews(g.client.(*testclient.Clientset))
+	status.Allowed = true
+
+	stopCh := make(chan struct{})
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(g.client, 0)
+	WatchAuthorizationCache(kubeInformerFactory)
+	kubeInformerFactory.Start(stopCh)
+	time.Sleep(250 * time.Millisecond)
+	defer func() {
+		close(stopCh)
+		authorizationCache.Lock()
+		authorizationCache.enabled = false
+		authorizationCache.decisions = map[string]authorizationDecision{}
+		authorizationCache.Unlock()
+	}()
+
+	resourceAttributes := authorizationv1.ResourceAttributes{Namespace: g.namespace.GetName(), Verb: "create", Group: "core.edgenet.io", Resource: "subnamespaces"}
+	t.Run("cached decision", func(t *testing.T) {
+		util.Equals(t, true, CheckAuthorization(user, nil, resourceAttributes))
+		util.Equals(t, true, CheckAuthorization(user, nil, resourceAttributes))
+		util.Equals(t, 1, len(*reviews))
+	})
+	t.Run("flush on rbac change", func(t *testing.T) {
+		status.Allowed = false
+		util.OK(t, CreateObjectSpecificRoleBinding(g.tenant.GetName(), g.namespace.GetName(), "edgenet:tenant-collaborator", user))
+		time.Sleep(250 * time.Millisecond)
+		util.Equals(t, false, CheckAuthorization(user, nil, resourceAttributes))
+		util.Equals(t, 2, len(*reviews))
+	})
+	t.Run("expired decision", func(t *testing.T) {
+		authorizationCache.Lock()
+		for key, decision := range authorizationCache.decisions {
+			decision.expiry = time.Now()
+			authorizationCache.decisions[key] = decision
+		}
+		authorizationCache.Unlock()
+		util.Equals(t, false, CheckAuthorization(user, nil, resourceAttributes))
+		util.Equals(t, 3, len(*reviews))
+	})
+	t.Run("bounded cache", func(t *testing.T) {
+		for i := 0; i <= authorizationCacheSize; i++ {
+			CheckAuthorization(fmt.Sprintf("user-%d@edge-net.org", i), nil, resourceAttributes)
+		}
+		authorizationCache.RLock()
+		defer authorizationCache.RUnlock()
+		util.Equals(t, true, len(authorizationCache.decisions) <= authorizationCacheSize)
+	})
+}
+
+// recordSubjectAccessReviews keeps the specs of the subject access reviews sent to the API server, and answers them
+// with the returned status or error. The decisions of the API server are not in the scope of the tests.
+func recordSubjectAccessReviews(client *testclient.Clientset) (*[]authorizationv1.SubjectAccessReviewSpec, *authorizationv1.SubjectAccessReviewStatus, *error) {
+	reviews := []authorizationv1.SubjectAccessReviewSpec{}
+	status := authorizationv1.SubjectAccessReviewStatus{}
+	var err error
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+		reviews = append(reviews, review.Spec)
+		if err != nil {
+			return true, nil, err
+		}
+		review.Status = status
+		return true, review, nil
+	})
+	return &reviews, &status, &err
 }
 
 func TestApplyTenantResourceQuota(t *testing.T) {
@@ -334,3 +417,161 @@ func TestApplyTenantResourceQuota(t *testing.T) {
 	_, err = EdgenetClientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), g.tenantResourceQuotaObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 }
+
+func TestApprovalPolicy(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	approvalPolicy := registrationv1alpha1.ApprovalPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "universities",
+		},
+		Spec: registrationv1alpha1.ApprovalPolicySpec{
+			RequestKind: "TenantRequest",
+			Rules: []registrationv1alpha1.ApprovalRule{
+				{
+					EmailDomains: []string{"*.univ.fr"},
+					MaxResourceAllocation: map[corev1.ResourceName]resource.Quantity{
+						"cpu":    resource.MustParse("8000m"),
+						"memory": resource.MustParse("8Gi"),
+					},
+				},
+			},
+		},
+	}
+	EdgenetClientset.RegistrationV1alpha1().ApprovalPolicies().Create(context.TODO(), approvalPolicy.DeepCopy(), metav1.CreateOptions{})
+	collaborators := approvalPolicy.DeepCopy()
+	collaborators.SetName("collaborators")
+	collaborators.Spec.RequestKind = "RoleRequest"
+	collaborators.Spec.Rules = []registrationv1alpha1.ApprovalRule{
+		{
+			EmailDomains: []string{"edge-net.org"},
+			Roles:        []registrationv1alpha1.RoleRefSpec{{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"}},
+		},
+	}
+	EdgenetClientset.RegistrationV1alpha1().ApprovalPolicies().Create(context.TODO(), collaborators, metav1.CreateOptions{})
+
+	cases := map[string]struct {
+		email    string
+		cpu      string
+		verified bool
+		expected string
+	}{
+		"subdomain within limits":  {"tom.public@lip6.univ.fr", "4000m", true, "universities"},
+		"subdomain beyond limits":  {"tom.public@lip6.univ.fr", "12000m", true, ""},
+		"domain without subdomain": {"tom.public@univ.fr", "4000m", true, "universities"},
+		"domain as suffix":         {"tom.public@fakeuniv.fr", "4000m", true, ""},
+		"other domain":             {"tom.public@edge-net.org", "4000m", true, ""},
+		"email not verified":       {"tom.public@lip6.univ.fr", "4000m", false, ""},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			tenantRequest := g.tenantRequest.DeepCopy()
+			tenantRequest.Spec.Contact.Email = tc.email
+			tenantRequest.Status.EmailVerified = tc.verified
+			tenantRequest.Spec.ResourceAllocation = map[corev1.ResourceName]resource.Quantity{
+				"cpu": resource.MustParse(tc.cpu),
+			}
+			util.Equals(t, tc.expected, GetApprovalPolicyForTenantRequest(tenantRequest))
+		})
+	}
+
+	roleRequest := registrationv1alpha1.RoleRequest{
+		Spec: registrationv1alpha1.RoleRequestSpec{
+			Email:   "joe.public@edge-net.org",
+			RoleRef: registrationv1alpha1.RoleRefSpec{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"},
+		},
+		Status: registrationv1alpha1.RoleRequestStatus{EmailVerified: true},
+	}
+	util.Equals(t, "collaborators", GetApprovalPolicyForRoleRequest(roleRequest.DeepCopy()))
+	roleRequest.Spec.Subject = &registrationv1alpha1.RoleSubjectSpec{Kind: "Group", Name: "edge-net.org"}
+	util.Equals(t, "", GetApprovalPolicyForRoleRequest(roleRequest.DeepCopy()))
+	roleRequest.Spec.Subject = nil
+	roleRequest.Spec.RoleRef.Name = "edgenet:tenant-admin"
+	util.Equals(t, "", GetApprovalPolicyForRoleRequest(roleRequest.DeepCopy()))
+}
+
+func TestCheckRoleTemplateRules(t *testing.T) {
+	cases := map[string]struct {
+		rules    []rbacv1.PolicyRule
+		expected bool
+	}{
+		"no rules":             {[]rbacv1.PolicyRule{}, false},
+		"read pods":            {[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list", "watch"}}}, true},
+		"manage deployments":   {[]rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"*"}}}, true},
+		"named secret":         {[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"registry"}}}, true},
+		"wildcard api group":   {[]rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"pods"}, Verbs: []string{"get"}}}, false},
+		"wildcard resource":    {[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"*"}, Verbs: []string{"get"}}}, false},
+		"update tenant status": {[]rbacv1.PolicyRule{{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"tenants/status"}, Verbs: []string{"update"}}}, false},
+		"create cluster roles": {[]rbacv1.PolicyRule{{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}, Verbs: []string{"create"}}}, false},
+		"non-resource urls":    {[]rbacv1.PolicyRule{{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}}}, false},
+		"missing verbs":        {[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}}}, false},
+		"one exceeding rule":   {[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}, {APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"delete"}}}, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			util.Equals(t, tc.expected, CheckRoleTemplateRules(tc.rules) == nil)
+		})
+	}
+}
+
+func TestTenantMembers(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	EdgenetClientset.CoreV1alpha1().Tenants().Create(context.TODO(), g.tenantObj.DeepCopy(), metav1.CreateOptions{})
+	namespace := g.namespace.DeepCopy()
+	namespace.SetLabels(map[string]string{"edge-net.io/kind": "core", "edge-net.io/tenant": g.tenantObj.GetName()})
+	Clientset.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+	email := "jane.doe@edge-net.org"
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "edgenet:tenant-collaborator", Namespace: namespace.GetName(), Labels: labels},
+		Subjects: []rbacv1.Subject{{Kind: "User", Name: email, APIGroup: "rbac.authorization.k8s.io"}}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"}}
+	Clientset.RbacV1().RoleBindings(namespace.GetName()).Create(context.TODO(), roleBinding, metav1.CreateOptions{})
+
+	util.OK(t, AddTenantMember(g.tenantObj.GetName(), email, roleBinding.RoleRef.Name))
+	util.OK(t, AddTenantMember(g.tenantObj.GetName(), email, roleBinding.RoleRef.Name))
+	tenant, err := EdgenetClientset.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, 1, len(tenant.Status.Members))
+	util.Equals(t, true, IsTenantMember(tenant, "Jane.Doe@edge-net.org"))
+
+	bound, err := HasTenantRoleBinding(g.tenantObj.GetName(), email)
+	util.OK(t, err)
+	util.Equals(t, true, bound)
+	util.OK(t, RevokeTenantMemberRoles(g.tenantObj.GetName(), email))
+	bound, err = HasTenantRoleBinding(g.tenantObj.GetName(), email)
+	util.OK(t, err)
+	util.Equals(t, false, bound)
+	_, err = Clientset.RbacV1().RoleBindings(namespace.GetName()).Get(context.TODO(), roleBinding.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+
+	util.OK(t, RemoveTenantMember(g.tenantObj.GetName(), email))
+	tenant, err = EdgenetClientset.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, false, IsTenantMember(tenant, email))
+
+	t.Run("conflict", func(t *testing.T) {
+		// Each status update loses against a concurrent one once before it gets through
+		client := edgenettestclient.NewSimpleClientset(g.tenantObj.DeepCopy())
+		conflicted := false
+		client.PrependReactor("update", "tenants", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "status" || conflicted {
+				conflicted = false
+				return false, nil, nil
+			}
+			conflicted = true
+			return true, nil, errors.NewConflict(corev1alpha1.Resource("tenants"), g.tenantObj.GetName(), fmt.Errorf("the object has been modified"))
+		})
+		EdgenetClientset = client
+		defer func() { EdgenetClientset = g.edgenetclient }()
+
+		util.OK(t, AddTenantMember(g.tenantObj.GetName(), email, roleBinding.RoleRef.Name))
+		tenant, err := client.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, IsTenantMember(tenant, email))
+		util.OK(t, RemoveTenantMember(g.tenantObj.GetName(), email))
+		tenant, err = client.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, false, IsTenantMember(tenant, email))
+	})
+}
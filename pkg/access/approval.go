@@ -0,0 +1,131 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// Kinds of the requests that approval policies can approve
+const (
+	tenantRequestKind = "TenantRequest"
+	roleRequestKind   = "RoleRequest"
+)
+
+// GetApprovalPolicyForTenantRequest returns the name of the approval policy that approves the tenant request automatically.
+// It returns an empty string if no policy approves the request, or if the contact email is not verified yet.
+func GetApprovalPolicyForTenantRequest(tenantRequest *registrationv1alpha1.TenantRequest) string {
+	if !tenantRequest.Status.EmailVerified {
+		return ""
+	}
+	return getApprovalPolicy(tenantRequestKind, func(rule registrationv1alpha1.ApprovalRule) bool {
+		if len(rule.Roles) != 0 || (len(rule.MaxResourceAllocation) == 0 && len(rule.EmailDomains) == 0) {
+			return false
+		}
+		if len(rule.EmailDomains) != 0 && !matchEmailDomain(rule.EmailDomains, tenantRequest.Spec.Contact.Email) {
+			return false
+		}
+		if len(rule.MaxResourceAllocation) != 0 {
+			for resourceName, quantity := range tenantRequest.Spec.ResourceAllocation {
+				limit, elementExists := rule.MaxResourceAllocation[resourceName]
+				if !elementExists || quantity.Cmp(limit) > 0 {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// GetApprovalPolicyForRoleRequest returns the name of the approval policy that approves the role request automatically.
+// It returns an empty string if no policy approves the request, or if the requester email is not verified yet.
+// Requests for a group or a service account always wait for manual approval, as the email of the requester says nothing about them.
+func GetApprovalPolicyForRoleRequest(roleRequest *registrationv1alpha1.RoleRequest) string {
+	if !roleRequest.Status.EmailVerified || roleRequest.Spec.Subject != nil {
+		return ""
+	}
+	return getApprovalPolicy(roleRequestKind, func(rule registrationv1alpha1.ApprovalRule) bool {
+		if len(rule.MaxResourceAllocation) != 0 || (len(rule.Roles) == 0 && len(rule.EmailDomains) == 0) {
+			return false
+		}
+		if len(rule.EmailDomains) != 0 && !matchEmailDomain(rule.EmailDomains, roleRequest.Spec.Email) {
+			return false
+		}
+		if len(rule.Roles) != 0 {
+			for _, roleRef := range rule.Roles {
+				if roleRef.Kind == roleRequest.Spec.RoleRef.Kind && roleRef.Name == roleRequest.Spec.RoleRef.Name {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// getApprovalPolicy goes through the approval policies of the given request kind in alphabetical order
+// and returns the name of the first one having a rule that the request satisfies
+func getApprovalPolicy(requestKind string, satisfies func(registrationv1alpha1.ApprovalRule) bool) string {
+	approvalPolicyRaw, err := EdgenetClientset.RegistrationV1alpha1().ApprovalPolicies().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		klog.Infoln(err)
+		return ""
+	}
+	sort.Slice(approvalPolicyRaw.Items, func(i, j int) bool {
+		return approvalPolicyRaw.Items[i].GetName() < approvalPolicyRaw.Items[j].GetName()
+	})
+	for _, approvalPolicyRow := range approvalPolicyRaw.Items {
+		if approvalPolicyRow.Spec.RequestKind != requestKind {
+			continue
+		}
+		for _, rule := range approvalPolicyRow.Spec.Rules {
+			if satisfies(rule) {
+				return approvalPolicyRow.GetName()
+			}
+		}
+	}
+	return ""
+}
+
+// matchEmailDomain checks whether the domain of the email address matches any of the given domains.
+// A domain starting with '*.' matches the domain itself and its subdomains.
+func matchEmailDomain(domains []string, email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return false
+	}
+	emailDomain := strings.ToLower(email[at+1:])
+	for _, domain := range domains {
+		domain = strings.ToLower(domain)
+		if strings.HasPrefix(domain, "*.") {
+			apexDomain := domain[2:]
+			if emailDomain == apexDomain || strings.HasSuffix(emailDomain, "."+apexDomain) {
+				return true
+			}
+		} else if emailDomain == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -20,83 +20,137 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
 )
 
 var labels = map[string]string{"edge-net.io/generated": "true"}
 
-// CheckAuthorization returns true if the user is holder of a role
-func CheckAuthorization(namespace, email, resource, resourceName, scope string) bool {
-	authorized := false
+// The cached decisions expire after a while, and the cache holds a limited number of them
+const (
+	authorizationCacheTTL  = 5 * time.Minute
+	authorizationCacheSize = 4096
+)
 
-	checkRules := func(rule rbacv1.PolicyRule) {
-		for _, ruleResource := range rule.Resources {
-			if ruleResource == resource {
-				for _, verb := range rule.Verbs {
-					if verb == "create" || verb == "update" || verb == "patch" || verb == "delete" || verb == "*" {
-						if len(rule.ResourceNames) > 0 {
-							for _, ruleResourceName := range rule.ResourceNames {
-								if ruleResourceName == resourceName {
-									authorized = true
-								}
-							}
-						} else {
-							authorized = true
-						}
-					}
-				}
-			}
-		}
+// authorizationDecision is a decision of the API server along with the time it expires in the cache
+type authorizationDecision struct {
+	allowed bool
+	expiry  time.Time
+}
+
+// authorizationCache keeps the decisions of the API server until an RBAC object changes or they expire
+var authorizationCache = struct {
+	sync.RWMutex
+	enabled   bool
+	decisions map[string]authorizationDecision
+}{decisions: map[string]authorizationDecision{}}
+
+// CheckAuthorization returns true if the API server allows the user, or one of the given groups, to perform the action
+// described by the resource attributes. The decision comes from a SubjectAccessReview so that groups, aggregated cluster roles,
+// API groups, and wildcards are taken into account. Decisions are cached once WatchAuthorizationCache is set up.
+func CheckAuthorization(user string, groups []string, resourceAttributes authorizationv1.ResourceAttributes) bool {
+	key := authorizationCacheKey(user, groups, resourceAttributes)
+	authorizationCache.RLock()
+	decision, cached := authorizationCache.decisions[key]
+	enabled := authorizationCache.enabled
+	authorizationCache.RUnlock()
+	if enabled && cached && time.Now().Before(decision.expiry) {
+		return decision.allowed
 	}
-	if scope == "namespace" {
-		roleBindingRaw, _ := Clientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
-		for _, roleBindingRow := range roleBindingRaw.Items {
-			for _, subject := range roleBindingRow.Subjects {
-				if subject.Kind == "User" && subject.Name == email {
-					if roleBindingRow.RoleRef.Kind == "Role" {
-						role, err := Clientset.RbacV1().Roles(namespace).Get(context.TODO(), roleBindingRow.RoleRef.Name, metav1.GetOptions{})
-						if err == nil {
-							for _, rule := range role.Rules {
-								checkRules(rule)
-							}
-						}
-					} else if roleBindingRow.RoleRef.Kind == "ClusterRole" {
-						role, err := Clientset.RbacV1().ClusterRoles().Get(context.TODO(), roleBindingRow.RoleRef.Name, metav1.GetOptions{})
-						if err == nil {
-							for _, rule := range role.Rules {
-								checkRules(rule)
-							}
-						}
-					}
-				}
-			}
+
+	subjectAccessReview := new(authorizationv1.SubjectAccessReview)
+	subjectAccessReview.Spec.User = user
+	subjectAccessReview.Spec.Groups = groups
+	subjectAccessReview.Spec.ResourceAttributes = &resourceAttributes
+	subjectAccessReviewResult, err := Clientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), subjectAccessReview, metav1.CreateOptions{})
+	if err != nil {
+		klog.Infoln(err)
+		return false
+	}
+	allowed := subjectAccessReviewResult.Status.Allowed && !subjectAccessReviewResult.Status.Denied
+
+	if enabled {
+		authorizationCache.Lock()
+		if len(authorizationCache.decisions) >= authorizationCacheSize {
+			evictAuthorizationDecisions()
 		}
-	} else {
-		clusterRoleBindingRaw, _ := Clientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
-		for _, clusterRoleBindingRow := range clusterRoleBindingRaw.Items {
-			for _, subject := range clusterRoleBindingRow.Subjects {
-				if subject.Kind == "User" && subject.Name == email {
-					clusterRole, err := Clientset.RbacV1().ClusterRoles().Get(context.TODO(), clusterRoleBindingRow.RoleRef.Name, metav1.GetOptions{})
-					if err == nil {
-						for _, rule := range clusterRole.Rules {
-							checkRules(rule)
-						}
-					}
-				}
-			}
+		authorizationCache.decisions[key] = authorizationDecision{allowed: allowed, expiry: time.Now().Add(authorizationCacheTTL)}
+		authorizationCache.Unlock()
+	}
+	return allowed
+}
+
+// evictAuthorizationDecisions drops the expired decisions, and all of them if the cache is still full.
+// The caller must hold the lock of the cache.
+func evictAuthorizationDecisions() {
+	now := time.Now()
+	for key, decision := range authorizationCache.decisions {
+		if !now.Before(decision.expiry) {
+			delete(authorizationCache.decisions, key)
 		}
+	}
+	if len(authorizationCache.decisions) >= authorizationCacheSize {
+		authorizationCache.decisions = map[string]authorizationDecision{}
+	}
+}
 
+// WatchAuthorizationCache enables caching the authorization decisions and flushes the cache
+// whenever a role, a cluster role, or one of their bindings changes
+func WatchAuthorizationCache(kubeInformerFactory kubeinformers.SharedInformerFactory) {
+	flush := func() {
+		authorizationCache.Lock()
+		authorizationCache.decisions = map[string]authorizationDecision{}
+		authorizationCache.Unlock()
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			flush()
+		},
+		UpdateFunc: func(old, new interface{}) {
+			flush()
+		},
+		DeleteFunc: func(obj interface{}) {
+			flush()
+		},
 	}
-	return authorized
+	kubeInformerFactory.Rbac().V1().Roles().Informer().AddEventHandler(handler)
+	kubeInformerFactory.Rbac().V1().RoleBindings().Informer().AddEventHandler(handler)
+	kubeInformerFactory.Rbac().V1().ClusterRoles().Informer().AddEventHandler(handler)
+	kubeInformerFactory.Rbac().V1().ClusterRoleBindings().Informer().AddEventHandler(handler)
+
+	authorizationCache.Lock()
+	authorizationCache.enabled = true
+	authorizationCache.Unlock()
 }
 
-// CreateClusterRoles generate a cluster role for tenant owners, admins, and collaborators
-func CreateClusterRoles() error {
-	policyRule := []rbacv1.PolicyRule{{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"subnamespaces"}, Verbs: []string{"*"}},
+// authorizationCacheKey generates the key under which the decision for the given request is cached
+func authorizationCacheKey(user string, groups []string, resourceAttributes authorizationv1.ResourceAttributes) string {
+	sortedGroups := append([]string{}, groups...)
+	sort.Strings(sortedGroups)
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s", user, strings.Join(sortedGroups, ","), resourceAttributes.Namespace,
+		resourceAttributes.Verb, resourceAttributes.Group, resourceAttributes.Version, resourceAttributes.Resource,
+		resourceAttributes.Subresource, resourceAttributes.Name)
+}
+
+// getTenantOwnerPolicyRules returns the policy rules of the tenant owner and admin roles.
+// These rules also set the upper bound of the rules that role templates can grant.
+func getTenantOwnerPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"subnamespaces"}, Verbs: []string{"*"}},
 		{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"subnamespaces/status"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"roletemplates"}, Verbs: []string{"*"}},
+		{APIGroups: []string{"core.edgenet.io"}, Resources: []string{"roletemplates/status"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"quotarequests"}, Verbs: []string{"create", "get", "list", "watch", "delete"}},
+		{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"quotarequests/status"}, Verbs: []string{"get", "list", "watch"}},
 		{APIGroups: []string{"apps.edgenet.io"}, Resources: []string{"selectivedeployments"}, Verbs: []string{"*"}},
 		{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles", "rolebindings"}, Verbs: []string{"*"}},
 		{APIGroups: []string{""}, Resources: []string{"configmaps", "endpoints", "persistentvolumeclaims", "pods", "pods/exec", "pods/log", "pods/attach", "pods/portforward", "replicationcontrollers", "services", "secrets", "serviceaccounts"}, Verbs: []string{"*"}},
@@ -106,7 +160,15 @@ func CreateClusterRoles() error {
 		{APIGroups: []string{"extensions"}, Resources: []string{"daemonsets", "deployments", "ingresses", "networkpolicies", "replicasets", "replicationcontrollers"}, Verbs: []string{"*"}},
 		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses", "networkpolicies"}, Verbs: []string{"*"}},
 		{APIGroups: []string{""}, Resources: []string{"events", "controllerrevisions"}, Verbs: []string{"get", "list", "watch"}}}
-	ownerRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edgenet:tenant-owner"}, Rules: policyRule}
+}
+
+// CreateClusterRoles generate a cluster role for tenant owners, admins, and collaborators
+func CreateClusterRoles() error {
+	policyRule := getTenantOwnerPolicyRules()
+	// Only the tenant owner can hand the tenant over to someone else
+	ownerPolicyRule := append([]rbacv1.PolicyRule{{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"ownershiptransferrequests"}, Verbs: []string{"create", "get", "list", "watch", "delete"}},
+		{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"ownershiptransferrequests/status"}, Verbs: []string{"get", "list", "watch"}}}, policyRule...)
+	ownerRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edgenet:tenant-owner"}, Rules: ownerPolicyRule}
 	ownerRole.SetLabels(labels)
 	_, err := Clientset.RbacV1().ClusterRoles().Create(context.TODO(), ownerRole, metav1.CreateOptions{})
 	if err != nil {
@@ -114,7 +176,7 @@ func CreateClusterRoles() error {
 		if errors.IsAlreadyExists(err) {
 			currentClusterRole, err := Clientset.RbacV1().ClusterRoles().Get(context.TODO(), ownerRole.GetName(), metav1.GetOptions{})
 			if err == nil {
-				currentClusterRole.Rules = policyRule
+				currentClusterRole.Rules = ownerPolicyRule
 				_, err = Clientset.RbacV1().ClusterRoles().Update(context.TODO(), currentClusterRole, metav1.UpdateOptions{})
 				if err == nil {
 					log.Println("Tenant owner cluster role updated")
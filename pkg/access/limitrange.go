@@ -0,0 +1,53 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"context"
+	"reflect"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplyTenantLimitRange keeps the limit range of the tenant in the namespace in line with the default container resources
+// of the tenant, deleting it if the tenant sets none
+func ApplyTenantLimitRange(tenant *corev1alpha1.Tenant, namespace string) error {
+	limitRange := tenant.MakeLimitRange(namespace)
+	if limitRange == nil {
+		if err := Clientset.CoreV1().LimitRanges(namespace).Delete(context.TODO(), corev1alpha1.TenantLimitRange, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	if currentLimitRange, err := Clientset.CoreV1().LimitRanges(namespace).Get(context.TODO(), limitRange.GetName(), metav1.GetOptions{}); err == nil {
+		if !reflect.DeepEqual(currentLimitRange.Spec, limitRange.Spec) {
+			currentLimitRangeCopy := currentLimitRange.DeepCopy()
+			currentLimitRangeCopy.Spec = limitRange.Spec
+			if _, err := Clientset.CoreV1().LimitRanges(namespace).Update(context.TODO(), currentLimitRangeCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	_, err := Clientset.CoreV1().LimitRanges(namespace).Create(context.TODO(), limitRange, metav1.CreateOptions{})
+	return err
+}
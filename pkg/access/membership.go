@@ -0,0 +1,159 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// AddTenantMember adds the user to the members of the tenant unless the user is already a member.
+// The tenant is read again whenever another change to it gets in first.
+func AddTenantMember(tenant, email, role string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tenantObj, err := EdgenetClientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if IsTenantMember(tenantObj, email) {
+			return nil
+		}
+		tenantCopy := tenantObj.DeepCopy()
+		tenantCopy.Status.Members = append(tenantCopy.Status.Members, corev1alpha1.Member{Email: email, Role: role, Joined: metav1.Now()})
+		_, err = EdgenetClientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// RemoveTenantMember removes the user from the members of the tenant if the user is a member.
+// The tenant is read again whenever another change to it gets in first.
+func RemoveTenantMember(tenant, email string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tenantObj, err := EdgenetClientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !IsTenantMember(tenantObj, email) {
+			return nil
+		}
+		tenantCopy := tenantObj.DeepCopy()
+		tenantCopy.Status.Members = []corev1alpha1.Member{}
+		for _, member := range tenantObj.Status.Members {
+			if !strings.EqualFold(member.Email, email) {
+				tenantCopy.Status.Members = append(tenantCopy.Status.Members, member)
+			}
+		}
+		_, err = EdgenetClientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// IsTenantMember checks whether the user is in the members of the tenant
+func IsTenantMember(tenant *corev1alpha1.Tenant, email string) bool {
+	for _, member := range tenant.Status.Members {
+		if strings.EqualFold(member.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTenantRoleBinding checks whether any role binding that EdgeNet generates in the core or subsidiary
+// namespaces of the tenant binds a role to the user
+func HasTenantRoleBinding(tenant, email string) (bool, error) {
+	namespaceRaw, err := Clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenant)})
+	if err != nil {
+		return false, err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		roleBindingRaw, err := Clientset.RbacV1().RoleBindings(namespaceRow.GetName()).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"})
+		if err != nil {
+			return false, err
+		}
+		for _, roleBindingRow := range roleBindingRaw.Items {
+			for _, subjectRow := range roleBindingRow.Subjects {
+				if isUserSubject(subjectRow, email) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// RevokeTenantMemberRoles removes the user from the role bindings that EdgeNet generates in the core and subsidiary
+// namespaces of the tenant, deleting the ones left without subjects. The role requests of the user in these
+// namespaces are deleted as well so that they do not bind the roles again.
+func RevokeTenantMemberRoles(tenant, email string) error {
+	namespaceRaw, err := Clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenant)})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		roleRequestRaw, err := EdgenetClientset.RegistrationV1alpha1().RoleRequests(namespaceRow.GetName()).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, roleRequestRow := range roleRequestRaw.Items {
+			if roleRequestRow.Spec.Subject == nil && strings.EqualFold(roleRequestRow.Spec.Email, email) {
+				err := EdgenetClientset.RegistrationV1alpha1().RoleRequests(roleRequestRow.GetNamespace()).Delete(context.TODO(), roleRequestRow.GetName(), metav1.DeleteOptions{})
+				if err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+
+		roleBindingRaw, err := Clientset.RbacV1().RoleBindings(namespaceRow.GetName()).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"})
+		if err != nil {
+			return err
+		}
+		for _, roleBindingRow := range roleBindingRaw.Items {
+			roleBindingCopy := roleBindingRow.DeepCopy()
+			roleBindingCopy.Subjects = []rbacv1.Subject{}
+			for _, subjectRow := range roleBindingRow.Subjects {
+				if !isUserSubject(subjectRow, email) {
+					roleBindingCopy.Subjects = append(roleBindingCopy.Subjects, subjectRow)
+				}
+			}
+			if len(roleBindingCopy.Subjects) == len(roleBindingRow.Subjects) {
+				continue
+			}
+			if len(roleBindingCopy.Subjects) == 0 {
+				err = Clientset.RbacV1().RoleBindings(roleBindingCopy.GetNamespace()).Delete(context.TODO(), roleBindingCopy.GetName(), metav1.DeleteOptions{})
+			} else {
+				_, err = Clientset.RbacV1().RoleBindings(roleBindingCopy.GetNamespace()).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{})
+			}
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isUserSubject checks whether the subject refers to the user with the given email address
+func isUserSubject(subject rbacv1.Subject, email string) bool {
+	return subject.Kind == "User" && strings.EqualFold(subject.Name, email)
+}
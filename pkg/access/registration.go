@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
 	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
@@ -50,6 +51,7 @@ func CreateTenant(tenantRequest *registrationv1alpha1.TenantRequest) error {
 	tenant.Spec.ShortName = tenantRequest.Spec.ShortName
 	tenant.Spec.URL = tenantRequest.Spec.URL
 	tenant.Spec.ClusterNetworkPolicy = tenantRequest.Spec.ClusterNetworkPolicy
+	tenant.Spec.NetworkProfile = tenantRequest.Spec.NetworkProfile
 	tenant.Spec.Enabled = true
 	tenant.SetAnnotations(tenantRequest.GetAnnotations())
 	if tenantRequest.GetOwnerReferences() != nil && len(tenantRequest.GetOwnerReferences()) > 0 {
@@ -140,9 +142,14 @@ func SendEmailForRoleRequest(roleRequestCopy *registrationv1alpha1.RoleRequest,
 	email.LastName = roleRequestCopy.Spec.LastName
 	email.Subject = subject
 	email.Recipient = recipient
+	email.Reason = roleRequestCopy.Spec.Reason
 	email.RoleRequest = new(mailer.RoleRequest)
 	email.RoleRequest.Name = roleRequestCopy.GetName()
 	email.RoleRequest.Namespace = roleRequestCopy.GetNamespace()
+	email.RoleRequest.Subject = describeRoleSubject(roleRequestCopy)
+	if roleRequestCopy.Status.GrantExpiry != nil {
+		email.RoleRequest.GrantExpiry = roleRequestCopy.Status.GrantExpiry.Format(time.RFC1123)
+	}
 	email.Send(purpose)
 }
 
@@ -154,6 +161,7 @@ func SendEmailForTenantRequest(tenantRequestCopy *registrationv1alpha1.TenantReq
 	email.LastName = tenantRequestCopy.Spec.Contact.LastName
 	email.Subject = subject
 	email.Recipient = recipient
+	email.Reason = tenantRequestCopy.Spec.Reason
 	email.TenantRequest = new(mailer.TenantRequest)
 	email.TenantRequest.Tenant = tenantRequestCopy.GetName()
 	email.Send(purpose)
@@ -167,8 +175,70 @@ func SendEmailForClusterRoleRequest(clusterRoleRequestCopy *registrationv1alpha1
 	email.LastName = clusterRoleRequestCopy.Spec.LastName
 	email.Subject = subject
 	email.Recipient = recipient
+	email.Reason = clusterRoleRequestCopy.Spec.Reason
 	email.ClusterRoleRequest = new(mailer.ClusterRoleRequest)
 	email.ClusterRoleRequest.Name = clusterRoleRequestCopy.GetName()
+	if clusterRoleRequestCopy.Status.GrantExpiry != nil {
+		email.ClusterRoleRequest.GrantExpiry = clusterRoleRequestCopy.Status.GrantExpiry.Format(time.RFC1123)
+	}
+	email.Send(purpose)
+}
+
+func SendEmailForQuotaRequest(quotaRequestCopy *registrationv1alpha1.QuotaRequest, purpose, subject, clusterUID string, recipient []string) {
+	email := new(mailer.Content)
+	email.Cluster = clusterUID
+	email.User = quotaRequestCopy.Spec.Email
+	email.FirstName = quotaRequestCopy.Spec.FirstName
+	email.LastName = quotaRequestCopy.Spec.LastName
+	email.Subject = subject
+	email.Recipient = recipient
+	email.QuotaRequest = new(mailer.QuotaRequest)
+	email.QuotaRequest.Name = quotaRequestCopy.GetName()
+	email.QuotaRequest.Namespace = quotaRequestCopy.GetNamespace()
+	email.Send(purpose)
+}
+
+func SendEmailForOwnershipTransferRequest(ownershipTransferRequestCopy *registrationv1alpha1.OwnershipTransferRequest, previousOwner, purpose, subject, clusterUID string, recipient []string) {
+	email := new(mailer.Content)
+	email.Cluster = clusterUID
+	email.User = ownershipTransferRequestCopy.Spec.NewOwner.Email
+	email.FirstName = ownershipTransferRequestCopy.Spec.NewOwner.FirstName
+	email.LastName = ownershipTransferRequestCopy.Spec.NewOwner.LastName
+	email.Subject = subject
+	email.Recipient = recipient
+	email.OwnershipTransfer = new(mailer.OwnershipTransfer)
+	email.OwnershipTransfer.Name = ownershipTransferRequestCopy.GetName()
+	email.OwnershipTransfer.Namespace = ownershipTransferRequestCopy.GetNamespace()
+	email.OwnershipTransfer.Tenant = ownershipTransferRequestCopy.GetNamespace()
+	email.OwnershipTransfer.PreviousOwner = previousOwner
+	email.Send(purpose)
+}
+
+func SendEmailForTenantOwnershipTransfer(tenantCopy *corev1alpha1.Tenant, previousOwner, purpose, subject, clusterUID string, recipient []string) {
+	email := new(mailer.Content)
+	email.Cluster = clusterUID
+	email.User = tenantCopy.Spec.Contact.Email
+	email.FirstName = tenantCopy.Spec.Contact.FirstName
+	email.LastName = tenantCopy.Spec.Contact.LastName
+	email.Subject = subject
+	email.Recipient = recipient
+	email.OwnershipTransfer = new(mailer.OwnershipTransfer)
+	email.OwnershipTransfer.Tenant = tenantCopy.GetName()
+	email.OwnershipTransfer.PreviousOwner = previousOwner
+	email.Send(purpose)
+}
+
+func SendEmailForTenantResourceQuota(tenantCopy *corev1alpha1.Tenant, namespaces []string, purpose, subject, clusterUID string, recipient []string) {
+	email := new(mailer.Content)
+	email.Cluster = clusterUID
+	email.User = tenantCopy.Spec.Contact.Email
+	email.FirstName = tenantCopy.Spec.Contact.FirstName
+	email.LastName = tenantCopy.Spec.Contact.LastName
+	email.Subject = subject
+	email.Recipient = recipient
+	email.TenantResourceQuota = new(mailer.TenantResourceQuota)
+	email.TenantResourceQuota.Tenant = tenantCopy.GetName()
+	email.TenantResourceQuota.Namespaces = namespaces
 	email.Send(purpose)
 }
 
@@ -182,9 +252,11 @@ func SendSlackNotificationForRoleRequest(roleRequestCopy *registrationv1alpha1.R
 	slackNotification.Subject = subject
 	slackNotification.AuthToken = os.Getenv(slack.AUTH_TOKEN_IDENTIFIER)
 	slackNotification.ChannelId = os.Getenv(slack.CHANNEL_ID_IDENTIFIER)
+	slackNotification.Reason = roleRequestCopy.Spec.Reason
 	slackNotification.RoleRequest = new(slack.RoleRequest)
 	slackNotification.RoleRequest.Name = roleRequestCopy.GetName()
 	slackNotification.RoleRequest.Namespace = roleRequestCopy.GetNamespace()
+	slackNotification.RoleRequest.Subject = describeRoleSubject(roleRequestCopy)
 	slackNotification.Send(purpose)
 }
 
@@ -198,6 +270,7 @@ func SendSlackNotificationForTenantRequest(tenantRequestCopy *registrationv1alph
 	slackNotification.Subject = subject
 	slackNotification.AuthToken = os.Getenv(slack.AUTH_TOKEN_IDENTIFIER)
 	slackNotification.ChannelId = os.Getenv(slack.CHANNEL_ID_IDENTIFIER)
+	slackNotification.Reason = tenantRequestCopy.Spec.Reason
 	slackNotification.TenantRequest = new(slack.TenantRequest)
 	slackNotification.TenantRequest.Tenant = tenantRequestCopy.GetName()
 	slackNotification.Send(purpose)
@@ -213,7 +286,40 @@ func SendSlackNotificationForClusterRoleRequest(clusterRoleRequestCopy *registra
 	slackNotification.Subject = subject
 	slackNotification.AuthToken = os.Getenv(slack.AUTH_TOKEN_IDENTIFIER)
 	slackNotification.ChannelId = os.Getenv(slack.CHANNEL_ID_IDENTIFIER)
+	slackNotification.Reason = clusterRoleRequestCopy.Spec.Reason
 	slackNotification.ClusterRolerequest = new(slack.ClusterRoleRequest)
 	slackNotification.ClusterRolerequest.Name = clusterRoleRequestCopy.GetName()
 	slackNotification.Send(purpose)
 }
+
+// Send a slack notification for quota request
+func SendSlackNotificationForQuotaRequest(quotaRequestCopy *registrationv1alpha1.QuotaRequest, purpose, subject, clusterUID string) {
+	slackNotification := new(slack.Content)
+	slackNotification.Cluster = clusterUID
+	slackNotification.User = quotaRequestCopy.Spec.Email
+	slackNotification.FirstName = quotaRequestCopy.Spec.FirstName
+	slackNotification.LastName = quotaRequestCopy.Spec.LastName
+	slackNotification.Subject = subject
+	slackNotification.AuthToken = os.Getenv(slack.AUTH_TOKEN_IDENTIFIER)
+	slackNotification.ChannelId = os.Getenv(slack.CHANNEL_ID_IDENTIFIER)
+	slackNotification.QuotaRequest = new(slack.QuotaRequest)
+	slackNotification.QuotaRequest.Name = quotaRequestCopy.GetName()
+	slackNotification.QuotaRequest.Namespace = quotaRequestCopy.GetNamespace()
+	slackNotification.Send(purpose)
+}
+
+// describeRoleSubject returns the group or service account that a role request is made for in a readable form.
+// It returns an empty string if the role is requested for the user making the request.
+func describeRoleSubject(roleRequestCopy *registrationv1alpha1.RoleRequest) string {
+	if roleRequestCopy.Spec.Subject == nil {
+		return ""
+	}
+	if roleRequestCopy.Spec.Subject.Kind == "ServiceAccount" {
+		namespace := roleRequestCopy.Spec.Subject.Namespace
+		if namespace == "" {
+			namespace = roleRequestCopy.GetNamespace()
+		}
+		return fmt.Sprintf("%s %s/%s", roleRequestCopy.Spec.Subject.Kind, namespace, roleRequestCopy.Spec.Subject.Name)
+	}
+	return fmt.Sprintf("%s %s", roleRequestCopy.Spec.Subject.Kind, roleRequestCopy.Spec.Subject.Name)
+}
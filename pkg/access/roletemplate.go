@@ -0,0 +1,95 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package access
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// GetRoleTemplateRoleName returns the name of the role that materializes the role template in a namespace
+func GetRoleTemplateRoleName(roleTemplate string) string {
+	return fmt.Sprintf("edgenet:role-template:%s", roleTemplate)
+}
+
+// CheckRoleTemplateRules returns an error if any of the given rules grants more than the tenant owner role does.
+// A rule is within bounds when each combination of its API groups, resources, and verbs is granted by a tenant owner rule.
+func CheckRoleTemplateRules(rules []rbacv1.PolicyRule) error {
+	if len(rules) == 0 {
+		return fmt.Errorf("role template must have at least one rule")
+	}
+	ownerRules := getTenantOwnerPolicyRules()
+	for _, rule := range rules {
+		if len(rule.NonResourceURLs) != 0 {
+			return fmt.Errorf("role template cannot grant access to non-resource URLs")
+		}
+		if len(rule.APIGroups) == 0 || len(rule.Resources) == 0 || len(rule.Verbs) == 0 {
+			return fmt.Errorf("role template rules must have API groups, resources, and verbs")
+		}
+		for _, apiGroup := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					if !isGrantedBy(ownerRules, apiGroup, resource, verb, rule.ResourceNames) {
+						return fmt.Errorf("role template cannot grant %q on %q in API group %q as it exceeds the tenant owner role", verb, resource, apiGroup)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isGrantedBy checks whether one of the rules grants the verb on the resource, limited to the resource names if any
+func isGrantedBy(rules []rbacv1.PolicyRule, apiGroup, resource, verb string, resourceNames []string) bool {
+	for _, rule := range rules {
+		if !hasRuleElement(rule.APIGroups, apiGroup) || !hasRuleElement(rule.Resources, resource) || !hasRuleElement(rule.Verbs, verb) {
+			continue
+		}
+		if len(rule.ResourceNames) == 0 {
+			return true
+		}
+		if len(resourceNames) == 0 {
+			continue
+		}
+		granted := true
+		for _, resourceName := range resourceNames {
+			named := false
+			for _, ruleResourceName := range rule.ResourceNames {
+				if ruleResourceName == resourceName {
+					named = true
+					break
+				}
+			}
+			granted = granted && named
+		}
+		if granted {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRuleElement checks whether the elements of a rule contain the value, either explicitly or by a wildcard
+func hasRuleElement(elements []string, value string) bool {
+	for _, element := range elements {
+		if element == rbacv1.ResourceAll || element == value {
+			return true
+		}
+	}
+	return false
+}
@@ -11,15 +11,18 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/EdgeNet-project/edgenet/pkg/access"
 	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
 	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 )
 
@@ -50,6 +53,10 @@ func (wh *Webhook) RunServer() {
 	http.HandleFunc("/validate/subnamespace", wh.validateSubNamespace)
 	http.HandleFunc("/validate/slice", wh.validateSlice)
 	http.HandleFunc("/validate/slice-claim", wh.validateSliceClaim)
+	http.HandleFunc("/validate/role-template", wh.validateRoleTemplate)
+	http.HandleFunc("/validate/tenant", wh.validateTenant)
+	http.HandleFunc("/validate/ownership-transfer-request", wh.validateOwnershipTransferRequest)
+	http.HandleFunc("/validate/quota-request", wh.validateQuotaRequest)
 
 	server := http.Server{
 		Addr: ":443",
@@ -427,6 +434,38 @@ func (wh *Webhook) validateRoleRequest(w http.ResponseWriter, r *http.Request) {
 			Message: "role request cannot be approved at creation",
 		}
 	}
+	if strings.HasPrefix(rolerequest.Spec.Email, "system:") {
+		admissionResponse.Allowed = false
+		admissionResponse.Result = &metav1.Status{
+			Message: fmt.Sprintf("role request cannot be made for the system user %q", rolerequest.Spec.Email),
+		}
+	}
+	if rolerequest.Spec.Subject != nil {
+		if err := validateRoleSubject(rolerequest.Spec.Subject); err != nil {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: err.Error(),
+			}
+		}
+	}
+
+	if admissionReviewRequest.Request.Operation == "UPDATE" || admissionReviewRequest.Request.Operation == "PATCH" {
+		oldObjectRaw := admissionReviewRequest.Request.OldObject.Raw
+		oldRolerequest := new(registrationv1alpha1.RoleRequest)
+		if _, _, err := deserializer.Decode(oldObjectRaw, nil, oldRolerequest); err != nil {
+			klog.Errorf("old rolerequest decode error: %v", err)
+			w.WriteHeader(400)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		if !reflect.DeepEqual(oldRolerequest.Spec.Subject, rolerequest.Spec.Subject) {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "role request subject cannot be changed after creation",
+			}
+		}
+	}
 
 	var admissionReviewResponse admissionv1.AdmissionReview
 	admissionReviewResponse.Response = admissionResponse
@@ -482,6 +521,13 @@ func (wh *Webhook) validateSubNamespace(w http.ResponseWriter, r *http.Request)
 				Message: "subsidiary namespace slice and resource allocation cannot be set at creation",
 			}
 		}
+		// Only the subnamespace controller recreates a moved subnamespace along with the name of its existing child
+		if _, elementExists := subnamespace.GetAnnotations()[corev1alpha1.SubNamespaceChildName]; elementExists && admissionReviewRequest.Request.UserInfo.Username != "system:serviceaccount:edgenet:subnamespace" {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "subsidiary namespace child name cannot be set",
+			}
+		}
 	}
 
 	if admissionReviewRequest.Request.Operation == "UPDATE" || admissionReviewRequest.Request.Operation == "PATCH" {
@@ -507,7 +553,7 @@ func (wh *Webhook) validateSubNamespace(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		if *oldSubnamespace.GetSliceClaim() != *subnamespace.GetSliceClaim() {
+		if !reflect.DeepEqual(oldSubnamespace.GetSliceClaim(), subnamespace.GetSliceClaim()) {
 			admissionResponse.Allowed = false
 			admissionResponse.Result = &metav1.Status{
 				Message: "subsidiary namespace slice cannot be set after creation",
@@ -520,6 +566,30 @@ func (wh *Webhook) validateSubNamespace(w http.ResponseWriter, r *http.Request)
 				Message: "subsidiary namespace resource allocation cannot be updated when a slice is applied",
 			}
 		}
+
+		if oldSubnamespace.GetAnnotations()[corev1alpha1.SubNamespaceChildName] != subnamespace.GetAnnotations()[corev1alpha1.SubNamespaceChildName] {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "subsidiary namespace child name cannot be changed",
+			}
+		}
+		if destination, elementExists := subnamespace.GetAnnotations()[corev1alpha1.SubNamespaceMoveTo]; elementExists && (subnamespace.Spec.Workspace == nil || subnamespace.GetSliceClaim() != nil || destination == "") {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "only workspaces without a slice claim can be moved to another parent",
+			}
+		} else if elementExists && destination != oldSubnamespace.GetAnnotations()[corev1alpha1.SubNamespaceMoveTo] {
+			// Moving a workspace creates a subnamespace in the destination, which the requester must be allowed to do there
+			userInfo := admissionReviewRequest.Request.UserInfo
+			resourceAttributes := authorizationv1.ResourceAttributes{Namespace: destination, Verb: "create",
+				Group: subnamespaceResource.Group, Version: subnamespaceResource.Version, Resource: subnamespaceResource.Resource}
+			if !access.CheckAuthorization(userInfo.Username, userInfo.Groups, resourceAttributes) {
+				admissionResponse.Allowed = false
+				admissionResponse.Result = &metav1.Status{
+					Message: fmt.Sprintf("subsidiary namespace cannot be moved to %s without the permission to create subnamespaces there", destination),
+				}
+			}
+		}
 	}
 
 	var admissionReviewResponse admissionv1.AdmissionReview
@@ -695,6 +765,298 @@ func (wh *Webhook) validateSliceClaim(w http.ResponseWriter, r *http.Request) {
 	w.Write(resp)
 }
 
+func (wh *Webhook) validateRoleTemplate(w http.ResponseWriter, r *http.Request) {
+	klog.Infoln("RoleTemplate: message on validate received")
+	deserializer := wh.Codecs.UniversalDeserializer()
+	admissionReviewRequest, err := admissionReviewFromRequest(r, deserializer)
+	if err != nil {
+		klog.Errorf("RoleTemplate admission review error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	roletemplateResource := metav1.GroupVersionResource{Group: "core.edgenet.io", Version: "v1alpha1", Resource: "roletemplates"}
+	if admissionReviewRequest.Request.Resource != roletemplateResource {
+		err := fmt.Errorf("roletemplate wrong resource kind: %v", admissionReviewRequest.Request.Resource.Resource)
+		klog.Error(err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rawRequest := admissionReviewRequest.Request.Object.Raw
+	roletemplate := new(corev1alpha1.RoleTemplate)
+	if _, _, err := deserializer.Decode(rawRequest, nil, roletemplate); err != nil {
+		klog.Errorf("roletemplate decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	admissionResponse := new(admissionv1.AdmissionResponse)
+	admissionResponse.Allowed = true
+
+	// Role templates can never grant more than what the tenant owner role does
+	if err := access.CheckRoleTemplateRules(roletemplate.Spec.Rules); err != nil {
+		admissionResponse.Allowed = false
+		admissionResponse.Result = &metav1.Status{
+			Message: err.Error(),
+		}
+	}
+
+	var admissionReviewResponse admissionv1.AdmissionReview
+	admissionReviewResponse.Response = admissionResponse
+	admissionReviewResponse.SetGroupVersionKind(admissionReviewRequest.GroupVersionKind())
+	admissionReviewResponse.Response.UID = admissionReviewRequest.Request.UID
+
+	resp, err := json.Marshal(admissionReviewResponse)
+	if err != nil {
+		klog.Errorf("roletemplate decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+func (wh *Webhook) validateTenant(w http.ResponseWriter, r *http.Request) {
+	klog.Infoln("Tenant: message on validate received")
+	deserializer := wh.Codecs.UniversalDeserializer()
+	admissionReviewRequest, err := admissionReviewFromRequest(r, deserializer)
+	if err != nil {
+		klog.Errorf("Tenant admission review error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	tenantResource := metav1.GroupVersionResource{Group: "core.edgenet.io", Version: "v1alpha1", Resource: "tenants"}
+	if admissionReviewRequest.Request.Resource != tenantResource {
+		err := fmt.Errorf("tenant wrong resource kind: %v", admissionReviewRequest.Request.Resource.Resource)
+		klog.Error(err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rawRequest := admissionReviewRequest.Request.Object.Raw
+	tenant := new(corev1alpha1.Tenant)
+	if _, _, err := deserializer.Decode(rawRequest, nil, tenant); err != nil {
+		klog.Errorf("tenant decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	admissionResponse := new(admissionv1.AdmissionResponse)
+	admissionResponse.Allowed = true
+	if admissionReviewRequest.Request.Operation == "UPDATE" || admissionReviewRequest.Request.Operation == "PATCH" {
+		oldObjectRaw := admissionReviewRequest.Request.OldObject.Raw
+		oldTenant := new(corev1alpha1.Tenant)
+		if _, _, err := deserializer.Decode(oldObjectRaw, nil, oldTenant); err != nil {
+			klog.Errorf("old tenant decode error: %v", err)
+			w.WriteHeader(400)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// The owner of a tenant changes only when the new owner accepts an ownership transfer request
+		if !strings.EqualFold(oldTenant.Spec.Contact.Email, tenant.Spec.Contact.Email) && admissionReviewRequest.Request.UserInfo.Username != "system:serviceaccount:edgenet:ownershiptransferrequest" {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "tenant contact email can only be changed by an ownership transfer request",
+			}
+		}
+	}
+
+	var admissionReviewResponse admissionv1.AdmissionReview
+	admissionReviewResponse.Response = admissionResponse
+	admissionReviewResponse.SetGroupVersionKind(admissionReviewRequest.GroupVersionKind())
+	admissionReviewResponse.Response.UID = admissionReviewRequest.Request.UID
+
+	resp, err := json.Marshal(admissionReviewResponse)
+	if err != nil {
+		klog.Errorf("tenant decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+func (wh *Webhook) validateOwnershipTransferRequest(w http.ResponseWriter, r *http.Request) {
+	klog.Infoln("OwnershipTransferRequest: message on validate received")
+	deserializer := wh.Codecs.UniversalDeserializer()
+	admissionReviewRequest, err := admissionReviewFromRequest(r, deserializer)
+	if err != nil {
+		klog.Errorf("OwnershipTransferRequest admission review error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	ownershiptransferrequestResource := metav1.GroupVersionResource{Group: "registration.edgenet.io", Version: "v1alpha1", Resource: "ownershiptransferrequests"}
+	if admissionReviewRequest.Request.Resource != ownershiptransferrequestResource {
+		err := fmt.Errorf("ownershiptransferrequest wrong resource kind: %v", admissionReviewRequest.Request.Resource.Resource)
+		klog.Error(err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rawRequest := admissionReviewRequest.Request.Object.Raw
+	ownershiptransferrequest := new(registrationv1alpha1.OwnershipTransferRequest)
+	if _, _, err := deserializer.Decode(rawRequest, nil, ownershiptransferrequest); err != nil {
+		klog.Errorf("ownershiptransferrequest decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	admissionResponse := new(admissionv1.AdmissionResponse)
+	admissionResponse.Allowed = true
+	if admissionReviewRequest.Request.Operation == "CREATE" {
+		if ownershiptransferrequest.Spec.Accepted {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "ownership transfer request cannot be accepted at creation",
+			}
+		}
+	}
+
+	if admissionReviewRequest.Request.Operation == "UPDATE" || admissionReviewRequest.Request.Operation == "PATCH" {
+		oldObjectRaw := admissionReviewRequest.Request.OldObject.Raw
+		oldOwnershipTransferRequest := new(registrationv1alpha1.OwnershipTransferRequest)
+		if _, _, err := deserializer.Decode(oldObjectRaw, nil, oldOwnershipTransferRequest); err != nil {
+			klog.Errorf("old ownershiptransferrequest decode error: %v", err)
+			w.WriteHeader(400)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		if oldOwnershipTransferRequest.Spec.NewOwner != ownershiptransferrequest.Spec.NewOwner {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "new owner of ownership transfer request cannot be changed after creation",
+			}
+		} else if !oldOwnershipTransferRequest.Spec.Accepted && ownershiptransferrequest.Spec.Accepted &&
+			!strings.EqualFold(admissionReviewRequest.Request.UserInfo.Username, ownershiptransferrequest.Spec.NewOwner.Email) {
+			// Only the new owner can accept the ownership of the tenant
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "ownership transfer request can only be accepted by the new owner",
+			}
+		}
+	}
+
+	var admissionReviewResponse admissionv1.AdmissionReview
+	admissionReviewResponse.Response = admissionResponse
+	admissionReviewResponse.SetGroupVersionKind(admissionReviewRequest.GroupVersionKind())
+	admissionReviewResponse.Response.UID = admissionReviewRequest.Request.UID
+
+	resp, err := json.Marshal(admissionReviewResponse)
+	if err != nil {
+		klog.Errorf("ownershiptransferrequest decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+func (wh *Webhook) validateQuotaRequest(w http.ResponseWriter, r *http.Request) {
+	klog.Infoln("QuotaRequest: message on validate received")
+	deserializer := wh.Codecs.UniversalDeserializer()
+	admissionReviewRequest, err := admissionReviewFromRequest(r, deserializer)
+	if err != nil {
+		klog.Errorf("QuotaRequest admission review error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	quotarequestResource := metav1.GroupVersionResource{Group: "registration.edgenet.io", Version: "v1alpha1", Resource: "quotarequests"}
+	if admissionReviewRequest.Request.Resource != quotarequestResource {
+		err := fmt.Errorf("quotarequest wrong resource kind: %v", admissionReviewRequest.Request.Resource.Resource)
+		klog.Error(err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rawRequest := admissionReviewRequest.Request.Object.Raw
+	quotarequest := new(registrationv1alpha1.QuotaRequest)
+	if _, _, err := deserializer.Decode(rawRequest, nil, quotarequest); err != nil {
+		klog.Errorf("quotarequest decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	admissionResponse := new(admissionv1.AdmissionResponse)
+	admissionResponse.Allowed = true
+	// Tenant owners and admins can create quota requests but only those who can update them, namely the cluster admins, approve
+	if admissionReviewRequest.Request.Operation == "CREATE" && quotarequest.Spec.Approved {
+		userInfo := admissionReviewRequest.Request.UserInfo
+		resourceAttributes := authorizationv1.ResourceAttributes{Namespace: admissionReviewRequest.Request.Namespace, Verb: "update",
+			Group: quotarequestResource.Group, Version: quotarequestResource.Version, Resource: quotarequestResource.Resource}
+		if !access.CheckAuthorization(userInfo.Username, userInfo.Groups, resourceAttributes) {
+			admissionResponse.Allowed = false
+			admissionResponse.Result = &metav1.Status{
+				Message: "quota request cannot be approved at creation",
+			}
+		}
+	}
+
+	var admissionReviewResponse admissionv1.AdmissionReview
+	admissionReviewResponse.Response = admissionResponse
+	admissionReviewResponse.SetGroupVersionKind(admissionReviewRequest.GroupVersionKind())
+	admissionReviewResponse.Response.UID = admissionReviewRequest.Request.UID
+
+	resp, err := json.Marshal(admissionReviewResponse)
+	if err != nil {
+		klog.Errorf("quotarequest decode error: %v", err)
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// validateRoleSubject checks whether the group or service account requested for a role is well-formed and not reserved by the system
+func validateRoleSubject(subject *registrationv1alpha1.RoleSubjectSpec) error {
+	switch subject.Kind {
+	case "Group":
+		if subject.Name == "" {
+			return errors.New("role request subject requires a group name")
+		}
+		// System groups, such as system:authenticated or system:masters, are reserved for the cluster
+		if strings.HasPrefix(subject.Name, "system:") {
+			return fmt.Errorf("role request subject cannot be the system group %q", subject.Name)
+		}
+		if subject.Namespace != "" {
+			return errors.New("role request subject of kind Group cannot have a namespace")
+		}
+	case "ServiceAccount":
+		if msgs := validation.IsDNS1123Subdomain(subject.Name); len(msgs) != 0 {
+			return fmt.Errorf("role request subject has an invalid service account name: %s", strings.Join(msgs, ", "))
+		}
+		if subject.Namespace != "" {
+			if msgs := validation.IsDNS1123Label(subject.Namespace); len(msgs) != 0 {
+				return fmt.Errorf("role request subject has an invalid namespace: %s", strings.Join(msgs, ", "))
+			}
+		}
+	default:
+		return fmt.Errorf("role request subject kind must be Group or ServiceAccount, not %q", subject.Kind)
+	}
+	return nil
+}
+
 func admissionReviewFromRequest(r *http.Request, deserializer runtime.Decoder) (*admissionv1.AdmissionReview, error) {
 	if r.Header.Get("Content-Type") != "application/json" {
 		return nil, errors.New("expected content-type is application/json")
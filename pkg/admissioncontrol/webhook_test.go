@@ -0,0 +1,171 @@
+package admissioncontrol
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var rolerequestResource = metav1.GroupVersionResource{Group: "registration.edgenet.io", Version: "v1alpha1", Resource: "rolerequests"}
+var subnamespaceResource = metav1.GroupVersionResource{Group: "core.edgenet.io", Version: "v1alpha1", Resource: "subnamespaces"}
+var ownershiptransferrequestResource = metav1.GroupVersionResource{Group: "registration.edgenet.io", Version: "v1alpha1", Resource: "ownershiptransferrequests"}
+
+// review posts an admission review of the given operation to the handler and returns the response
+func review(t *testing.T, handler http.HandlerFunc, operation admissionv1.Operation, resource metav1.GroupVersionResource, object, oldObject interface{}) *admissionv1.AdmissionResponse {
+	return reviewAs(t, handler, "", operation, resource, object, oldObject)
+}
+
+// reviewAs posts an admission review of the given operation made by the user to the handler and returns the response
+func reviewAs(t *testing.T, handler http.HandlerFunc, username string, operation admissionv1.Operation, resource metav1.GroupVersionResource, object, oldObject interface{}) *admissionv1.AdmissionResponse {
+	admissionReview := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "review",
+			Resource:  resource,
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+		},
+	}
+	raw, err := json.Marshal(object)
+	util.OK(t, err)
+	admissionReview.Request.Object = runtime.RawExtension{Raw: raw}
+	if oldObject != nil {
+		raw, err := json.Marshal(oldObject)
+		util.OK(t, err)
+		admissionReview.Request.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	body, err := json.Marshal(admissionReview)
+	util.OK(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler(recorder, request)
+	util.Equals(t, http.StatusOK, recorder.Code)
+
+	admissionReviewResponse := new(admissionv1.AdmissionReview)
+	util.OK(t, json.Unmarshal(recorder.Body.Bytes(), admissionReviewResponse))
+	return admissionReviewResponse.Response
+}
+
+func TestValidateRoleRequest(t *testing.T) {
+	wh := Webhook{Codecs: serializer.NewCodecFactory(runtime.NewScheme())}
+	roleRequest := registrationv1alpha1.RoleRequest{
+		TypeMeta: metav1.TypeMeta{APIVersion: "registration.edgenet.io/v1alpha1", Kind: "RoleRequest"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "group",
+			Namespace: "edgenet",
+		},
+		Spec: registrationv1alpha1.RoleRequestSpec{
+			Email:   "john.doe@edge-net.org",
+			RoleRef: registrationv1alpha1.RoleRefSpec{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"},
+			Subject: &registrationv1alpha1.RoleSubjectSpec{Kind: "Group", Name: "researchers"},
+		},
+	}
+
+	t.Run("creation", func(t *testing.T) {
+		util.Equals(t, true, review(t, wh.validateRoleRequest, admissionv1.Create, rolerequestResource, roleRequest, nil).Allowed)
+	})
+	t.Run("system group", func(t *testing.T) {
+		roleRequestCopy := roleRequest.DeepCopy()
+		roleRequestCopy.Spec.Subject.Name = "system:masters"
+		util.Equals(t, false, review(t, wh.validateRoleRequest, admissionv1.Create, rolerequestResource, roleRequestCopy, nil).Allowed)
+	})
+	t.Run("system user", func(t *testing.T) {
+		roleRequestCopy := roleRequest.DeepCopy()
+		roleRequestCopy.Spec.Subject = nil
+		roleRequestCopy.Spec.Email = "system:kube-controller-manager"
+		util.Equals(t, false, review(t, wh.validateRoleRequest, admissionv1.Create, rolerequestResource, roleRequestCopy, nil).Allowed)
+	})
+	t.Run("approval", func(t *testing.T) {
+		roleRequestCopy := roleRequest.DeepCopy()
+		roleRequestCopy.Spec.Approved = true
+		util.Equals(t, true, review(t, wh.validateRoleRequest, admissionv1.Update, rolerequestResource, roleRequestCopy, roleRequest).Allowed)
+	})
+	t.Run("subject change", func(t *testing.T) {
+		roleRequestCopy := roleRequest.DeepCopy()
+		roleRequestCopy.Spec.Subject.Name = "administrators"
+		util.Equals(t, false, review(t, wh.validateRoleRequest, admissionv1.Update, rolerequestResource, roleRequestCopy, roleRequest).Allowed)
+	})
+}
+
+func TestValidateOwnershipTransferRequest(t *testing.T) {
+	wh := Webhook{Codecs: serializer.NewCodecFactory(runtime.NewScheme())}
+	ownershipTransferRequest := registrationv1alpha1.OwnershipTransferRequest{
+		TypeMeta: metav1.TypeMeta{APIVersion: "registration.edgenet.io/v1alpha1", Kind: "OwnershipTransferRequest"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "handover",
+			Namespace: "edgenet",
+		},
+		Spec: registrationv1alpha1.OwnershipTransferRequestSpec{
+			NewOwner: corev1alpha1.Contact{Email: "jane.doe@edge-net.org", FirstName: "Jane", LastName: "Doe", Phone: "+33NUMBER"},
+		},
+	}
+	acceptedOwnershipTransferRequest := ownershipTransferRequest.DeepCopy()
+	acceptedOwnershipTransferRequest.Spec.Accepted = true
+
+	t.Run("acceptance at creation", func(t *testing.T) {
+		util.Equals(t, false, reviewAs(t, wh.validateOwnershipTransferRequest, "jane.doe@edge-net.org", admissionv1.Create, ownershiptransferrequestResource, acceptedOwnershipTransferRequest, nil).Allowed)
+	})
+	t.Run("acceptance by new owner", func(t *testing.T) {
+		util.Equals(t, true, reviewAs(t, wh.validateOwnershipTransferRequest, "Jane.Doe@edge-net.org", admissionv1.Update, ownershiptransferrequestResource, acceptedOwnershipTransferRequest, ownershipTransferRequest).Allowed)
+	})
+	t.Run("acceptance by someone else", func(t *testing.T) {
+		util.Equals(t, false, reviewAs(t, wh.validateOwnershipTransferRequest, "john.doe@edge-net.org", admissionv1.Update, ownershiptransferrequestResource, acceptedOwnershipTransferRequest, ownershipTransferRequest).Allowed)
+	})
+	t.Run("new owner change", func(t *testing.T) {
+		ownershipTransferRequestCopy := ownershipTransferRequest.DeepCopy()
+		ownershipTransferRequestCopy.Spec.NewOwner.Email = "john.doe@edge-net.org"
+		util.Equals(t, false, reviewAs(t, wh.validateOwnershipTransferRequest, "john.doe@edge-net.org", admissionv1.Update, ownershiptransferrequestResource, ownershipTransferRequestCopy, ownershipTransferRequest).Allowed)
+	})
+}
+
+func TestValidateSubNamespaceMove(t *testing.T) {
+	wh := Webhook{Codecs: serializer.NewCodecFactory(runtime.NewScheme())}
+	// The requester can create subnamespaces in the allowed namespace only
+	client := testclient.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+		review.Status.Allowed = review.Spec.ResourceAttributes.Namespace == "allowed"
+		return true, review, nil
+	})
+	access.Clientset = client
+
+	subnamespace := corev1alpha1.SubNamespace{
+		TypeMeta: metav1.TypeMeta{APIVersion: "core.edgenet.io/v1alpha1", Kind: "SubNamespace"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workspace",
+			Namespace: "edgenet",
+		},
+		Spec: corev1alpha1.SubNamespaceSpec{
+			Workspace: &corev1alpha1.Workspace{Scope: "local"},
+		},
+	}
+
+	t.Run("allowed destination", func(t *testing.T) {
+		subnamespaceCopy := subnamespace.DeepCopy()
+		subnamespaceCopy.SetAnnotations(map[string]string{corev1alpha1.SubNamespaceMoveTo: "allowed"})
+		util.Equals(t, true, reviewAs(t, wh.validateSubNamespace, "john.doe@edge-net.org", admissionv1.Update, subnamespaceResource, subnamespaceCopy, subnamespace).Allowed)
+	})
+	t.Run("forbidden destination", func(t *testing.T) {
+		subnamespaceCopy := subnamespace.DeepCopy()
+		subnamespaceCopy.SetAnnotations(map[string]string{corev1alpha1.SubNamespaceMoveTo: "forbidden"})
+		util.Equals(t, false, reviewAs(t, wh.validateSubNamespace, "john.doe@edge-net.org", admissionv1.Update, subnamespaceResource, subnamespaceCopy, subnamespace).Allowed)
+	})
+}
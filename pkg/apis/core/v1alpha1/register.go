@@ -62,6 +62,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&SliceList{},
 		&SliceClaim{},
 		&SliceClaimList{},
+		&RoleTemplate{},
+		&RoleTemplateList{},
+		&ClusterPeer{},
+		&ClusterPeerList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
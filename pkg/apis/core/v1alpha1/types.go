@@ -18,15 +18,51 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Condition types that controllers set in the status of the core resources
+const (
+	// ConditionReady denotes that all steps of the reconciliation succeeded.
+	ConditionReady = "Ready"
+	// ConditionNamespaceReady denotes that the core or subsidiary namespace is in place.
+	ConditionNamespaceReady = "NamespaceReady"
+	// ConditionNetworkPolicyApplied denotes that the network policies of the tenant are in place.
+	ConditionNetworkPolicyApplied = "NetworkPolicyApplied"
+	// ConditionRoleBound denotes that the owner roles are bound.
+	ConditionRoleBound = "RoleBound"
+	// ConditionQuotaApplied denotes that the resource quota is applied.
+	ConditionQuotaApplied = "QuotaApplied"
+	// ConditionInheritanceSynced denotes that the objects inherited from the parent namespace are in sync.
+	ConditionInheritanceSynced = "InheritanceSynced"
+	// ConditionSliceBound denotes that a slice and a slice claim are bound together.
+	ConditionSliceBound = "SliceBound"
+	// ConditionNodesReserved denotes that the nodes of a slice are reserved.
+	ConditionNodesReserved = "NodesReserved"
+	// ConditionNodeJoined denotes that a contributed node joined the cluster.
+	ConditionNodeJoined = "NodeJoined"
+	// ConditionSuspended denotes that the workloads of a disabled tenant are stopped and non-owner access is revoked.
+	ConditionSuspended = "Suspended"
+	// ConditionCleanedUp denotes that everything a deleted tenant leaves behind is cleaned up.
+	ConditionCleanedUp = "CleanedUp"
+	// ConditionPeerConnected denotes that the API server of a member cluster is reachable with the kubeconfig of the peer.
+	ConditionPeerConnected = "PeerConnected"
+	// ConditionFederated denotes that a federated workspace is mirrored into all enabled member clusters.
+	ConditionFederated = "Federated"
+	// ConditionMoved denotes that a workspace is moved to the parent namespace it asks for.
+	ConditionMoved = "Moved"
+	// ConditionInheritanceConflict denotes that objects in a workspace collide with the objects it inherits from the parent namespace.
+	ConditionInheritanceConflict = "InheritanceConflict"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -58,8 +94,41 @@ type TenantSpec struct {
 	// Whether cluster-level network policies will be applied to tenant namespaces
 	// for security purposes.
 	ClusterNetworkPolicy bool `json:"clusternetworkpolicy"`
-	// If the tenant is active then this field is true.
+	// Network profile that sets the isolation level of tenant namespaces. This can be
+	// 'restricted', 'baseline', or 'privileged'. Restricted only allows intra-tenant
+	// communication, baseline allows intra-tenant communication plus ingress from
+	// external traffic, and privileged allows all kind of traffics.
+	NetworkProfile string `json:"networkprofile"`
+	// If the tenant is active then this field is true. Setting it to false suspends the tenant without removing its namespaces.
 	Enabled bool `json:"enabled"`
+	// Period during which the namespaces of a deleted tenant are kept with read-only access
+	// for the owner to export resources before the cleanup starts. Optional.
+	ExportWindow *metav1.Duration `json:"exportwindow,omitempty"`
+	// Default resources of the containers that do not set them in the namespaces of the tenant,
+	// which keeps the resource quota from rejecting their pods. Optional.
+	LimitRange *ContainerLimitRange `json:"limitrange,omitempty"`
+	// Pod Security Standards level enforced in the namespaces of the tenant. This can be
+	// 'restricted', 'baseline', or 'privileged'. Optional.
+	PodSecurity string `json:"podsecurity,omitempty"`
+	// Labels and annotations set on all namespaces of the tenant, including the ones of
+	// its workspaces and subtenants. Optional.
+	NamespaceMetadata *NamespaceMetadata `json:"namespacemetadata,omitempty"`
+}
+
+// NamespaceMetadata describes the labels and annotations of namespaces
+type NamespaceMetadata struct {
+	// Labels of the namespaces.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations of the namespaces.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ContainerLimitRange describes the default resources of containers
+type ContainerLimitRange struct {
+	// Default resource limits of a container.
+	Default map[corev1.ResourceName]resource.Quantity `json:"default,omitempty"`
+	// Default resource requests of a container.
+	DefaultRequest map[corev1.ResourceName]resource.Quantity `json:"defaultrequest,omitempty"`
 }
 
 // Address describes postal address of tenant
@@ -88,12 +157,36 @@ type Contact struct {
 	Phone string `json:"phone"`
 }
 
+// Member describes a user belonging to a tenant
+type Member struct {
+	// Email address of the member.
+	Email string `json:"email"`
+	// Name of the role bound to the member when joining the tenant.
+	Role string `json:"role"`
+	// Date when the member joined the tenant.
+	Joined metav1.Time `json:"joined"`
+}
+
+// TenantMemberRemoval is the annotation that lists the comma-separated emails of the members to remove from a tenant,
+// whose roles are then revoked in all namespaces of the tenant
+const TenantMemberRemoval = "edge-net.io/remove-members"
+
 // TenantStatus is the status for a Tenant resource
 type TenantStatus struct {
-	// The state can be 'Established' or 'Failure'.
+	// The state can be 'Established', 'Suspended', 'Terminating', or 'Failure'.
 	State string `json:"state"`
 	// Additional description can be located here.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the Tenant.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Members of the tenant, which the tenant and role request controllers maintain from the roles they bind.
+	Members []Member `json:"members,omitempty"`
+	// Email of the owner whose roles are bound, which lets the controller announce a change of ownership.
+	Owner string `json:"owner,omitempty"`
+	// Expiry of the export window of a deleted tenant, after which its resources are cleaned up.
+	ExportExpiry *metav1.Time `json:"exportexpiry,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -112,6 +205,80 @@ func (t Tenant) MakeOwnerReference() metav1.OwnerReference {
 	return *metav1.NewControllerRef(&t.ObjectMeta, SchemeGroupVersion.WithKind("Tenant"))
 }
 
+// TenantLimitRange is the name of the limit range that applies the default container resources of a tenant
+const TenantLimitRange = "tenant-limitrange"
+
+// MakeLimitRange returns the limit range applying the default container resources of the tenant in the namespace,
+// or nil if the tenant has none
+func (t Tenant) MakeLimitRange(namespace string) *corev1.LimitRange {
+	if t.Spec.LimitRange == nil {
+		return nil
+	}
+	limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: TenantLimitRange, Namespace: namespace, Labels: map[string]string{"edge-net.io/tenant": t.GetName()}}}
+	limitRange.Spec.Limits = []corev1.LimitRangeItem{{Type: corev1.LimitTypeContainer, Default: t.Spec.LimitRange.Default, DefaultRequest: t.Spec.LimitRange.DefaultRequest}}
+	return limitRange
+}
+
+// SetPodSecurityLabels sets the namespace labels that enforce the Pod Security Standards level of the tenant,
+// or removes them if the tenant has none
+func (t Tenant) SetPodSecurityLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if t.Spec.PodSecurity == "" {
+		delete(labels, "pod-security.kubernetes.io/enforce")
+		delete(labels, "pod-security.kubernetes.io/enforce-version")
+	} else {
+		labels["pod-security.kubernetes.io/enforce"] = t.Spec.PodSecurity
+		labels["pod-security.kubernetes.io/enforce-version"] = "latest"
+	}
+	return labels
+}
+
+// SetNamespaceMetadata sets the namespace metadata of the tenant on the labels and annotations of a namespace.
+// The keys it sets are recorded in annotations, which lets it remove the ones the tenant no longer has.
+func (t Tenant) SetNamespaceMetadata(labels, annotations map[string]string) (map[string]string, map[string]string) {
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	namespaceMetadata := NamespaceMetadata{}
+	if t.Spec.NamespaceMetadata != nil {
+		namespaceMetadata = *t.Spec.NamespaceMetadata
+	}
+	propagateMetadata(labels, annotations, namespaceMetadata.Labels, "edge-net.io/tenant-labels")
+	propagateMetadata(annotations, annotations, namespaceMetadata.Annotations, "edge-net.io/tenant-annotations")
+	return labels, annotations
+}
+
+// propagateMetadata copies the metadata into the target except for the keys EdgeNet reserves, removes the keys recorded
+// under the tracking key that the metadata no longer has, and records the keys it copies
+func propagateMetadata(target, tracker, metadata map[string]string, trackingKey string) {
+	if previousKeys, elementExists := tracker[trackingKey]; elementExists {
+		for _, key := range strings.Split(previousKeys, ",") {
+			if _, elementExists := metadata[key]; !elementExists {
+				delete(target, key)
+			}
+		}
+	}
+	keys := []string{}
+	for key, value := range metadata {
+		if strings.HasPrefix(key, "edge-net.io/") || strings.HasPrefix(key, "pod-security.kubernetes.io/") || key == "scheduler.alpha.kubernetes.io/node-selector" {
+			continue
+		}
+		target[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		delete(tracker, trackingKey)
+		return
+	}
+	sort.Strings(keys)
+	tracker[trackingKey] = strings.Join(keys, ",")
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -148,17 +315,43 @@ type Workspace struct {
 	// Which services are going to be inherited from the parent namespace to the this workspace thus
 	// subnamespace.
 	// The supported resources are: RBAC, NetworkPolicies, Limit Ranges, Secrets, Config Maps, and
-	// Service Accounts.
+	// Service Accounts. Cluster admins can allow additional kinds, such as Antrea network policies,
+	// through the inheritance-allowlist config map in the edgenet namespace.
 	Inheritance map[string]bool `json:"inheritance"`
-	// Scope can be 'federated', or 'local'. It cannot be changed after creation.
+	// Scope can be 'federated', or 'local'. It cannot be changed after creation. The earlier 'federation'
+	// spelling is still accepted for 'federated'.
 	Scope string `json:"scope"`
-	// Denote the workspace in sync with its parent.
+	// Denote the workspace in sync with its parent. Changes to the inherited objects in the parent namespace
+	// are then pushed to the workspace, and to the workspaces under it that are in sync as well.
 	Sync bool `json:"sync"`
 	// Owner of the workspace.
 	Owner *Contact `json:"owner"`
 	// SliceClaim is the name of a SliceClaim in the same namespace as the workspace using this slice.
 	SliceClaim *string `json:"sliceclaim"`
-}
+	// ConflictPolicy decides what happens when an object in the workspace collides with an object inherited
+	// from the parent namespace. This can be 'parent-wins', 'child-wins', or 'fail'. Defaults to 'child-wins'.
+	// Unless the policy is 'fail', the parent always wins for roles, role bindings, and network policies, which guard
+	// the workspace, and the local objects it overwrites are reported as conflicts.
+	ConflictPolicy string `json:"conflictpolicy,omitempty"`
+}
+
+// Conflict policies of a workspace
+const (
+	// ConflictPolicyParentWins overwrites the colliding objects with the objects of the parent namespace,
+	// including the ones marked as overridden.
+	ConflictPolicyParentWins = "parent-wins"
+	// ConflictPolicyChildWins keeps the colliding objects as they are.
+	ConflictPolicyChildWins = "child-wins"
+	// ConflictPolicyFail keeps the colliding objects as they are and fails the inheritance.
+	ConflictPolicyFail = "fail"
+)
+
+// InheritanceSkip is the annotation that keeps an object in a parent namespace from being inherited when it is set to true
+const InheritanceSkip = "edge-net.io/skip-inheritance"
+
+// InheritanceOverride is the annotation that marks an inherited object as locally overridden when it is set to true.
+// It has no effect on roles, role bindings, and network policies.
+const InheritanceOverride = "edge-net.io/overridden"
 
 // Subtenant resource represents a tenant under another tenant.
 type Subtenant struct {
@@ -171,12 +364,44 @@ type Subtenant struct {
 	SliceClaim *string `json:"sliceclaim"`
 }
 
+// SubNamespaceMoveTo is the annotation that asks for moving a workspace to the parent namespace it holds
+const SubNamespaceMoveTo = "edge-net.io/move-to"
+
+// SubNamespaceChildName is the annotation that holds the name of the child of a moved subnamespace
+const SubNamespaceChildName = "edge-net.io/child-name"
+
 // SubNamespaceStatus is the status for a SubNamespace resource
 type SubNamespaceStatus struct {
 	// Denotes the state of the SubNamespace. This can be 'Failure', or 'Established'.
 	State string `json:"state"`
 	// Message contains additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the SubNamespace.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Federation shows the state of a federated workspace in each member cluster.
+	Federation []FederationStatus `json:"federation,omitempty"`
+	// Conflicts lists the objects in the workspace that collide with the objects inherited from the parent namespace.
+	Conflicts []InheritanceConflict `json:"conflicts,omitempty"`
+}
+
+// InheritanceConflict is an object in a workspace that collides with an object inherited from the parent namespace
+type InheritanceConflict struct {
+	// Kind of the object, such as Role or RoleBinding.
+	Kind string `json:"kind"`
+	// Name of the object.
+	Name string `json:"name"`
+}
+
+// FederationStatus is the state of a federated workspace in a member cluster
+type FederationStatus struct {
+	// Name of the cluster peer that registers the member cluster.
+	Cluster string `json:"cluster"`
+	// Denotes the state of the mirror. This can be 'Failure', or 'Mirrored'.
+	State string `json:"state"`
+	// Message contains additional information.
+	Message string `json:"message"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -208,9 +433,13 @@ func (s SubNamespace) RetrieveQuantity(key corev1.ResourceName) resource.Quantit
 }
 
 // GenerateChildName forms a name for child according to the mode, Workspace or Subtenant.
+// A subnamespace moved to another parent keeps the name of its existing child.
 func (s SubNamespace) GenerateChildName(clusterUID string) string {
+	if childName, elementExists := s.GetAnnotations()[SubNamespaceChildName]; elementExists && childName != "" {
+		return childName
+	}
 	childName := s.GetName()
-	if s.Spec.Workspace != nil && s.Spec.Workspace.Scope == "federation" {
+	if s.IsFederated() {
 		childName = fmt.Sprintf("%s-%s", clusterUID, childName)
 	}
 
@@ -219,6 +448,12 @@ func (s SubNamespace) GenerateChildName(clusterUID string) string {
 	return childName
 }
 
+// IsFederated returns true if the subnamespace is a federated workspace. Workspaces created with the earlier
+// 'federation' spelling of the scope are federated as well, so their children keep their names.
+func (s SubNamespace) IsFederated() bool {
+	return s.Spec.Workspace != nil && (s.Spec.Workspace.Scope == "federated" || s.Spec.Workspace.Scope == "federation")
+}
+
 // GetMode return the mode as workspace or subtenant.
 func (s SubNamespace) GetMode() string {
 	if s.Spec.Workspace != nil {
@@ -303,6 +538,10 @@ type NodeContributionStatus struct {
 	State string `json:"state"`
 	// Message contains additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the NodeContribution.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -340,6 +579,9 @@ type TenantResourceQuotaSpec struct {
 	Claim map[string]ResourceTuning `json:"claim"`
 	// To decrease the overall quota.
 	Drop map[string]ResourceTuning `json:"drop"`
+	// EvictionPolicy determines what happens when the overall quota falls short of the
+	// subnamespaces. This can be 'delete-newest', 'delete-by-priority', 'scale-down', or 'freeze'.
+	EvictionPolicy string `json:"evictionpolicy,omitempty"`
 }
 
 // ResourceTuning indicates resources to add or remove, and how long they will remain.
@@ -348,16 +590,54 @@ type TenantResourceQuotaSpec struct {
 type ResourceTuning struct {
 	// This denotes which resources to be included.
 	ResourceList map[corev1.ResourceName]resource.Quantity `json:"resourcelist"`
+	// Start date of the ResourceTuning. This can be nil if the ResourceTuning takes effect immediately.
+	Start *metav1.Time `json:"start,omitempty"`
 	// Expiration date of the ResourceTuning. This can be nil if no expiration date is specified.
 	Expiry *metav1.Time `json:"expiry"`
 }
 
+// IsActive returns true if the ResourceTuning has started and not expired yet.
+func (r ResourceTuning) IsActive() bool {
+	if r.Start != nil && time.Until(r.Start.Time) > 0 {
+		return false
+	}
+	return r.Expiry == nil || (r.Expiry != nil && time.Until(r.Expiry.Time) >= 0)
+}
+
 // TenantResourceQuotaStatus is the status for a tenant resouce quota resource
 type TenantResourceQuotaStatus struct {
 	// Denotes the state of the TenantResourceQuota. This can be 'Failure', or 'Success'.
 	State string `json:"state"`
 	// Message contains additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the TenantResourceQuota.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Assigned is the net quota of the tenant, claims minus drops that are in effect.
+	Assigned map[corev1.ResourceName]resource.Quantity `json:"assigned,omitempty"`
+	// Allocated is the sum of the quotas allocated to the subnamespaces.
+	Allocated map[corev1.ResourceName]resource.Quantity `json:"allocated,omitempty"`
+	// Remaining is the quota left for the core namespace. It is negative for the resources in shortage.
+	Remaining map[corev1.ResourceName]resource.Quantity `json:"remaining,omitempty"`
+	// Used is the actual consumption summed from every resource quota in the hierarchy.
+	Used map[corev1.ResourceName]resource.Quantity `json:"used,omitempty"`
+	// Namespaces breaks down the quota and the consumption per namespace in the hierarchy.
+	Namespaces []NamespaceUsage `json:"namespaces,omitempty"`
+}
+
+// NamespaceUsage reports the quota and the consumption of a namespace in the tenant hierarchy.
+type NamespaceUsage struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+	// SubNamespace is the name of the subnamespace that generates the namespace. Empty for the core namespace.
+	SubNamespace string `json:"subnamespace,omitempty"`
+	// Parent is the namespace where the subnamespace resides. Empty for the core namespace.
+	Parent string `json:"parent,omitempty"`
+	// Hard is the sum of the resource quotas in the namespace.
+	Hard map[corev1.ResourceName]resource.Quantity `json:"hard,omitempty"`
+	// Used is the sum of the consumption in the namespace.
+	Used map[corev1.ResourceName]resource.Quantity `json:"used,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -374,12 +654,13 @@ type TenantResourceQuotaList struct {
 }
 
 // Fetches the net value of the resources. For example, 1Gb memory is claimed and 100 milliCPU
-// are dropped. Then the function returns the net resources as '+1Gb', '-100m'.
+// are dropped. Then the function returns the net resources as '+1Gb', '-100m'. The claims and
+// drops that have not started yet or have expired are left out.
 func (t TenantResourceQuota) Fetch() map[corev1.ResourceName]resource.Quantity {
 	assignedQuota := make(map[corev1.ResourceName]resource.Quantity)
 	if len(t.Spec.Claim) > 0 {
 		for _, claim := range t.Spec.Claim {
-			if claim.Expiry == nil || (claim.Expiry != nil && time.Until(claim.Expiry.Time) >= 0) {
+			if claim.IsActive() {
 				for key, value := range claim.ResourceList {
 					if assignedQuantity, elementExists := assignedQuota[key]; elementExists {
 						assignedQuantity.Add(value)
@@ -393,7 +674,7 @@ func (t TenantResourceQuota) Fetch() map[corev1.ResourceName]resource.Quantity {
 	}
 	if len(t.Spec.Drop) > 0 {
 		for _, drop := range t.Spec.Drop {
-			if drop.Expiry == nil || (drop.Expiry != nil && time.Until(drop.Expiry.Time) >= 0) {
+			if drop.IsActive() {
 				for key, value := range drop.ResourceList {
 					if assignedQuantity, elementExists := assignedQuota[key]; elementExists {
 						assignedQuantity.Sub(value)
@@ -470,6 +751,10 @@ type SliceStatus struct {
 	Message string `json:"message"`
 	// Expiration date of the slice.
 	Expiry *metav1.Time `json:"expiry"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the Slice.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -522,6 +807,10 @@ type SliceClaimStatus struct {
 	State string `json:"state"`
 	// Message contains additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the SliceClaim.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -551,3 +840,103 @@ func (sc SliceClaim) MakeObjectReference() *corev1.ObjectReference {
 func (sc SliceClaim) MakeOwnerReference() metav1.OwnerReference {
 	return *metav1.NewControllerRef(&sc.ObjectMeta, SchemeGroupVersion.WithKind("SliceClaim"))
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoleTemplate describes a custom role that cluster admins and tenant owners define for the users of a namespace
+type RoleTemplate struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the role template resource spec
+	Spec RoleTemplateSpec `json:"spec"`
+	// Status is the role template resource status
+	Status RoleTemplateStatus `json:"status,omitempty"`
+}
+
+// RoleTemplateSpec is the spec for a role template resource
+type RoleTemplateSpec struct {
+	// Description tells users what the role is for, e.g. read-only observer.
+	Description string `json:"description,omitempty"`
+	// Rules are the policy rules of the role. They cannot exceed the rules of the tenant owner role.
+	Rules []rbacv1.PolicyRule `json:"rules"`
+}
+
+// RoleTemplateStatus is the status for a role template resource
+type RoleTemplateStatus struct {
+	// Denotes the state of the RoleTemplate. This can be 'Failure', or 'Established'.
+	State string `json:"state"`
+	// Message contains additional information.
+	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the RoleTemplate.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoleTemplateList is a list of role template resources
+type RoleTemplateList struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ListMeta `json:"metadata"`
+	// RoleTemplateList is a list of RoleTemplate resources. This element contains
+	// RoleTemplate resources.
+	Items []RoleTemplate `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPeer registers a member cluster into which federated workspaces are mirrored
+type ClusterPeer struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the cluster peer resource spec
+	Spec ClusterPeerSpec `json:"spec"`
+	// Status is the cluster peer resource status
+	Status ClusterPeerStatus `json:"status,omitempty"`
+}
+
+// ClusterPeerSpec is the spec for a cluster peer resource
+type ClusterPeerSpec struct {
+	// Name of the secret in the edgenet namespace that holds the kubeconfig of the member cluster
+	// under the kubeconfig key.
+	SecretName string `json:"secretname"`
+	// Federated workspaces are mirrored only into the member clusters of enabled peers.
+	Enabled bool `json:"enabled"`
+}
+
+// ClusterPeerStatus is the status for a cluster peer resource
+type ClusterPeerStatus struct {
+	// Denotes the state of the ClusterPeer. This can be 'Failure', 'Disabled', or 'Established'.
+	State string `json:"state"`
+	// Message contains additional information.
+	Message string `json:"message"`
+	// Version is the Kubernetes version of the member cluster.
+	Version string `json:"version,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the ClusterPeer.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPeerList is a list of cluster peer resources
+type ClusterPeerList struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ListMeta `json:"metadata"`
+	// ClusterPeerList is a list of ClusterPeer resources. This element contains
+	// ClusterPeer resources.
+	Items []ClusterPeer `json:"items"`
+}
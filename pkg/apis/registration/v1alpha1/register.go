@@ -56,6 +56,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterRoleRequestList{},
 		&RoleRequest{},
 		&RoleRequestList{},
+		&QuotaRequest{},
+		&QuotaRequestList{},
+		&OwnershipTransferRequest{},
+		&OwnershipTransferRequestList{},
+		&ApprovalPolicy{},
+		&ApprovalPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
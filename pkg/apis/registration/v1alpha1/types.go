@@ -24,6 +24,22 @@ import (
 	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
 )
 
+// Condition types that controllers set in the status of the registration resources
+const (
+	// ConditionApproved denotes that the request is approved by the administrators.
+	ConditionApproved = "Approved"
+	// ConditionRoleBound denotes that the requested role is bound to the requester.
+	ConditionRoleBound = "RoleBound"
+	// ConditionTenantCreated denotes that the tenant is created out of the request.
+	ConditionTenantCreated = "TenantCreated"
+	// ConditionQuotaClaimed denotes that the requested resources are claimed in the tenant resource quota.
+	ConditionQuotaClaimed = "QuotaClaimed"
+	// ConditionAccepted denotes that the new owner accepts the ownership of the tenant.
+	ConditionAccepted = "Accepted"
+	// ConditionOwnershipTransferred denotes that the tenant is handed over to the new owner.
+	ConditionOwnershipTransferred = "OwnershipTransferred"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,21 +71,37 @@ type TenantRequestSpec struct {
 	// Whether cluster-level network policies will be applied to tenant namespaces
 	// for security purposes.
 	ClusterNetworkPolicy bool `json:"clusternetworkpolicy"`
+	// Network profile that sets the isolation level of tenant namespaces. This can be
+	// 'restricted', 'baseline', or 'privileged'.
+	NetworkProfile string `json:"networkprofile"`
 	// Requested allocation of certain resource types. Resource types are
 	// kubernetes default resource types.
 	ResourceAllocation map[corev1.ResourceName]resource.Quantity `json:"resourceallocation"`
 	// If the tenant is approved or not by the administrators.
 	Approved bool `json:"approved"`
+	// True if this request is denied by the administrators. A denied request is
+	// never fulfilled, even if it gets approved afterward.
+	Denied bool `json:"denied,omitempty"`
+	// Reason for the denial, which is shared with the requester.
+	Reason string `json:"reason,omitempty"`
 }
 
 // TenantRequestStatus is the status for a TenantRequest resource
 type TenantRequestStatus struct {
 	// Expiration date of the request.
 	Expiry *metav1.Time `json:"expiry"`
-	// Current state of the policy. This can be 'Failure', 'Pending', or 'Approved'.
+	// Current state of the policy. This can be 'Failure', 'Pending', 'Approved', 'Denied', or 'Expired'.
 	State string `json:"state"`
 	// Description for additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the TenantRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ApprovedBy is the name of the approval policy that approved the request automatically, if any.
+	ApprovedBy string `json:"approvedby,omitempty"`
+	// EmailVerified is true once the requester confirms the email address, which approval policies require.
+	EmailVerified bool `json:"emailverified"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -114,16 +146,33 @@ type ClusterRoleRequestSpec struct {
 	RoleName string `json:"rolename"`
 	// True if this role request is approved false if not.
 	Approved bool `json:"approved"`
+	// True if this request is denied by the administrators. A denied request is
+	// never fulfilled, even if it gets approved afterward.
+	Denied bool `json:"denied,omitempty"`
+	// Reason for the denial, which is shared with the requester.
+	Reason string `json:"reason,omitempty"`
+	// Duration of the role grant, counted from the approval. The role is revoked once it elapses.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// ValidUntil is the date when the role grant ends. It takes precedence over the duration.
+	ValidUntil *metav1.Time `json:"validuntil,omitempty"`
 }
 
 // ClusterRoleRequestStatus is the status for a ClusterRoleRequest resource
 type ClusterRoleRequestStatus struct {
 	// Expiration date of the request.
 	Expiry *metav1.Time `json:"expiry"`
-	// Current state of the policy. This can be 'Failure', 'Pending', or 'Approved'.
+	// Current state of the policy. This can be 'Failure', 'Pending', 'Approved', 'Denied', 'Expired', or 'Revoked'.
 	State string `json:"state"`
 	// Description for additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the ClusterRoleRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// GrantExpiry is the date when the granted role gets revoked, if the grant is time-bound.
+	GrantExpiry *metav1.Time `json:"grantexpiry,omitempty"`
+	// GrantExpiryNotified is true once the user is notified of the upcoming revocation.
+	GrantExpiryNotified bool `json:"grantexpirynotified,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -162,28 +211,64 @@ type RoleRequestSpec struct {
 	LastName string `json:"lastname"`
 	// Email of the person requesting the role.
 	Email string `json:"email"`
-	// RoleRefSpec indicates the requested Role or ClusterRole
+	// RoleRefSpec indicates the requested Role, ClusterRole, or RoleTemplate
 	RoleRef RoleRefSpec `json:"roleref"`
+	// Subject is the group or service account to bind the role to. If it is not set, the role is
+	// bound to the user identified by the email of the person making the request.
+	Subject *RoleSubjectSpec `json:"subject,omitempty"`
 	// True if this role request is approved false if not.
 	Approved bool `json:"approved"`
+	// True if this request is denied by the administrators. A denied request is
+	// never fulfilled, even if it gets approved afterward.
+	Denied bool `json:"denied,omitempty"`
+	// Reason for the denial, which is shared with the requester.
+	Reason string `json:"reason,omitempty"`
+	// Duration of the role grant, counted from the approval. The role is revoked once it elapses.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// ValidUntil is the date when the role grant ends. It takes precedence over the duration.
+	ValidUntil *metav1.Time `json:"validuntil,omitempty"`
 }
 
-// RoleRefSpec indicates the requested Role / ClusterRole
+// RoleRefSpec indicates the requested Role / ClusterRole / RoleTemplate
 type RoleRefSpec struct {
-	// The kind of the RoleRefSpec, this can be 'ClusterRole', or 'Role'.
+	// The kind of the RoleRefSpec, this can be 'ClusterRole', 'Role', or 'RoleTemplate'.
+	// A RoleTemplate is bound through the role that materializes it in the namespace.
 	Kind string `json:"kind"`
 	// Name of the role.
 	Name string `json:"name"`
 }
 
+// RoleSubjectSpec indicates the subject that the requested Role / ClusterRole is bound to
+type RoleSubjectSpec struct {
+	// The kind of the subject, this can be 'Group', or 'ServiceAccount'.
+	Kind string `json:"kind"`
+	// Name of the group or service account.
+	Name string `json:"name"`
+	// Namespace of the service account, which needs to belong to the same tenant as the request.
+	// The namespace of the request is used if it is not set.
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // RoleRequestStatus is the status for a RoleRequest resource
 type RoleRequestStatus struct {
 	// Expiration date of the request.
 	Expiry *metav1.Time `json:"expiry"`
-	// Current state of the policy. This can be 'Failure', 'Pending', or 'Approved'.
+	// Current state of the policy. This can be 'Failure', 'Pending', 'Approved', 'Denied', 'Expired', or 'Revoked'.
 	State string `json:"state"`
 	// Description for additional information.
 	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the RoleRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// GrantExpiry is the date when the granted role gets revoked, if the grant is time-bound.
+	GrantExpiry *metav1.Time `json:"grantexpiry,omitempty"`
+	// GrantExpiryNotified is true once the user is notified of the upcoming revocation.
+	GrantExpiryNotified bool `json:"grantexpirynotified,omitempty"`
+	// ApprovedBy is the name of the approval policy that approved the request automatically, if any.
+	ApprovedBy string `json:"approvedby,omitempty"`
+	// EmailVerified is true once the requester confirms the email address, which approval policies require.
+	EmailVerified bool `json:"emailverified"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -198,3 +283,161 @@ type RoleRequestList struct {
 	// RoleRequest resources.
 	Items []RoleRequest `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// QuotaRequest describes a QuotaRequest resource
+type QuotaRequest struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the quotarequest resource spec
+	Spec QuotaRequestSpec `json:"spec"`
+	// Status is the quotarequest resource status
+	Status QuotaRequestStatus `json:"status,omitempty"`
+}
+
+// QuotaRequestSpec is the spec for a QuotaRequest resource
+type QuotaRequestSpec struct {
+	// First name of the person requesting the quota.
+	FirstName string `json:"firstname"`
+	// Last name of the person requesting the quota.
+	LastName string `json:"lastname"`
+	// Email of the person requesting the quota.
+	Email string `json:"email"`
+	// Claim denotes the resources to add to the tenant resource quota, and how long they will remain.
+	Claim corev1alpha1.ResourceTuning `json:"claim"`
+	// Justification explains why the tenant needs the resources.
+	Justification string `json:"justification"`
+	// True if this quota request is approved false if not.
+	Approved bool `json:"approved"`
+}
+
+// QuotaRequestStatus is the status for a QuotaRequest resource
+type QuotaRequestStatus struct {
+	// Expiration date of the request.
+	Expiry *metav1.Time `json:"expiry"`
+	// Current state of the policy. This can be 'Failure', 'Pending', 'Approved', or 'Expired'.
+	State string `json:"state"`
+	// Description for additional information.
+	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the QuotaRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// QuotaRequestList is a list of QuotaRequest resources
+type QuotaRequestList struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ListMeta `json:"metadata"`
+	// QuotaRequestList is a list of QuotaRequest resources. This element contains
+	// QuotaRequest resources.
+	Items []QuotaRequest `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OwnershipTransferRequest describes an OwnershipTransferRequest resource, which hands a tenant over to a new owner
+type OwnershipTransferRequest struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the ownershiptransferrequest resource spec
+	Spec OwnershipTransferRequestSpec `json:"spec"`
+	// Status is the ownershiptransferrequest resource status
+	Status OwnershipTransferRequestStatus `json:"status,omitempty"`
+}
+
+// OwnershipTransferRequestSpec is the spec for an OwnershipTransferRequest resource
+type OwnershipTransferRequestSpec struct {
+	// Contact information of the new owner, which becomes the contact of the tenant.
+	NewOwner corev1alpha1.Contact `json:"newowner"`
+	// True once the new owner accepts the ownership of the tenant. Only the new owner can accept it.
+	Accepted bool `json:"accepted"`
+}
+
+// OwnershipTransferRequestStatus is the status for an OwnershipTransferRequest resource
+type OwnershipTransferRequestStatus struct {
+	// Expiration date of the request.
+	Expiry *metav1.Time `json:"expiry"`
+	// Current state of the request. This can be 'Failure', 'Pending', 'Transferred', or 'Expired'.
+	State string `json:"state"`
+	// Description for additional information.
+	Message string `json:"message"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// Conditions describe the progress of each step the controller takes to reconcile the OwnershipTransferRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// PreviousOwner is the email address of the owner from whom the tenant is transferred.
+	PreviousOwner string `json:"previousowner,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OwnershipTransferRequestList is a list of OwnershipTransferRequest resources
+type OwnershipTransferRequestList struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ListMeta `json:"metadata"`
+	// OwnershipTransferRequestList is a list of OwnershipTransferRequest resources. This element contains
+	// OwnershipTransferRequest resources.
+	Items []OwnershipTransferRequest `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApprovalPolicy describes an ApprovalPolicy resource
+type ApprovalPolicy struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the approvalpolicy resource spec
+	Spec ApprovalPolicySpec `json:"spec"`
+}
+
+// ApprovalPolicySpec is the spec for an ApprovalPolicy resource
+type ApprovalPolicySpec struct {
+	// Kind of the requests that the policy approves automatically. This can be 'TenantRequest', or 'RoleRequest'.
+	RequestKind string `json:"requestkind"`
+	// Rules of the policy. A request gets approved if it satisfies any of the rules.
+	Rules []ApprovalRule `json:"rules"`
+}
+
+// ApprovalRule lists the criteria that a request needs to satisfy altogether. A criterion that
+// does not concern the kind of the request causes the rule not to match.
+type ApprovalRule struct {
+	// Email domains of the requesters. A leading wildcard, such as '*.univ.fr', matches the domain and its subdomains.
+	EmailDomains []string `json:"emaildomains,omitempty"`
+	// Roles that the requesters can obtain. This only concerns role requests.
+	Roles []RoleRefSpec `json:"roles,omitempty"`
+	// Upper limits of the resource allocation that can be requested. Resource types that are not
+	// listed cannot be requested. This only concerns tenant requests.
+	MaxResourceAllocation map[corev1.ResourceName]resource.Quantity `json:"maxresourceallocation,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApprovalPolicyList is a list of ApprovalPolicy resources
+type ApprovalPolicyList struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+	// ObjectMeta contains the metadata for the particular object, including
+	metav1.ListMeta `json:"metadata"`
+	// ApprovalPolicyList is a list of ApprovalPolicy resources. This element contains
+	// ApprovalPolicy resources.
+	Items []ApprovalPolicy `json:"items"`
+}
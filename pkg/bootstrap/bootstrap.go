@@ -30,6 +30,7 @@ import (
 
 	antrea "antrea.io/antrea/pkg/client/clientset/versioned"
 	namecheap "github.com/billputer/go-namecheap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -161,3 +162,35 @@ func CreateAntreaClientset(by string) (*antrea.Clientset, error) {
 	}
 	return antreaclientset, nil
 }
+
+// CreateDynamicClientset generates the clientset to interact with the resources of any kind
+func CreateDynamicClientset(by string) (dynamic.Interface, error) {
+	var dynamicclientset dynamic.Interface
+	var generateClientset = func(config *rest.Config) dynamic.Interface {
+		// Create the clientset
+		dynamicclientset, err := dynamic.NewForConfig(config)
+		if err != nil {
+			// TODO: Error handling
+			panic(err.Error())
+		}
+		return dynamicclientset
+	}
+
+	if by == "kubeconfig" {
+		// Use the current context in kubeconfig
+		config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			log.Println(err.Error())
+			panic(err.Error())
+		}
+		dynamicclientset = generateClientset(config)
+	} else if by == "serviceaccount" {
+		// Creates the in-cluster config
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			panic(err.Error())
+		}
+		dynamicclientset = generateClientset(config)
+	}
+	return dynamicclientset, nil
+}
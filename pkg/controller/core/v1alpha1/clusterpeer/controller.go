@@ -0,0 +1,288 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpeer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/federation"
+	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
+	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+)
+
+const controllerAgentName = "clusterpeer-controller"
+
+// Definitions of the state of the clusterpeer resource
+const (
+	successSynced         = "Synced"
+	messageResourceSynced = "Cluster peer synced successfully"
+	successEstablished    = "Established"
+	messageEstablished    = "Member cluster is reachable"
+	failureKubeconfig     = "Kubeconfig Invalid"
+	messageKubeconfig     = "Clientset of the member cluster cannot be created with the kubeconfig secret"
+	failureConnection     = "Not Connected"
+	messageConnection     = "Member cluster is unreachable"
+	messageDisabled       = "Cluster peer is disabled"
+	failure               = "Failure"
+	established           = "Established"
+	disabled              = "Disabled"
+)
+
+// Reasons of the status conditions of the clusterpeer resource
+const (
+	reasonKubeconfigInvalid = "KubeconfigInvalid"
+	reasonUnreachable       = "Unreachable"
+	reasonConnected         = "Connected"
+	reasonDisabled          = "Disabled"
+)
+
+// Controller is the controller implementation for Cluster Peer resources
+type Controller struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	// edgenetclientset is a clientset for the EdgeNet API groups
+	edgenetclientset clientset.Interface
+
+	clusterpeersLister listers.ClusterPeerLister
+	clusterpeersSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue. This is used to queue work to be
+	// processed instead of performing it as soon as a change happens. This
+	// means we can ensure we only process a fixed amount of resources at a
+	// time, and makes it easy to ensure we are never processing the same item
+	// simultaneously in two different workers.
+	workqueue workqueue.RateLimitingInterface
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
+}
+
+// NewController returns a new controller
+func NewController(
+	kubeclientset kubernetes.Interface,
+	edgenetclientset clientset.Interface,
+	clusterpeerInformer informers.ClusterPeerInformer) *Controller {
+
+	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
+	klog.Infoln("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
+	controller := &Controller{
+		kubeclientset:      kubeclientset,
+		edgenetclientset:   edgenetclientset,
+		clusterpeersLister: clusterpeerInformer.Lister(),
+		clusterpeersSynced: clusterpeerInformer.Informer().HasSynced,
+		workqueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ClusterPeers"),
+		recorder:           recorder,
+	}
+
+	klog.Infoln("Setting up event handlers")
+	// Set up an event handler for when Cluster Peer resources change. Periodic resyncs
+	// arrive as updates as well, which lets the controller check the member clusters again.
+	clusterpeerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueClusterPeer,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueClusterPeer(new)
+		},
+	})
+
+	return controller
+}
+
+// Run will set up the event handlers for the type of cluster peer, as well
+// as syncing informer caches and starting workers. It will block until stopCh
+// is closed, at which point it will shutdown the workqueue and wait for
+// workers to finish processing their current work items.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.Infoln("Starting Cluster Peer controller")
+
+	klog.Infoln("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh,
+		c.clusterpeersSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.Infoln("Starting workers")
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.Infoln("Started workers")
+	<-stopCh
+	klog.Infoln("Shutting down workers")
+
+	return nil
+}
+
+// runWorker is a long-running function that will continually call the
+// processNextWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workqueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.workqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.syncHandler(key); err != nil {
+			c.workqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.workqueue.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// syncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Cluster Peer
+// resource with the current status of the resource.
+func (c *Controller) syncHandler(key string) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	clusterpeer, err := c.clusterpeersLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("clusterpeer '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	c.processClusterPeer(clusterpeer.DeepCopy())
+	c.recorder.Event(clusterpeer, corev1.EventTypeNormal, successSynced, messageResourceSynced)
+	return nil
+}
+
+// enqueueClusterPeer takes a ClusterPeer resource and converts it into a name
+// string which is then put onto the work queue. This method should *not* be
+// passed resources of any type other than ClusterPeer.
+func (c *Controller) enqueueClusterPeer(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// processClusterPeer checks whether the member cluster is reachable with the kubeconfig that the peer refers to
+func (c *Controller) processClusterPeer(clusterpeerCopy *corev1alpha1.ClusterPeer) {
+	oldStatus := clusterpeerCopy.Status.DeepCopy()
+	defer func() {
+		if !reflect.DeepEqual(*oldStatus, clusterpeerCopy.Status) {
+			if _, err := c.edgenetclientset.CoreV1alpha1().ClusterPeers().UpdateStatus(context.TODO(), clusterpeerCopy, metav1.UpdateOptions{}); err != nil {
+				klog.Infoln(err)
+			}
+		}
+	}()
+	clusterpeerCopy.Status.ObservedGeneration = clusterpeerCopy.GetGeneration()
+
+	if !clusterpeerCopy.Spec.Enabled {
+		clusterpeerCopy.Status.State = disabled
+		clusterpeerCopy.Status.Message = messageDisabled
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionPeerConnected, metav1.ConditionUnknown, reasonDisabled, messageDisabled)
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonDisabled, messageDisabled)
+		return
+	}
+
+	memberclientset, err := federation.GetMemberClientset(c.kubeclientset, clusterpeerCopy)
+	if err != nil {
+		c.recorder.Event(clusterpeerCopy, corev1.EventTypeWarning, failureKubeconfig, messageKubeconfig)
+		clusterpeerCopy.Status.State = failure
+		clusterpeerCopy.Status.Message = messageKubeconfig
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionPeerConnected, metav1.ConditionFalse, reasonKubeconfigInvalid, err.Error())
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonKubeconfigInvalid, messageKubeconfig)
+		klog.Infoln(err)
+		return
+	}
+	serverVersion, err := memberclientset.Discovery().ServerVersion()
+	if err != nil {
+		c.recorder.Event(clusterpeerCopy, corev1.EventTypeWarning, failureConnection, messageConnection)
+		clusterpeerCopy.Status.State = failure
+		clusterpeerCopy.Status.Message = messageConnection
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionPeerConnected, metav1.ConditionFalse, reasonUnreachable, err.Error())
+		util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonUnreachable, messageConnection)
+		klog.Infoln(err)
+		return
+	}
+
+	if clusterpeerCopy.Status.State != established {
+		c.recorder.Event(clusterpeerCopy, corev1.EventTypeNormal, successEstablished, messageEstablished)
+	}
+	clusterpeerCopy.Status.State = established
+	clusterpeerCopy.Status.Message = messageEstablished
+	clusterpeerCopy.Status.Version = serverVersion.GitVersion
+	util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionPeerConnected, metav1.ConditionTrue, reasonConnected, fmt.Sprintf("Member cluster runs Kubernetes %s", serverVersion.GitVersion))
+	util.SetCondition(&clusterpeerCopy.Status.Conditions, clusterpeerCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionTrue, reasonConnected, messageEstablished)
+}
@@ -0,0 +1,184 @@
+package clusterpeer
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/federation"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+	"github.com/EdgeNet-project/edgenet/pkg/signals"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/klog"
+)
+
+// The main structure of test group
+type TestGroup struct {
+	clusterPeerObj corev1alpha1.ClusterPeer
+	secretObj      corev1.Secret
+}
+
+var kubeclientset kubernetes.Interface = testclient.NewSimpleClientset()
+var edgenetclientset versioned.Interface = edgenettestclient.NewSimpleClientset()
+var memberclientset = testclient.NewSimpleClientset()
+
+func TestMain(m *testing.M) {
+	klog.SetOutput(ioutil.Discard)
+	log.SetOutput(ioutil.Discard)
+	logrus.SetOutput(ioutil.Discard)
+
+	flag.String("dir", "../../../../..", "Override the directory.")
+	flag.String("smtp-path", "../../../../../configs/smtp_test.yaml", "Set SMTP path.")
+	flag.Parse()
+
+	federation.CreateMemberClientset = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		if string(kubeconfig) != "member" {
+			return nil, fmt.Errorf("invalid kubeconfig")
+		}
+		return memberclientset, nil
+	}
+
+	stopCh := signals.SetupSignalHandler()
+
+	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, time.Second*30)
+
+	controller := NewController(kubeclientset,
+		edgenetclientset,
+		edgenetInformerFactory.Core().V1alpha1().ClusterPeers())
+
+	edgenetInformerFactory.Start(stopCh)
+
+	go func() {
+		if err := controller.Run(2, stopCh); err != nil {
+			klog.Fatalf("Error running controller: %s", err.Error())
+		}
+	}()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "edgenet"}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{})
+
+	time.Sleep(500 * time.Millisecond)
+
+	os.Exit(m.Run())
+	<-stopCh
+}
+
+func (g *TestGroup) Init() {
+	clusterPeerObj := corev1alpha1.ClusterPeer{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterPeer",
+			APIVersion: "core.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "member",
+		},
+		Spec: corev1alpha1.ClusterPeerSpec{
+			SecretName: "member-kubeconfig",
+			Enabled:    true,
+		},
+	}
+	secretObj := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "member-kubeconfig",
+			Namespace: "edgenet",
+		},
+		Data: map[string][]byte{federation.KubeconfigKey: []byte("member")},
+	}
+	g.clusterPeerObj = clusterPeerObj
+	g.secretObj = secretObj
+}
+
+func TestStartController(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	secret := g.secretObj.DeepCopy()
+	secret.SetName(util.GenerateRandomString(6))
+	kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+	clusterPeer := g.clusterPeerObj.DeepCopy()
+	clusterPeer.SetName(util.GenerateRandomString(6))
+	clusterPeer.Spec.SecretName = secret.GetName()
+	edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), clusterPeer, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	clusterPeer, err := edgenetclientset.CoreV1alpha1().ClusterPeers().Get(context.TODO(), clusterPeer.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, established, clusterPeer.Status.State)
+
+	t.Run("disable", func(t *testing.T) {
+		clusterPeerCopy := clusterPeer.DeepCopy()
+		clusterPeerCopy.Spec.Enabled = false
+		edgenetclientset.CoreV1alpha1().ClusterPeers().Update(context.TODO(), clusterPeerCopy, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		clusterPeerCopy, err := edgenetclientset.CoreV1alpha1().ClusterPeers().Get(context.TODO(), clusterPeer.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, disabled, clusterPeerCopy.Status.State)
+	})
+}
+
+func TestFailure(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	t.Run("missing secret", func(t *testing.T) {
+		clusterPeer := g.clusterPeerObj.DeepCopy()
+		clusterPeer.SetName(util.GenerateRandomString(6))
+		clusterPeer.Spec.SecretName = util.GenerateRandomString(6)
+		edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), clusterPeer, metav1.CreateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		clusterPeer, err := edgenetclientset.CoreV1alpha1().ClusterPeers().Get(context.TODO(), clusterPeer.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, clusterPeer.Status.State)
+		util.Equals(t, messageKubeconfig, clusterPeer.Status.Message)
+	})
+	t.Run("invalid kubeconfig", func(t *testing.T) {
+		secret := g.secretObj.DeepCopy()
+		secret.SetName(util.GenerateRandomString(6))
+		secret.Data = map[string][]byte{federation.KubeconfigKey: []byte("invalid")}
+		kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+		clusterPeer := g.clusterPeerObj.DeepCopy()
+		clusterPeer.SetName(util.GenerateRandomString(6))
+		clusterPeer.Spec.SecretName = secret.GetName()
+		edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), clusterPeer, metav1.CreateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		clusterPeer, err := edgenetclientset.CoreV1alpha1().ClusterPeers().Get(context.TODO(), clusterPeer.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, clusterPeer.Status.State)
+		util.Equals(t, messageKubeconfig, clusterPeer.Status.Message)
+	})
+	t.Run("unreachable member", func(t *testing.T) {
+		memberclientset.PrependReactor("get", "version", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("connection refused")
+		})
+		defer func() {
+			memberclientset.ReactionChain = memberclientset.ReactionChain[1:]
+		}()
+		secret := g.secretObj.DeepCopy()
+		secret.SetName(util.GenerateRandomString(6))
+		kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+		clusterPeer := g.clusterPeerObj.DeepCopy()
+		clusterPeer.SetName(util.GenerateRandomString(6))
+		clusterPeer.Spec.SecretName = secret.GetName()
+		edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), clusterPeer, metav1.CreateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		clusterPeer, err := edgenetclientset.CoreV1alpha1().ClusterPeers().Get(context.TODO(), clusterPeer.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, clusterPeer.Status.State)
+		util.Equals(t, messageConnection, clusterPeer.Status.Message)
+	})
+}
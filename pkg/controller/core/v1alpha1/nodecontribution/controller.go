@@ -36,6 +36,7 @@ import (
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
 	"github.com/EdgeNet-project/edgenet/pkg/remoteip"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -81,6 +82,15 @@ const (
 	unknownStr            = "Unknown"
 )
 
+// Reasons of the node joined condition
+const (
+	reasonInQueue    = "InQueue"
+	reasonInProgress = "InProgress"
+	reasonFailure    = "Failure"
+	reasonIncomplete = "Incomplete"
+	reasonJoined     = "Joined"
+)
+
 // Dictionary of status messages
 var statusDict = map[string]string{
 	"successful":              "Node is up and running",
@@ -290,6 +300,8 @@ func (c *Controller) syncHandler(key string) error {
 		c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, inqueue, statusDict["in-queue"])
 		nodecontributionCopy.Status.State = inqueue
 		nodecontributionCopy.Status.Message = statusDict["in-queue"]
+		util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonInQueue, nodecontributionCopy.Status.Message)
+		nodecontributionCopy.Status.ObservedGeneration = nodecontributionCopy.GetGeneration()
 		_, err := c.edgenetclientset.CoreV1alpha1().NodeContributions().UpdateStatus(context.TODO(), nodecontributionCopy, metav1.UpdateOptions{})
 		klog.Infoln(err)
 		return nil
@@ -310,6 +322,8 @@ func (c *Controller) init(nodecontribution *corev1alpha1.NodeContribution) {
 		c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, failure, statusDict["invalid-host"])
 		nodecontributionCopy.Status.State = failure
 		nodecontributionCopy.Status.Message = statusDict["invalid-host"]
+		util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonFailure, nodecontributionCopy.Status.Message)
+		nodecontributionCopy.Status.ObservedGeneration = nodecontributionCopy.GetGeneration()
 		c.edgenetclientset.CoreV1alpha1().NodeContributions().UpdateStatus(context.TODO(), nodecontributionCopy, metav1.UpdateOptions{})
 		return
 	}
@@ -331,6 +345,7 @@ func (c *Controller) init(nodecontribution *corev1alpha1.NodeContribution) {
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, incomplete, statusDict["configuration-failure"])
 				nodecontributionCopy.Status.State = incomplete
 				nodecontributionCopy.Status.Message = statusDict["configuration-failure"]
+				util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonIncomplete, nodecontributionCopy.Status.Message)
 			} else {
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, setupProcedure, messageDonePatch)
 			}
@@ -339,11 +354,14 @@ func (c *Controller) init(nodecontribution *corev1alpha1.NodeContribution) {
 			c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, success, statusDict["successful"])
 			nodecontributionCopy.Status.State = success
 			nodecontributionCopy.Status.Message = statusDict["successful"]
+			util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionTrue, reasonJoined, nodecontributionCopy.Status.Message)
 		} else {
 			c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, failure, statusDict["failure"])
 			nodecontributionCopy.Status.State = failure
 			nodecontributionCopy.Status.Message = statusDict["failure"]
+			util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonFailure, nodecontributionCopy.Status.Message)
 		}
+		nodecontributionCopy.Status.ObservedGeneration = nodecontributionCopy.GetGeneration()
 		c.edgenetclientset.CoreV1alpha1().NodeContributions().UpdateStatus(context.TODO(), nodecontributionCopy, metav1.UpdateOptions{})
 	} else {
 		c.balanceMultiThreading(5)
@@ -456,10 +474,13 @@ func (c *Controller) setup(addr, nodeName, recordType string, config *ssh.Client
 	c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, inprogress, statusDict["in-progress"])
 	nodecontributionCopy.Status.State = inprogress
 	nodecontributionCopy.Status.Message = statusDict["in-progress"]
+	util.SetCondition(&nodecontributionCopy.Status.Conditions, nodecontributionCopy.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonInProgress, nodecontributionCopy.Status.Message)
+	nodecontributionCopy.Status.ObservedGeneration = nodecontributionCopy.GetGeneration()
 	nodecontributionUpdated, err := c.edgenetclientset.CoreV1alpha1().NodeContributions().UpdateStatus(context.TODO(), nodecontributionCopy, metav1.UpdateOptions{})
 
 	defer func() {
 		if !reflect.DeepEqual(nodecontributionCopy.Status, nodecontributionUpdated.Status) {
+			nodecontributionUpdated.Status.ObservedGeneration = nodecontributionUpdated.GetGeneration()
 			if _, err := c.edgenetclientset.CoreV1alpha1().NodeContributions().UpdateStatus(context.TODO(), nodecontributionUpdated, metav1.UpdateOptions{}); err != nil {
 				// TO-DO: Provide more information on error
 				klog.Info(err)
@@ -492,6 +513,7 @@ nodeSetupLoop:
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, incomplete, hostnameError)
 				nodecontributionUpdated.Status.State = incomplete
 				nodecontributionUpdated.Status.Message = hostnameError
+				util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonIncomplete, nodecontributionUpdated.Status.Message)
 				klog.Info(hostnameError)
 			} else {
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, setupProcedure, messageDoneDNS)
@@ -512,6 +534,7 @@ nodeSetupLoop:
 					c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, failure, statusDict["ssh-failure"])
 					nodecontributionUpdated.Status.State = failure
 					nodecontributionUpdated.Status.Message = statusDict["ssh-failure"]
+					util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonFailure, nodecontributionUpdated.Status.Message)
 					klog.Info(err)
 					endProcedure <- true
 					return
@@ -533,6 +556,7 @@ nodeSetupLoop:
 					c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, failure, statusDict["join-failure"])
 					nodecontributionUpdated.Status.State = failure
 					nodecontributionUpdated.Status.Message = statusDict["join-failure"]
+					util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonFailure, nodecontributionUpdated.Status.Message)
 					klog.Info(err)
 					endProcedure <- true
 					return
@@ -551,6 +575,7 @@ nodeSetupLoop:
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, incomplete, statusDict["configuration-failure"])
 				nodecontributionUpdated.Status.State = incomplete
 				nodecontributionUpdated.Status.Message = statusDict["configuration-failure"]
+				util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonIncomplete, nodecontributionUpdated.Status.Message)
 			} else {
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeNormal, setupProcedure, messageDonePatch)
 			}
@@ -566,6 +591,7 @@ nodeSetupLoop:
 				c.recorder.Event(nodecontributionCopy, corev1.EventTypeWarning, incomplete, statusDict["owner-reference-failure"])
 				nodecontributionUpdated.Status.State = incomplete
 				nodecontributionUpdated.Status.Message = statusDict["owner-reference-failure"]
+				util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonIncomplete, nodecontributionUpdated.Status.Message)
 			}
 			endProcedure <- true
 		case <-endProcedure:
@@ -578,6 +604,7 @@ nodeSetupLoop:
 			// Terminate the procedure after 5 minutes
 			nodecontributionUpdated.Status.State = failure
 			nodecontributionUpdated.Status.Message = statusDict["timeout"]
+			util.SetCondition(&nodecontributionUpdated.Status.Conditions, nodecontributionUpdated.GetGeneration(), corev1alpha1.ConditionNodeJoined, metav1.ConditionFalse, reasonFailure, nodecontributionUpdated.Status.Message)
 			klog.Info(err)
 			break nodeSetupLoop
 		}
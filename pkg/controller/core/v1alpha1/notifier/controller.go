@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"net/mail"
-	"reflect"
 	"regexp"
 	"time"
 
@@ -50,8 +49,10 @@ const controllerAgentName = "notifier-controller"
 
 // Definitions of the state of the tenantrequest resource
 const (
-	failure = "Failure"
-	pending = "Pending"
+	failure  = "Failure"
+	pending  = "Pending"
+	approved = "Approved"
+	denied   = "Denied"
 )
 
 // The main structure of controller
@@ -65,6 +66,8 @@ type Controller struct {
 	rolerequestsSynced        cache.InformerSynced
 	clusterrolerequestsLister listers.ClusterRoleRequestLister
 	clusterrolerequestsSynced cache.InformerSynced
+	quotarequestsLister       listers.QuotaRequestLister
+	quotarequestsSynced       cache.InformerSynced
 
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
@@ -74,6 +77,7 @@ type Controller struct {
 	workqueueTenantRequest      workqueue.RateLimitingInterface
 	workqueueClusterRoleRequest workqueue.RateLimitingInterface
 	workqueueRoleRequest        workqueue.RateLimitingInterface
+	workqueueQuotaRequest       workqueue.RateLimitingInterface
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	recorder record.EventRecorder
@@ -85,7 +89,8 @@ func NewController(
 	edgenetclientset clientset.Interface,
 	tenantrequestInformer informers.TenantRequestInformer,
 	rolerequestInformer informers.RoleRequestInformer,
-	clusterrolerequestInformer informers.ClusterRoleRequestInformer) *Controller {
+	clusterrolerequestInformer informers.ClusterRoleRequestInformer,
+	quotarequestInformer informers.QuotaRequestInformer) *Controller {
 	// Create event broadcaster
 	utilruntime.Must(scheme.AddToScheme(scheme.Scheme))
 	klog.Infoln("Creating event broadcaster")
@@ -103,19 +108,23 @@ func NewController(
 		rolerequestsSynced:          rolerequestInformer.Informer().HasSynced,
 		clusterrolerequestsLister:   clusterrolerequestInformer.Lister(),
 		clusterrolerequestsSynced:   clusterrolerequestInformer.Informer().HasSynced,
+		quotarequestsLister:         quotarequestInformer.Lister(),
+		quotarequestsSynced:         quotarequestInformer.Informer().HasSynced,
 		workqueueTenantRequest:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NotifierTenantRequest"),
 		workqueueClusterRoleRequest: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NotifierClusterRoleRequest"),
 		workqueueRoleRequest:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NotifierRoleRequest"),
+		workqueueQuotaRequest:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "NotifierQuotaRequest"),
 		recorder:                    recorder,
 	}
 	klog.Infoln("Setting up event handlers")
 
-	// Event handlers deal with events of resources.
+	// Event handlers deal with events of resources. Only a new state is notified, as the conditions of
+	// the requests change on every reconcile.
 	tenantrequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: func(old, new interface{}) {
 			newTenantRequest := new.(*registrationv1alpha1.TenantRequest)
 			oldTenantRequest := old.(*registrationv1alpha1.TenantRequest)
-			if !reflect.DeepEqual(newTenantRequest.Status, oldTenantRequest.Status) {
+			if newTenantRequest.Status.State != oldTenantRequest.Status.State {
 				controller.enqueueNotifier(new)
 			}
 		},
@@ -124,7 +133,7 @@ func NewController(
 		UpdateFunc: func(old, new interface{}) {
 			newRoleRequest := new.(*registrationv1alpha1.RoleRequest)
 			oldRoleRequest := old.(*registrationv1alpha1.RoleRequest)
-			if !reflect.DeepEqual(newRoleRequest.Status, oldRoleRequest.Status) {
+			if newRoleRequest.Status.State != oldRoleRequest.Status.State {
 				controller.enqueueNotifier(new)
 			}
 		},
@@ -133,11 +142,22 @@ func NewController(
 		UpdateFunc: func(old, new interface{}) {
 			newClusterRoleRequest := new.(*registrationv1alpha1.ClusterRoleRequest)
 			oldClusterRoleRequest := old.(*registrationv1alpha1.ClusterRoleRequest)
-			if !reflect.DeepEqual(newClusterRoleRequest.Status, oldClusterRoleRequest.Status) {
+			if newClusterRoleRequest.Status.State != oldClusterRoleRequest.Status.State {
 				controller.enqueueNotifier(new)
 			}
 		},
 	})
+	quotarequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			newQuotaRequest := new.(*registrationv1alpha1.QuotaRequest)
+			oldQuotaRequest := old.(*registrationv1alpha1.QuotaRequest)
+			if newQuotaRequest.Status.State != oldQuotaRequest.Status.State {
+				controller.enqueueNotifier(new)
+			}
+		},
+	})
+
+	access.Clientset = kubeclientset
 
 	return controller
 }
@@ -147,6 +167,7 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	defer c.workqueueTenantRequest.ShutDown()
 	defer c.workqueueClusterRoleRequest.ShutDown()
 	defer c.workqueueRoleRequest.ShutDown()
+	defer c.workqueueQuotaRequest.ShutDown()
 
 	klog.Infoln("Starting Notifier Controller")
 
@@ -155,7 +176,8 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	if ok := cache.WaitForCacheSync(stopCh,
 		c.tenantrequestsSynced,
 		c.rolerequestsSynced,
-		c.clusterrolerequestsSynced); !ok {
+		c.clusterrolerequestsSynced,
+		c.quotarequestsSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
@@ -180,8 +202,9 @@ func (c *Controller) processNextWorkItem() bool {
 	isSyncedTenant := c.processNextTenantRequestItem()
 	isSyncedClusterRoleRequest := c.processNextClusterRoleRequestItem()
 	isSyncedRoleRequest := c.processNextRoleRequestItem()
+	isSyncedQuotaRequest := c.processNextQuotaRequestItem()
 
-	if !isSyncedTenant && !isSyncedClusterRoleRequest && !isSyncedRoleRequest {
+	if !isSyncedTenant && !isSyncedClusterRoleRequest && !isSyncedRoleRequest && !isSyncedQuotaRequest {
 		return false
 	}
 	return true
@@ -295,6 +318,42 @@ func (c *Controller) processNextRoleRequestItem() bool {
 	return true
 }
 
+func (c *Controller) processNextQuotaRequestItem() bool {
+	obj, shutdown := c.workqueueQuotaRequest.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workqueueQuotaRequest.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.workqueueQuotaRequest.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.syncQuotaRequestHandler(key); err != nil {
+			c.workqueueQuotaRequest.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+
+		c.workqueueQuotaRequest.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
 // syncTenantRequestHandler looks at the actual state and sends a notification if desired.
 func (c *Controller) syncTenantRequestHandler(key string) error {
 	_, name, err := cache.SplitMetaNamespaceKey(key)
@@ -361,6 +420,28 @@ func (c *Controller) syncRoleRequestHandler(key string) error {
 	return nil
 }
 
+// syncQuotaRequestHandler looks at the actual state and sends a notification if desired.
+func (c *Controller) syncQuotaRequestHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+	quotarequest, err := c.quotarequestsLister.QuotaRequests(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("quota request '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+	klog.Infof("processNextQuotaRequestItem: object created/updated detected: %s", key)
+	c.processQuotaRequest(quotarequest)
+
+	return nil
+}
+
 func (c *Controller) enqueueNotifier(obj interface{}) {
 	// Put the resource object into a key
 	var key string
@@ -378,6 +459,8 @@ func (c *Controller) enqueueNotifier(obj interface{}) {
 		c.workqueueClusterRoleRequest.Add(key)
 	case *registrationv1alpha1.RoleRequest:
 		c.workqueueRoleRequest.Add(key)
+	case *registrationv1alpha1.QuotaRequest:
+		c.workqueueQuotaRequest.Add(key)
 	}
 }
 
@@ -406,19 +489,15 @@ func (c *Controller) processTenantRequest(tenantrequest *registrationv1alpha1.Te
 					if subjectRow.Kind == "User" {
 						_, err := mail.ParseAddress(subjectRow.Name)
 						if err == nil {
-							subjectAccessReview := new(authorizationv1.SubjectAccessReview)
-							resourceAttributes := new(authorizationv1.ResourceAttributes)
-							resourceAttributes.Group = "registration.edgenet.io"
-							resourceAttributes.Version = "v1alpha1"
-							resourceAttributes.Resource = "tenantrequests"
-							resourceAttributes.Verb = "UPDATE"
-							resourceAttributes.Name = tenantrequest.GetName()
-							subjectAccessReview.Spec.ResourceAttributes = resourceAttributes
-							subjectAccessReview.Spec.User = subjectRow.Name
-							if subjectAccessReviewResult, err := c.kubeclientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), subjectAccessReview, metav1.CreateOptions{}); err == nil {
-								if subjectAccessReviewResult.Status.Allowed {
-									emailList = append(emailList, subjectRow.Name)
-								}
+							resourceAttributes := authorizationv1.ResourceAttributes{
+								Group:    "registration.edgenet.io",
+								Version:  "v1alpha1",
+								Resource: "tenantrequests",
+								Verb:     "update",
+								Name:     tenantrequest.GetName(),
+							}
+							if access.CheckAuthorization(subjectRow.Name, nil, resourceAttributes) {
+								emailList = append(emailList, subjectRow.Name)
 							}
 						}
 					}
@@ -432,7 +511,12 @@ func (c *Controller) processTenantRequest(tenantrequest *registrationv1alpha1.Te
 			access.SendSlackNotificationForTenantRequest(tenantrequest, "tenant-request-made", "[EdgeNet Admin] A tenant request made",
 				string(systemNamespace.GetUID()))
 		}
-	} else {
+	} else if tenantrequest.Status.State == denied {
+		access.SendEmailForTenantRequest(tenantrequest, "tenant-request-denied", "[EdgeNet] Tenant request denied",
+			string(systemNamespace.GetUID()), []string{tenantrequest.Spec.Contact.Email})
+		access.SendSlackNotificationForTenantRequest(tenantrequest, "tenant-request-denied", "[EdgeNet] Tenant request denied",
+			string(systemNamespace.GetUID()))
+	} else if tenantrequest.Status.State == approved {
 		access.SendEmailForTenantRequest(tenantrequest, "tenant-request-approved", "[EdgeNet] Tenant request approved",
 			string(systemNamespace.GetUID()), []string{tenantrequest.Spec.Contact.Email})
 		access.SendSlackNotificationForTenantRequest(tenantrequest, "tenant-request-approved", "[EdgeNet] Tenant request approved",
@@ -464,20 +548,16 @@ func (c *Controller) processRoleRequest(rolerequest *registrationv1alpha1.RoleRe
 					if subjectRow.Kind == "User" {
 						_, err := mail.ParseAddress(subjectRow.Name)
 						if err == nil {
-							subjectAccessReview := new(authorizationv1.SubjectAccessReview)
-							resourceAttributes := new(authorizationv1.ResourceAttributes)
-							resourceAttributes.Group = "registration.edgenet.io"
-							resourceAttributes.Version = "v1alpha1"
-							resourceAttributes.Resource = "rolerequests"
-							resourceAttributes.Verb = "UPDATE"
-							resourceAttributes.Namespace = rolerequest.GetNamespace()
-							resourceAttributes.Name = rolerequest.GetName()
-							subjectAccessReview.Spec.ResourceAttributes = resourceAttributes
-							subjectAccessReview.Spec.User = subjectRow.Name
-							if subjectAccessReviewResult, err := c.kubeclientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), subjectAccessReview, metav1.CreateOptions{}); err == nil {
-								if subjectAccessReviewResult.Status.Allowed {
-									emailList = append(emailList, subjectRow.Name)
-								}
+							resourceAttributes := authorizationv1.ResourceAttributes{
+								Group:     "registration.edgenet.io",
+								Version:   "v1alpha1",
+								Resource:  "rolerequests",
+								Verb:      "update",
+								Namespace: rolerequest.GetNamespace(),
+								Name:      rolerequest.GetName(),
+							}
+							if access.CheckAuthorization(subjectRow.Name, nil, resourceAttributes) {
+								emailList = append(emailList, subjectRow.Name)
 							}
 						}
 					}
@@ -490,7 +570,12 @@ func (c *Controller) processRoleRequest(rolerequest *registrationv1alpha1.RoleRe
 			access.SendSlackNotificationForRoleRequest(rolerequest, "role-request-made", "[EdgeNet] A role request made",
 				string(systemNamespace.GetUID()))
 		}
-	} else {
+	} else if rolerequest.Status.State == denied {
+		access.SendEmailForRoleRequest(rolerequest, "role-request-denied", "[EdgeNet] Role request denied",
+			string(systemNamespace.GetUID()), []string{rolerequest.Spec.Email})
+		access.SendSlackNotificationForRoleRequest(rolerequest, "role-request-denied", "[EdgeNet] Role request denied",
+			string(systemNamespace.GetUID()))
+	} else if rolerequest.Status.State == approved {
 		access.SendEmailForRoleRequest(rolerequest, "role-request-approved", "[EdgeNet] Role request approved",
 			string(systemNamespace.GetUID()), []string{rolerequest.Spec.Email})
 		access.SendSlackNotificationForRoleRequest(rolerequest, "role-request-approved", "[EdgeNet] Role request approved",
@@ -522,19 +607,15 @@ func (c *Controller) processClusterRoleRequest(clusterRolerequest *registrationv
 					if subjectRow.Kind == "User" {
 						_, err := mail.ParseAddress(subjectRow.Name)
 						if err == nil {
-							subjectAccessReview := new(authorizationv1.SubjectAccessReview)
-							resourceAttributes := new(authorizationv1.ResourceAttributes)
-							resourceAttributes.Group = "registration.edgenet.io"
-							resourceAttributes.Version = "v1alpha1"
-							resourceAttributes.Resource = "clusterrolerequests"
-							resourceAttributes.Verb = "UPDATE"
-							resourceAttributes.Name = clusterRolerequest.GetName()
-							subjectAccessReview.Spec.ResourceAttributes = resourceAttributes
-							subjectAccessReview.Spec.User = subjectRow.Name
-							if subjectAccessReviewResult, err := c.kubeclientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), subjectAccessReview, metav1.CreateOptions{}); err == nil {
-								if subjectAccessReviewResult.Status.Allowed {
-									emailList = append(emailList, subjectRow.Name)
-								}
+							resourceAttributes := authorizationv1.ResourceAttributes{
+								Group:    "registration.edgenet.io",
+								Version:  "v1alpha1",
+								Resource: "clusterrolerequests",
+								Verb:     "update",
+								Name:     clusterRolerequest.GetName(),
+							}
+							if access.CheckAuthorization(subjectRow.Name, nil, resourceAttributes) {
+								emailList = append(emailList, subjectRow.Name)
 							}
 						}
 					}
@@ -547,10 +628,70 @@ func (c *Controller) processClusterRoleRequest(clusterRolerequest *registrationv
 			access.SendSlackNotificationForClusterRoleRequest(clusterRolerequest, "clusterrole-request-made", "[EdgeNet] A cluster role request made",
 				string(systemNamespace.GetUID()))
 		}
-	} else {
+	} else if clusterRolerequest.Status.State == denied {
+		access.SendEmailForClusterRoleRequest(clusterRolerequest, "clusterrole-request-denied", "[EdgeNet] Cluster role request denied",
+			string(systemNamespace.GetUID()), []string{clusterRolerequest.Spec.Email})
+		access.SendSlackNotificationForClusterRoleRequest(clusterRolerequest, "clusterrole-request-denied", "[EdgeNet] Cluster role request denied",
+			string(systemNamespace.GetUID()))
+	} else if clusterRolerequest.Status.State == approved {
 		access.SendEmailForClusterRoleRequest(clusterRolerequest, "clusterrole-request-approved", "[EdgeNet] Cluster role request approved",
 			string(systemNamespace.GetUID()), []string{clusterRolerequest.Spec.Email})
 		access.SendSlackNotificationForClusterRoleRequest(clusterRolerequest, "clusterrole-request-approved", "[EdgeNet] Cluster role request approved",
 			string(systemNamespace.GetUID()))
 	}
 }
+
+func (c *Controller) processQuotaRequest(quotarequest *registrationv1alpha1.QuotaRequest) {
+	klog.Infoln("processQuotaRequest")
+
+	systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	if quotarequest.Status.State == failure || quotarequest.Status.State == "" {
+		return
+	} else if quotarequest.Status.State == pending {
+		// The function below notifies those who have the right to approve this quota request.
+		// Quota requests reside in tenant namespaces; however, tenants cannot grant resources to themselves. Thus, we check the permissions
+		// granted by Cluster Role Binding following a pattern as it is the cluster administration that approves these requests.
+		// Furthermore, only those to which the system has granted permission, by attaching the "edge-net.io/generated=true" label, receive a notification email.
+		emailList := []string{}
+		if clusterRoleBindingRaw, err := c.kubeclientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			r, _ := regexp.Compile("(.*)(edgenet:clusteradministration)(.*)(admin|manager|deputy)(.*)")
+			for _, clusterRoleBindingRow := range clusterRoleBindingRaw.Items {
+				if match := r.MatchString(clusterRoleBindingRow.GetName()); !match {
+					continue
+				}
+				for _, subjectRow := range clusterRoleBindingRow.Subjects {
+					if subjectRow.Kind == "User" {
+						_, err := mail.ParseAddress(subjectRow.Name)
+						if err == nil {
+							resourceAttributes := authorizationv1.ResourceAttributes{
+								Group:     "registration.edgenet.io",
+								Version:   "v1alpha1",
+								Resource:  "quotarequests",
+								Verb:      "update",
+								Namespace: quotarequest.GetNamespace(),
+								Name:      quotarequest.GetName(),
+							}
+							if access.CheckAuthorization(subjectRow.Name, nil, resourceAttributes) {
+								emailList = append(emailList, subjectRow.Name)
+							}
+						}
+					}
+				}
+			}
+		}
+		if len(emailList) > 0 {
+			access.SendEmailForQuotaRequest(quotarequest, "quota-request-made", "[EdgeNet Admin] A quota request made",
+				string(systemNamespace.GetUID()), emailList)
+			access.SendSlackNotificationForQuotaRequest(quotarequest, "quota-request-made", "[EdgeNet Admin] A quota request made",
+				string(systemNamespace.GetUID()))
+		}
+	} else if quotarequest.Status.State == approved {
+		access.SendEmailForQuotaRequest(quotarequest, "quota-request-approved", "[EdgeNet] Quota request approved",
+			string(systemNamespace.GetUID()), []string{quotarequest.Spec.Email})
+		access.SendSlackNotificationForQuotaRequest(quotarequest, "quota-request-approved", "[EdgeNet] Quota request approved",
+			string(systemNamespace.GetUID()))
+	}
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roletemplate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
+	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	rbacinformers "k8s.io/client-go/informers/rbac/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+)
+
+const controllerAgentName = "roletemplate-controller"
+
+// Definitions of the state of the roletemplate resource
+const (
+	successSynced         = "Synced"
+	messageResourceSynced = "Role template synced successfully"
+	successEstablished    = "Established"
+	messageEstablished    = "Role template established successfully"
+	failureRules          = "Rules Exceeded"
+	messageRulesExceeded  = "Role template rules exceed the tenant owner role"
+	failureCreation       = "Creation Failed"
+	messageCreationFailed = "Role creation failed"
+	failureRemoval        = "Not Removed"
+	messageRemovalFailed  = "Role removal failed"
+	failure               = "Failure"
+	established           = "Established"
+)
+
+// Reasons of the status conditions of the roletemplate resource
+const (
+	reasonRulesExceeded  = "RulesExceeded"
+	reasonCreationFailed = "CreationFailed"
+	reasonEstablished    = "Established"
+)
+
+// roleFinalizer lets the controller remove the role that materializes the template before the template is deleted
+const roleFinalizer = "core.edgenet.io/role-template"
+
+// roleTemplateLabel is attached to the roles materializing role templates, and holds the name of the template
+const roleTemplateLabel = "edge-net.io/role-template"
+
+// Controller is the controller implementation for Role Template resources
+type Controller struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	// edgenetclientset is a clientset for the EdgeNet API groups
+	edgenetclientset clientset.Interface
+
+	roletemplatesLister listers.RoleTemplateLister
+	roletemplatesSynced cache.InformerSynced
+
+	rolesSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue. This is used to queue work to be
+	// processed instead of performing it as soon as a change happens. This
+	// means we can ensure we only process a fixed amount of resources at a
+	// time, and makes it easy to ensure we are never processing the same item
+	// simultaneously in two different workers.
+	workqueue workqueue.RateLimitingInterface
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
+}
+
+// NewController returns a new controller
+func NewController(
+	kubeclientset kubernetes.Interface,
+	edgenetclientset clientset.Interface,
+	roleInformer rbacinformers.RoleInformer,
+	roletemplateInformer informers.RoleTemplateInformer) *Controller {
+
+	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
+	klog.Infoln("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
+	controller := &Controller{
+		kubeclientset:       kubeclientset,
+		edgenetclientset:    edgenetclientset,
+		roletemplatesLister: roletemplateInformer.Lister(),
+		roletemplatesSynced: roletemplateInformer.Informer().HasSynced,
+		rolesSynced:         roleInformer.Informer().HasSynced,
+		workqueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RoleTemplates"),
+		recorder:            recorder,
+	}
+
+	klog.Infoln("Setting up event handlers")
+	// Set up an event handler for when Role Template resources change
+	roletemplateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueRoleTemplate,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueRoleTemplate(new)
+		},
+	})
+	// The roles materializing the templates are restored when someone modifies or removes them
+	roleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			newRole := new.(*rbacv1.Role)
+			oldRole := old.(*rbacv1.Role)
+			if newRole.ResourceVersion == oldRole.ResourceVersion {
+				return
+			}
+			controller.handleObject(new)
+		},
+		DeleteFunc: controller.handleObject,
+	})
+
+	return controller
+}
+
+// Run will set up the event handlers for the types of role template and role, as well
+// as syncing informer caches and starting workers. It will block until stopCh
+// is closed, at which point it will shutdown the workqueue and wait for
+// workers to finish processing their current work items.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.Infoln("Starting Role Template controller")
+
+	klog.Infoln("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh,
+		c.rolesSynced,
+		c.roletemplatesSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.Infoln("Starting workers")
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.Infoln("Started workers")
+	<-stopCh
+	klog.Infoln("Shutting down workers")
+
+	return nil
+}
+
+// runWorker is a long-running function that will continually call the
+// processNextWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workqueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.workqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.syncHandler(key); err != nil {
+			c.workqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.workqueue.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// syncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Role Template
+// resource with the current status of the resource.
+func (c *Controller) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	roletemplate, err := c.roletemplatesLister.RoleTemplates(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("roletemplate '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	if roletemplate.GetDeletionTimestamp() != nil {
+		return c.finalizeRoleTemplate(roletemplate.DeepCopy())
+	}
+	if !hasFinalizer(roletemplate) {
+		// The template is processed once the update event of the finalizer arrives
+		roletemplateCopy := roletemplate.DeepCopy()
+		roletemplateCopy.SetFinalizers(append(roletemplateCopy.GetFinalizers(), roleFinalizer))
+		_, err := c.edgenetclientset.CoreV1alpha1().RoleTemplates(roletemplateCopy.GetNamespace()).Update(context.TODO(), roletemplateCopy, metav1.UpdateOptions{})
+		return err
+	}
+
+	c.processRoleTemplate(roletemplate.DeepCopy())
+	c.recorder.Event(roletemplate, corev1.EventTypeNormal, successSynced, messageResourceSynced)
+	return nil
+}
+
+// enqueueRoleTemplate takes a RoleTemplate resource and converts it into a namespace/name
+// string which is then put onto the work queue. This method should *not* be
+// passed resources of any type other than RoleTemplate.
+func (c *Controller) enqueueRoleTemplate(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// handleObject will take any resource implementing metav1.Object and attempt
+// to find the RoleTemplate resource that it materializes. It does this by looking at the
+// role template label of the object. It then enqueues that RoleTemplate resource to be processed.
+// If the object does not have the label, it will simply be skipped.
+func (c *Controller) handleObject(obj interface{}) {
+	var object metav1.Object
+	var ok bool
+	if object, ok = obj.(metav1.Object); !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+		klog.Infof("Recovered deleted object '%s' from tombstone", object.GetName())
+	}
+	if name, elementExists := object.GetLabels()[roleTemplateLabel]; elementExists {
+		roletemplate, err := c.roletemplatesLister.RoleTemplates(object.GetNamespace()).Get(name)
+		if err != nil {
+			// Roles inherited from the parent namespace do not have a template in their namespace
+			return
+		}
+		c.enqueueRoleTemplate(roletemplate)
+	}
+}
+
+// processRoleTemplate validates the rules of the template and makes sure that a role with the same rules exists in the namespace
+func (c *Controller) processRoleTemplate(roletemplateCopy *corev1alpha1.RoleTemplate) {
+	oldStatus := roletemplateCopy.Status.DeepCopy()
+	defer func() {
+		if !reflect.DeepEqual(*oldStatus, roletemplateCopy.Status) {
+			if _, err := c.edgenetclientset.CoreV1alpha1().RoleTemplates(roletemplateCopy.GetNamespace()).UpdateStatus(context.TODO(), roletemplateCopy, metav1.UpdateOptions{}); err != nil {
+				klog.Infoln(err)
+			}
+		}
+	}()
+	roletemplateCopy.Status.ObservedGeneration = roletemplateCopy.GetGeneration()
+
+	// A template that exceeds the tenant owner role must not be usable, so the role it has materialized is removed
+	if err := access.CheckRoleTemplateRules(roletemplateCopy.Spec.Rules); err != nil {
+		c.recorder.Event(roletemplateCopy, corev1.EventTypeWarning, failureRules, err.Error())
+		roletemplateCopy.Status.State = failure
+		roletemplateCopy.Status.Message = messageRulesExceeded
+		util.SetCondition(&roletemplateCopy.Status.Conditions, roletemplateCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonRulesExceeded, err.Error())
+		if err := c.removeRole(roletemplateCopy); err != nil {
+			c.recorder.Event(roletemplateCopy, corev1.EventTypeWarning, failureRemoval, messageRemovalFailed)
+			klog.Infoln(err)
+		}
+		return
+	}
+
+	roleName := access.GetRoleTemplateRoleName(roletemplateCopy.GetName())
+	roleLabels := map[string]string{"edge-net.io/generated": "true", roleTemplateLabel: roletemplateCopy.GetName()}
+	role, err := c.kubeclientset.RbacV1().Roles(roletemplateCopy.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	if err == nil {
+		if !reflect.DeepEqual(role.Rules, roletemplateCopy.Spec.Rules) || !reflect.DeepEqual(role.GetLabels(), roleLabels) {
+			roleCopy := role.DeepCopy()
+			roleCopy.Rules = roletemplateCopy.Spec.Rules
+			roleCopy.SetLabels(roleLabels)
+			_, err = c.kubeclientset.RbacV1().Roles(roleCopy.GetNamespace()).Update(context.TODO(), roleCopy, metav1.UpdateOptions{})
+		}
+	} else if errors.IsNotFound(err) {
+		role = &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: roletemplateCopy.GetNamespace()}, Rules: roletemplateCopy.Spec.Rules}
+		role.SetLabels(roleLabels)
+		_, err = c.kubeclientset.RbacV1().Roles(role.GetNamespace()).Create(context.TODO(), role, metav1.CreateOptions{})
+	}
+	if err != nil {
+		c.recorder.Event(roletemplateCopy, corev1.EventTypeWarning, failureCreation, messageCreationFailed)
+		roletemplateCopy.Status.State = failure
+		roletemplateCopy.Status.Message = messageCreationFailed
+		util.SetCondition(&roletemplateCopy.Status.Conditions, roletemplateCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonCreationFailed, err.Error())
+		klog.Infoln(err)
+		return
+	}
+
+	if roletemplateCopy.Status.State != established {
+		c.recorder.Event(roletemplateCopy, corev1.EventTypeNormal, successEstablished, messageEstablished)
+	}
+	roletemplateCopy.Status.State = established
+	roletemplateCopy.Status.Message = messageEstablished
+	util.SetCondition(&roletemplateCopy.Status.Conditions, roletemplateCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionTrue, reasonEstablished, messageEstablished)
+}
+
+// finalizeRoleTemplate removes the role materializing the template before letting the template go.
+// Role bindings that refer to the role remain in place, but they no longer grant anything.
+func (c *Controller) finalizeRoleTemplate(roletemplateCopy *corev1alpha1.RoleTemplate) error {
+	if !hasFinalizer(roletemplateCopy) {
+		return nil
+	}
+	if err := c.removeRole(roletemplateCopy); err != nil {
+		c.recorder.Event(roletemplateCopy, corev1.EventTypeWarning, failureRemoval, messageRemovalFailed)
+		return err
+	}
+	finalizers := []string{}
+	for _, finalizer := range roletemplateCopy.GetFinalizers() {
+		if finalizer != roleFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	roletemplateCopy.SetFinalizers(finalizers)
+	_, err := c.edgenetclientset.CoreV1alpha1().RoleTemplates(roletemplateCopy.GetNamespace()).Update(context.TODO(), roletemplateCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// removeRole deletes the role materializing the template if there is any
+func (c *Controller) removeRole(roletemplateCopy *corev1alpha1.RoleTemplate) error {
+	err := c.kubeclientset.RbacV1().Roles(roletemplateCopy.GetNamespace()).Delete(context.TODO(), access.GetRoleTemplateRoleName(roletemplateCopy.GetName()), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// hasFinalizer checks whether the role template holds the finalizer of the controller
+func hasFinalizer(roletemplate *corev1alpha1.RoleTemplate) bool {
+	for _, finalizer := range roletemplate.GetFinalizers() {
+		if finalizer == roleFinalizer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package roletemplate
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+	"github.com/EdgeNet-project/edgenet/pkg/signals"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog"
+)
+
+// The main structure of test group
+type TestGroup struct {
+	roleTemplateObj corev1alpha1.RoleTemplate
+}
+
+var kubeclientset kubernetes.Interface = testclient.NewSimpleClientset()
+var edgenetclientset versioned.Interface = edgenettestclient.NewSimpleClientset()
+
+func TestMain(m *testing.M) {
+	klog.SetOutput(ioutil.Discard)
+	log.SetOutput(ioutil.Discard)
+	logrus.SetOutput(ioutil.Discard)
+
+	flag.String("dir", "../../../../..", "Override the directory.")
+	flag.String("smtp-path", "../../../../../configs/smtp_test.yaml", "Set SMTP path.")
+	flag.Parse()
+
+	stopCh := signals.SetupSignalHandler()
+
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeclientset, time.Second*30)
+	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, time.Second*30)
+
+	controller := NewController(kubeclientset,
+		edgenetclientset,
+		kubeInformerFactory.Rbac().V1().Roles(),
+		edgenetInformerFactory.Core().V1alpha1().RoleTemplates())
+
+	kubeInformerFactory.Start(stopCh)
+	edgenetInformerFactory.Start(stopCh)
+
+	go func() {
+		if err := controller.Run(2, stopCh); err != nil {
+			klog.Fatalf("Error running controller: %s", err.Error())
+		}
+	}()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "edgenet"}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{})
+
+	time.Sleep(500 * time.Millisecond)
+
+	os.Exit(m.Run())
+	<-stopCh
+}
+
+func (g *TestGroup) Init() {
+	roleTemplateObj := corev1alpha1.RoleTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleTemplate",
+			APIVersion: "core.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-reader",
+			Namespace: "edgenet",
+		},
+		Spec: corev1alpha1.RoleTemplateSpec{
+			Description: "Read-only access to pods and their logs",
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+	}
+	g.roleTemplateObj = roleTemplateObj
+}
+
+func TestStartController(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	roleTemplate := g.roleTemplateObj.DeepCopy()
+	roleTemplate.SetName(util.GenerateRandomString(6))
+	edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Create(context.TODO(), roleTemplate, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	roleTemplate, err := edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, established, roleTemplate.Status.State)
+	util.Equals(t, true, hasFinalizer(roleTemplate))
+	role, err := kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), access.GetRoleTemplateRoleName(roleTemplate.GetName()), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, roleTemplate.Spec.Rules, role.Rules)
+	util.Equals(t, roleTemplate.GetName(), role.GetLabels()[roleTemplateLabel])
+
+	t.Run("restore role", func(t *testing.T) {
+		err := kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Delete(context.TODO(), role.GetName(), metav1.DeleteOptions{})
+		util.OK(t, err)
+		time.Sleep(250 * time.Millisecond)
+		_, err = kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), role.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+	})
+	t.Run("update rules", func(t *testing.T) {
+		roleTemplateCopy, err := edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		roleTemplateCopy.Spec.Rules = append(roleTemplateCopy.Spec.Rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: []string{"get"}})
+		edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplateCopy.GetNamespace()).Update(context.TODO(), roleTemplateCopy, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		role, err := kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), access.GetRoleTemplateRoleName(roleTemplate.GetName()), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, roleTemplateCopy.Spec.Rules, role.Rules)
+	})
+	t.Run("exceed tenant owner role", func(t *testing.T) {
+		roleTemplateCopy, err := edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		roleTemplateCopy.Spec.Rules = append(roleTemplateCopy.Spec.Rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"delete"}})
+		edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplateCopy.GetNamespace()).Update(context.TODO(), roleTemplateCopy, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		roleTemplateCopy, err = edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, roleTemplateCopy.Status.State)
+		_, err = kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), access.GetRoleTemplateRoleName(roleTemplate.GetName()), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+}
+
+func TestFinalizeRoleTemplate(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	roleTemplate := g.roleTemplateObj.DeepCopy()
+	roleTemplate.SetName(util.GenerateRandomString(6))
+	edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Create(context.TODO(), roleTemplate, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	roleName := access.GetRoleTemplateRoleName(roleTemplate.GetName())
+	_, err := kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.OK(t, err)
+
+	// The fake clientset does not handle finalizers, so the deletion is imitated by setting the deletion timestamp
+	roleTemplate, err = edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	deletionTimestamp := metav1.Now()
+	roleTemplate.SetDeletionTimestamp(&deletionTimestamp)
+	edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Update(context.TODO(), roleTemplate, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	_, err = kubeclientset.RbacV1().Roles(roleTemplate.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	roleTemplate, err = edgenetclientset.CoreV1alpha1().RoleTemplates(roleTemplate.GetNamespace()).Get(context.TODO(), roleTemplate.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, false, hasFinalizer(roleTemplate))
+}
@@ -31,6 +31,7 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -69,6 +70,15 @@ const (
 	applied               = "Applied"
 )
 
+// Reasons of the status conditions of the slice resource
+const (
+	reasonReserved    = "Reserved"
+	reasonSliceFailed = "InsufficientNodes"
+	reasonPatchFailed = "PatchFailed"
+	reasonBound       = "Bound"
+	reasonProvisioned = "Provisioned"
+)
+
 // Controller is the controller implementation for Slice resources
 type Controller struct {
 	// kubeclientset is a standard kubernetes clientset
@@ -333,15 +343,16 @@ func (c *Controller) processSlice(sliceCopy *corev1alpha1.Slice) {
 		c.edgenetclientset.CoreV1alpha1().Slices().Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
 		return
 	}
-	oldStatus := sliceCopy.Status
+	oldStatus := sliceCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, sliceCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, sliceCopy.Status) {
 			if _, err := c.edgenetclientset.CoreV1alpha1().Slices().UpdateStatus(context.TODO(), sliceCopy, metav1.UpdateOptions{}); err != nil {
 				klog.Infoln(err)
 			}
 		}
 	}
 	defer statusUpdate()
+	sliceCopy.Status.ObservedGeneration = sliceCopy.GetGeneration()
 
 	isReserved := c.reserveNodes(sliceCopy)
 
@@ -355,6 +366,7 @@ func (c *Controller) processSlice(sliceCopy *corev1alpha1.Slice) {
 				sliceClaimCopy := sliceClaim.DeepCopy()
 				sliceClaimCopy.Status.State = failure
 				sliceClaimCopy.Status.Message = messageSliceFailed
+				util.SetCondition(&sliceClaimCopy.Status.Conditions, sliceClaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonSliceFailed, messageSliceFailed)
 				_, err := c.edgenetclientset.CoreV1alpha1().SliceClaims(sliceClaimCopy.GetNamespace()).UpdateStatus(context.TODO(), sliceClaimCopy, metav1.UpdateOptions{})
 				klog.Infoln(err)
 			}
@@ -394,12 +406,14 @@ func (c *Controller) syncWithSliceClaim(sliceCopy *corev1alpha1.Slice, sliceClai
 						c.recorder.Event(sliceCopy, corev1.EventTypeNormal, successBound, messageBound)
 						sliceCopy.Status.State = bound
 						sliceCopy.Status.Message = messageBound
+						util.SetCondition(&sliceCopy.Status.Conditions, sliceCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionTrue, reasonBound, messageBound)
 					}
 
 					if sliceClaim.Status.State != bound {
 						sliceClaimCopy := sliceClaim.DeepCopy()
 						sliceClaimCopy.Status.State = bound
 						sliceClaimCopy.Status.Message = messageBound
+						util.SetCondition(&sliceClaimCopy.Status.Conditions, sliceClaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionTrue, reasonBound, messageBound)
 						_, err := c.edgenetclientset.CoreV1alpha1().SliceClaims(sliceClaimCopy.GetNamespace()).UpdateStatus(context.TODO(), sliceClaimCopy, metav1.UpdateOptions{})
 						klog.Infoln(err)
 					}
@@ -423,6 +437,7 @@ func (c *Controller) provisionSlice(sliceCopy *corev1alpha1.Slice) {
 		// c.recorder.Event(sliceCopy, corev1.EventTypeNormal, successBound, messagePr)
 		sliceCopy.Status.State = provisioned
 		// sliceCopy.Status.Message = messageReserved
+		util.SetCondition(&sliceCopy.Status.Conditions, sliceCopy.GetGeneration(), corev1alpha1.ConditionNodesReserved, metav1.ConditionTrue, reasonProvisioned, "Reserved nodes are provisioned for the slice")
 	}
 }
 
@@ -498,6 +513,7 @@ func (c *Controller) reserveNodes(sliceCopy *corev1alpha1.Slice) bool {
 				c.recorder.Event(sliceCopy, corev1.EventTypeWarning, failureSlice, messageSliceFailed)
 				sliceCopy.Status.State = failure
 				sliceCopy.Status.Message = messageSliceFailed
+				util.SetCondition(&sliceCopy.Status.Conditions, sliceCopy.GetGeneration(), corev1alpha1.ConditionNodesReserved, metav1.ConditionFalse, reasonSliceFailed, messageSliceFailed)
 				return false
 			} else {
 				var pickedNodeList []string
@@ -514,6 +530,7 @@ func (c *Controller) reserveNodes(sliceCopy *corev1alpha1.Slice) bool {
 						c.recorder.Event(sliceCopy, corev1.EventTypeWarning, failurePatch, messagePatchFailed)
 						sliceCopy.Status.State = failure
 						sliceCopy.Status.Message = messagePatchFailed
+						util.SetCondition(&sliceCopy.Status.Conditions, sliceCopy.GetGeneration(), corev1alpha1.ConditionNodesReserved, metav1.ConditionFalse, reasonPatchFailed, err.Error())
 						isPatched = false
 						break
 					}
@@ -531,6 +548,7 @@ func (c *Controller) reserveNodes(sliceCopy *corev1alpha1.Slice) bool {
 		c.recorder.Event(sliceCopy, corev1.EventTypeNormal, successReserved, messageReserved)
 		sliceCopy.Status.State = reserved
 		sliceCopy.Status.Message = messageReserved
+		util.SetCondition(&sliceCopy.Status.Conditions, sliceCopy.GetGeneration(), corev1alpha1.ConditionNodesReserved, metav1.ConditionTrue, reasonReserved, messageReserved)
 	}
 	return true
 }
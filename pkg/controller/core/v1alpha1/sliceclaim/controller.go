@@ -29,6 +29,7 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -79,6 +80,17 @@ const (
 	established = "Established"
 )
 
+// Reasons of the status conditions of the sliceclaim resource
+const (
+	reasonQuotaShortage  = "QuotaShortage"
+	reasonBindingFailed  = "BindingFailed"
+	reasonBoundAlready   = "BoundAlready"
+	reasonCreationFailed = "CreationFailed"
+	reasonPending        = "Pending"
+	reasonBound          = "Bound"
+	reasonApplied        = "Applied"
+)
+
 // Controller is the controller implementation for Slice Claimresources
 type Controller struct {
 	// kubeclientset is a standard kubernetes clientset
@@ -309,9 +321,9 @@ func (c *Controller) handleSubNamespace(obj interface{}) {
 
 func (c *Controller) processSliceClaim(sliceclaimCopy *corev1alpha1.SliceClaim) {
 	updated := make(chan bool, 1)
-	oldStatus := sliceclaimCopy.Status
+	oldStatus := sliceclaimCopy.Status.DeepCopy()
 	updateStatus := func(updated chan<- bool) {
-		if !reflect.DeepEqual(oldStatus, sliceclaimCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, sliceclaimCopy.Status) {
 			if _, err := c.edgenetclientset.CoreV1alpha1().SliceClaims(sliceclaimCopy.GetNamespace()).UpdateStatus(context.TODO(), sliceclaimCopy, metav1.UpdateOptions{}); err != nil {
 				klog.Infoln(err)
 			}
@@ -320,6 +332,7 @@ func (c *Controller) processSliceClaim(sliceclaimCopy *corev1alpha1.SliceClaim)
 		close(updated)
 	}
 	defer updateStatus(updated)
+	sliceclaimCopy.Status.ObservedGeneration = sliceclaimCopy.GetGeneration()
 
 	namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), sliceclaimCopy.GetNamespace(), metav1.GetOptions{})
 	if err != nil {
@@ -337,6 +350,7 @@ func (c *Controller) processSliceClaim(sliceclaimCopy *corev1alpha1.SliceClaim)
 				c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureQuotaShortage, messageQuotaShortage)
 				sliceclaimCopy.Status.State = failure
 				sliceclaimCopy.Status.Message = messageQuotaShortage
+				util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionFalse, reasonQuotaShortage, messageQuotaShortage)
 				return
 			}
 			c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successQuotaCheck, messageQuotaCheck)
@@ -350,6 +364,7 @@ func (c *Controller) processSliceClaim(sliceclaimCopy *corev1alpha1.SliceClaim)
 			c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successBound, messageBound)
 			sliceclaimCopy.Status.State = bound
 			sliceclaimCopy.Status.Message = messageBound
+			util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionTrue, reasonBound, messageBound)
 		}
 	}
 
@@ -361,17 +376,20 @@ func (c *Controller) claimSlice(sliceclaimCopy *corev1alpha1.SliceClaim, updated
 			c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 			sliceclaimCopy.Status.State = failure
 			sliceclaimCopy.Status.Message = messageBindingFailed
+			util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonBindingFailed, messageBindingFailed)
 		} else {
 			if slice.Spec.ClaimRef != nil {
 				if reflect.DeepEqual(slice.Spec.ClaimRef, sliceclaimCopy.MakeObjectReference()) {
 					c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successBound, messageBound)
 					sliceclaimCopy.Status.State = bound
 					sliceclaimCopy.Status.Message = messageBound
+					util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionTrue, reasonBound, messageBound)
 					return true
 				}
 				c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureBound, messageBoundAlready)
 				sliceclaimCopy.Status.State = failure
 				sliceclaimCopy.Status.Message = messageBoundAlready
+				util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonBoundAlready, messageBoundAlready)
 			} else {
 				if slice.Status.State == reserved && slice.Spec.SliceClassName == sliceclaimCopy.Spec.SliceClassName && reflect.DeepEqual(slice.Spec.NodeSelector, sliceclaimCopy.Spec.NodeSelector) {
 					sliceCopy := slice.DeepCopy()
@@ -380,14 +398,17 @@ func (c *Controller) claimSlice(sliceclaimCopy *corev1alpha1.SliceClaim, updated
 						c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 						sliceclaimCopy.Status.State = failure
 						sliceclaimCopy.Status.Message = messageBindingFailed
+						util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonBindingFailed, messageBindingFailed)
 					}
 					c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successClaimed, messageClaimed)
 					sliceclaimCopy.Status.State = requested
 					sliceclaimCopy.Status.Message = messagePending
+					util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonPending, messagePending)
 				} else {
 					c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 					sliceclaimCopy.Status.State = failure
 					sliceclaimCopy.Status.Message = messageBindingFailed
+					util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonBindingFailed, messageBindingFailed)
 				}
 			}
 		}
@@ -405,17 +426,20 @@ func (c *Controller) claimSlice(sliceclaimCopy *corev1alpha1.SliceClaim, updated
 				c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, failureCreation, messageCreationFailed)
 				sliceclaimCopy.Status.State = failure
 				sliceclaimCopy.Status.Message = messageCreationFailed
+				util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonCreationFailed, messageCreationFailed)
 			} else if errors.IsAlreadyExists(err) {
 				return tieClaim2Slice(slice)
 			} else {
 				c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successClaimed, messageClaimed)
 				sliceclaimCopy.Status.State = requested
 				sliceclaimCopy.Status.Message = messagePending
+				util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonPending, messagePending)
 			}
 		} else {
 			c.recorder.Event(sliceclaimCopy, corev1.EventTypeWarning, pendingSlice, messagePending)
 			sliceclaimCopy.Status.State = pending
 			sliceclaimCopy.Status.Message = messagePending
+			util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonPending, messagePending)
 		}
 	} else {
 		return tieClaim2Slice(slice)
@@ -434,6 +458,7 @@ func (c *Controller) tieSubnamespace2Claim(sliceclaimCopy *corev1alpha1.SliceCla
 							c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successApplied, messageApplied)
 							sliceclaimCopy.Status.State = applied
 							sliceclaimCopy.Status.Message = messageApplied
+							util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonApplied, messageApplied)
 							return true
 						}
 					}
@@ -460,6 +485,7 @@ func (c *Controller) tieSubnamespace2Claim(sliceclaimCopy *corev1alpha1.SliceCla
 						c.recorder.Event(sliceclaimCopy, corev1.EventTypeNormal, successApplied, messageApplied)
 						sliceclaimCopy.Status.State = applied
 						sliceclaimCopy.Status.Message = messageApplied
+						util.SetCondition(&sliceclaimCopy.Status.Conditions, sliceclaimCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonApplied, messageApplied)
 						return true
 					}
 				}
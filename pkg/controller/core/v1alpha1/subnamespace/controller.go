@@ -26,25 +26,32 @@ import (
 	"github.com/EdgeNet-project/edgenet/pkg/access"
 	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
 	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/federation"
 	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
 	namespacev1 "github.com/EdgeNet-project/edgenet/pkg/namespace"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	"github.com/google/uuid"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	networkinginformers "k8s.io/client-go/informers/networking/v1"
 	rbacinformers "k8s.io/client-go/informers/rbac/v1"
@@ -61,6 +68,24 @@ import (
 
 const controllerAgentName = "subnamespace-controller"
 
+// federationFinalizer keeps a federated workspace until its mirrors are removed from the member clusters
+const federationFinalizer = "core.edgenet.io/federation"
+
+// moveProgress is the annotation that records the quota steps a move has taken, in the form of destination/step,
+// so that retrying a move that fails halfway does not take or return the quota twice
+const moveProgress = "edge-net.io/move-progress"
+
+// The quota steps of a move
+const (
+	quotaTaken    = "quota-taken"
+	quotaReturned = "quota-returned"
+)
+
+// inheritanceAllowList is the config map in the edgenet namespace that lets workspaces inherit additional kinds.
+// Each key is what a workspace sets in its inheritance, and each value is the resource in the form of group/version/resource,
+// or version/resource for the core group. The service account of the controller must be granted access to each resource.
+const inheritanceAllowList = "inheritance-allowlist"
+
 // Definitions of the state of the subnamespace resource
 const (
 	successSynced          = "Synced"
@@ -89,11 +114,49 @@ const (
 	messageCollision       = "Name is not available. Please choose another one."
 	failureSlice           = "Slice Unready"
 	messageSlice           = "Slice is not ready to be used."
+	failureTenantDefaults  = "Not Applied"
+	messageTenantDefaults  = "Default container resources, pod security level, and namespace metadata of the tenant cannot be applied"
+	successFederated       = "Federated"
+	messageFederated       = "Federated workspace mirrored into the member clusters successfully"
+	failureFederation      = "Not Federated"
+	messageFederationFail  = "Federated workspace cannot be mirrored into all member clusters"
+	failureMirrorRemoval   = "Mirror Left"
+	messageMirrorRemoval   = "Mirrors of the federated workspace are left in the member clusters of disabled or unreachable cluster peers"
+	successMoved           = "Moved"
+	messageMoved           = "Workspace moved to the new parent successfully"
+	failureMove            = "Not Moved"
+	messageMoveFail        = "Workspace cannot be moved to the new parent"
+	failureConflict        = "Conflict"
+	messageConflict        = "Objects in the workspace collide with the objects inherited from the parent namespace"
+	failureOverwritten     = "Overwritten"
+	messageOverwritten     = "Local objects guarding the workspace are overwritten by the objects inherited from the parent namespace"
 	failure                = "Failure"
 	established            = "Established"
 	bound                  = "Bound"
 	applied                = "Applied"
 	provisioned            = "Provisioned"
+	mirrored               = "Mirrored"
+)
+
+// Reasons of the status conditions of the subnamespace resource
+const (
+	reasonFormed             = "Formed"
+	reasonNameCollision      = "NameCollision"
+	reasonCreationFailed     = "CreationFailed"
+	reasonNamespaceCreated   = "NamespaceCreated"
+	reasonSliceUnready       = "SliceUnready"
+	reasonSliceBound         = "SliceBound"
+	reasonQuotaShortage      = "QuotaShortage"
+	reasonQuotaNotApplied    = "QuotaNotApplied"
+	reasonQuotaApplied       = "QuotaApplied"
+	reasonInheritanceFailed  = "InheritanceFailed"
+	reasonInheritanceSynced  = "InheritanceSynced"
+	reasonNoResourceRequired = "NoResourceRequired"
+	reasonFederated          = "Federated"
+	reasonFederationFailed   = "FederationFailed"
+	reasonMoveFailed         = "MoveFailed"
+	reasonConflictDetected   = "ConflictDetected"
+	reasonNoConflict         = "NoConflict"
 )
 
 // Controller is the controller implementation for Subsidiary Namespace resources
@@ -102,9 +165,13 @@ type Controller struct {
 	kubeclientset kubernetes.Interface
 	// edgenetclientset is a clientset for the EdgeNet API groups
 	edgenetclientset clientset.Interface
+	// dynamicclientset is a clientset for the kinds in the inheritance allow-list
+	dynamicclientset dynamic.Interface
 
 	subnamespacesLister listers.SubNamespaceLister
 	subnamespacesSynced cache.InformerSynced
+	namespacesLister    corelisters.NamespaceLister
+	namespacesSynced    cache.InformerSynced
 
 	rolesLister           rbaclisters.RoleLister
 	rolesSynced           cache.InformerSynced
@@ -136,6 +203,7 @@ type Controller struct {
 func NewController(
 	kubeclientset kubernetes.Interface,
 	edgenetclientset clientset.Interface,
+	dynamicclientset dynamic.Interface,
 	roleInformer rbacinformers.RoleInformer,
 	rolebindingInformer rbacinformers.RoleBindingInformer,
 	networkpolicyInformer networkinginformers.NetworkPolicyInformer,
@@ -143,6 +211,7 @@ func NewController(
 	secretInformer coreinformers.SecretInformer,
 	configmapInformer coreinformers.ConfigMapInformer,
 	serviceaccountInformer coreinformers.ServiceAccountInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
 	subnamespaceInformer informers.SubNamespaceInformer) *Controller {
 
 	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
@@ -155,6 +224,7 @@ func NewController(
 	controller := &Controller{
 		kubeclientset:         kubeclientset,
 		edgenetclientset:      edgenetclientset,
+		dynamicclientset:      dynamicclientset,
 		rolesLister:           roleInformer.Lister(),
 		rolesSynced:           roleInformer.Informer().HasSynced,
 		rolebindingsLister:    rolebindingInformer.Lister(),
@@ -171,6 +241,8 @@ func NewController(
 		serviceaccountsSynced: serviceaccountInformer.Informer().HasSynced,
 		subnamespacesLister:   subnamespaceInformer.Lister(),
 		subnamespacesSynced:   subnamespaceInformer.Informer().HasSynced,
+		namespacesLister:      namespaceInformer.Lister(),
+		namespacesSynced:      namespaceInformer.Informer().HasSynced,
 		workqueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SubNamespaces"),
 		recorder:              recorder,
 	}
@@ -230,12 +302,11 @@ func NewController(
 		},
 	})
 
+	// Periodic resyncs deliver the objects unchanged, so there is nothing to push down to the workspaces
 	roleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*rbacv1.Role)
-			oldObj := old.(*rbacv1.Role)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -245,9 +316,7 @@ func NewController(
 	rolebindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*rbacv1.RoleBinding)
-			oldObj := old.(*rbacv1.RoleBinding)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -257,9 +326,7 @@ func NewController(
 	networkpolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*networkingv1.NetworkPolicy)
-			oldObj := old.(*networkingv1.NetworkPolicy)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -269,9 +336,7 @@ func NewController(
 	limitrangeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*corev1.LimitRange)
-			oldObj := old.(*corev1.LimitRange)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -281,9 +346,7 @@ func NewController(
 	secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*corev1.Secret)
-			oldObj := old.(*corev1.Secret)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -293,9 +356,7 @@ func NewController(
 	configmapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*corev1.ConfigMap)
-			oldObj := old.(*corev1.ConfigMap)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -305,9 +366,7 @@ func NewController(
 	serviceaccountInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: controller.handleObject,
 		UpdateFunc: func(old, new interface{}) {
-			newObj := new.(*corev1.ServiceAccount)
-			oldObj := old.(*corev1.ServiceAccount)
-			if newObj.ResourceVersion == oldObj.ResourceVersion {
+			if reflect.DeepEqual(old, new) {
 				return
 			}
 			controller.handleObject(new)
@@ -333,10 +392,15 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 
 	klog.Infoln("Waiting for informer caches to sync")
 	if ok := cache.WaitForCacheSync(stopCh,
-		c.subnamespacesSynced); !ok {
+		c.subnamespacesSynced,
+		c.namespacesSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
+	if err := c.watchAllowedKinds(stopCh); err != nil {
+		klog.Infoln(err)
+	}
+
 	klog.Infoln("Starting workers")
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -413,6 +477,19 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
+	if subnamespace.IsFederated() {
+		if subnamespace.GetDeletionTimestamp() != nil {
+			return c.finalizeFederatedWorkspace(subnamespace.DeepCopy())
+		}
+		if !hasFederationFinalizer(subnamespace) {
+			// The workspace is processed once the update event of the finalizer arrives
+			subnamespaceCopy := subnamespace.DeepCopy()
+			subnamespaceCopy.SetFinalizers(append(subnamespaceCopy.GetFinalizers(), federationFinalizer))
+			_, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).Update(context.TODO(), subnamespaceCopy, metav1.UpdateOptions{})
+			return err
+		}
+	}
+
 	c.processSubNamespace(subnamespace.DeepCopy())
 	c.recorder.Event(subnamespace, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
@@ -444,12 +521,36 @@ func (c *Controller) enqueueSubNamespaceAfter(obj interface{}, after time.Durati
 	c.workqueue.AddAfter(key, after)
 }
 
-// handleObject will take any resource implementing metav1.Object and attempt
-// to find the SubNamespace resource that 'owns' its namespace. It does this by
-// looking at the objects metadata.ownerReferences field for an appropriate OwnerReference.
-// It then enqueues that SubNamespace resource to be processed. If the object does not
-// have an appropriate OwnerReference, it will simply be skipped.
+// handleObject will take any resource implementing metav1.Object and find the kind it belongs to in
+// the inheritance of workspaces. It then hands the object over to handleInheritedObject.
 func (c *Controller) handleObject(obj interface{}) {
+	object := obj
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		object = tombstone.Obj
+	}
+	var key string
+	switch object.(type) {
+	case *rbacv1.Role, *rbacv1.RoleBinding:
+		key = "rbac"
+	case *networkingv1.NetworkPolicy:
+		key = "networkpolicy"
+	case *corev1.LimitRange:
+		key = "limitrange"
+	case *corev1.Secret:
+		key = "secret"
+	case *corev1.ConfigMap:
+		key = "configmap"
+	case *corev1.ServiceAccount:
+		key = "serviceaccount"
+	}
+	c.handleInheritedObject(obj, key)
+}
+
+// handleInheritedObject enqueues the workspaces that keep the kind of the object in sync with its namespace,
+// all the way down the tree. Objects outside the tenant namespaces are ignored. When the object is generated by inheritance,
+// it also attempts to find the SubNamespace resource that 'owns' its namespace by looking at the namespace's metadata.ownerReferences
+// field, so that local changes to the object are reverted. If the namespace does not have an appropriate OwnerReference, it will simply be skipped.
+func (c *Controller) handleInheritedObject(obj interface{}, key string) {
 	var object metav1.Object
 	var ok bool
 	if object, ok = obj.(metav1.Object); !ok {
@@ -465,35 +566,30 @@ func (c *Controller) handleObject(obj interface{}) {
 		}
 		klog.Infof("Recovered deleted object '%s' from tombstone", object.GetName())
 	}
-	klog.Infof("Processing object: %s", object.GetName())
-
-	namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), object.GetNamespace(), metav1.GetOptions{})
+	namespace, err := c.namespacesLister.Get(object.GetNamespace())
 	if err != nil {
 		return
 	}
+	klog.Infof("Processing object: %s", object.GetName())
 
-	subnamespaceRaw, err := c.subnamespacesLister.SubNamespaces(object.GetNamespace()).List(labels.Everything())
-	if err == nil {
-		for _, subnamespaceRow := range subnamespaceRaw {
-			if subnamespaceRow.Spec.Workspace != nil && subnamespaceRow.Spec.Workspace.Sync {
-				c.enqueueSubNamespaceAfter(subnamespaceRow, 30*time.Second)
-			}
-		}
-	}
+	c.enqueueSyncedWorkspaces(namespace, key)
 
 	objectLabels := object.GetLabels()
-	if ownerRef := metav1.GetControllerOf(namespace); ownerRef != nil && objectLabels["edge-net.io/generated"] == "true" {
+	if objectLabels["edge-net.io/generated"] != "true" {
+		return
+	}
+	if ownerRef := metav1.GetControllerOf(namespace); ownerRef != nil {
 		if ownerRef.Kind != "Namespace" {
 			return
 		}
 
-		parentnamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), ownerRef.Name, metav1.GetOptions{})
+		parentnamespace, err := c.namespacesLister.Get(ownerRef.Name)
 		if err != nil {
 			return
 		}
 		parentnamespaceLabels := parentnamespace.GetLabels()
 
-		subnamespaceRaw, err = c.subnamespacesLister.SubNamespaces(ownerRef.Name).List(labels.Everything())
+		subnamespaceRaw, err := c.subnamespacesLister.SubNamespaces(ownerRef.Name).List(labels.Everything())
 		if err != nil {
 			klog.Infof("ignoring orphaned object '%s' of subnamespace '%s'", object.GetSelfLink(), ownerRef.Name)
 		} else {
@@ -510,21 +606,120 @@ func (c *Controller) handleObject(obj interface{}) {
 	}
 }
 
+// enqueueSyncedWorkspaces enqueues the workspaces in the namespace that are in sync with it and inherit the kind,
+// then does the same for their child namespaces, as the changes flow down the tree
+func (c *Controller) enqueueSyncedWorkspaces(namespace *corev1.Namespace, key string) {
+	namespaces := []*corev1.Namespace{namespace}
+	for len(namespaces) > 0 {
+		parentNamespace := namespaces[0]
+		namespaces = namespaces[1:]
+		subnamespaceRaw, err := c.subnamespacesLister.SubNamespaces(parentNamespace.GetName()).List(labels.Everything())
+		if err != nil {
+			continue
+		}
+		for _, subnamespaceRow := range subnamespaceRaw {
+			if subnamespaceRow.Spec.Workspace == nil || !subnamespaceRow.Spec.Workspace.Sync || !subnamespaceRow.Spec.Workspace.Inheritance[key] {
+				continue
+			}
+			c.enqueueSubNamespace(subnamespaceRow)
+			if subnamespaceRow.Status.State != established {
+				continue
+			}
+			childNamespace, err := c.namespacesLister.Get(subnamespaceRow.GenerateChildName(parentNamespace.GetLabels()["edge-net.io/cluster-uid"]))
+			if err != nil {
+				continue
+			}
+			namespaces = append(namespaces, childNamespace)
+		}
+	}
+}
+
+// watchAllowedKinds sets up event handlers for the kinds in the inheritance allow-list and waits for their caches to sync.
+// The kinds that the API server does not serve, or that the controller cannot list and watch, are skipped. The allow-list is read once,
+// so the controller needs a restart to watch the kinds added to it later on. Workspaces still pick them up when reconciled.
+func (c *Controller) watchAllowedKinds(stopCh <-chan struct{}) error {
+	allowList, err := c.getInheritanceAllowList()
+	if err != nil {
+		return err
+	}
+	// The objects outside the tenant namespaces are left out as they arrive, since the objects themselves rarely carry tenant labels
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(c.dynamicclientset, time.Second*30)
+	for key, resource := range allowList {
+		if err := c.checkWatchable(resource); err != nil {
+			klog.Infoln(err)
+			continue
+		}
+		key := key
+		dynamicInformerFactory.ForResource(resource).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.handleInheritedObject(obj, key)
+			},
+			UpdateFunc: func(old, new interface{}) {
+				if reflect.DeepEqual(old, new) {
+					return
+				}
+				c.handleInheritedObject(new, key)
+			},
+			DeleteFunc: func(obj interface{}) {
+				c.handleInheritedObject(obj, key)
+			},
+		})
+	}
+	dynamicInformerFactory.Start(stopCh)
+	for resource, synced := range dynamicInformerFactory.WaitForCacheSync(stopCh) {
+		if !synced {
+			return fmt.Errorf("failed to wait for the cache of %s to sync", resource.String())
+		}
+	}
+	return nil
+}
+
+// checkWatchable returns an error when the API server does not serve the resource or the controller is not allowed to list and watch it
+func (c *Controller) checkWatchable(resource schema.GroupVersionResource) error {
+	resourceList, err := c.kubeclientset.Discovery().ServerResourcesForGroupVersion(resource.GroupVersion().String())
+	if err != nil {
+		return fmt.Errorf("%s cannot be discovered: %s", resource.String(), err)
+	}
+	served := false
+	for _, apiResource := range resourceList.APIResources {
+		if apiResource.Name == resource.Resource {
+			served = true
+			break
+		}
+	}
+	if !served {
+		return fmt.Errorf("%s is not served by the API server", resource.String())
+	}
+	for _, verb := range []string{"list", "watch"} {
+		review := new(authorizationv1.SelfSubjectAccessReview)
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Verb: verb, Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+		reviewResult, err := c.kubeclientset.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		if !reviewResult.Status.Allowed || reviewResult.Status.Denied {
+			return fmt.Errorf("the controller is not allowed to %s %s", verb, resource.String())
+		}
+	}
+	return nil
+}
+
 func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNamespace) {
 	if subnamespaceCopy.Spec.Expiry != nil && time.Until(subnamespaceCopy.Spec.Expiry.Time) <= 0 {
 		c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, successExpired, messageExpired)
 		c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).Delete(context.TODO(), subnamespaceCopy.GetName(), metav1.DeleteOptions{})
 		return
 	}
-	oldStatus := subnamespaceCopy.Status
+	oldStatus := subnamespaceCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, subnamespaceCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, subnamespaceCopy.Status) {
 			if _, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).UpdateStatus(context.TODO(), subnamespaceCopy, metav1.UpdateOptions{}); err != nil {
 				klog.Infoln(err)
 			}
 		}
 	}
 	defer statusUpdate()
+	subnamespaceCopy.Status.ObservedGeneration = subnamespaceCopy.GetGeneration()
 
 	// Below code checks whether namespace, where role request made, is local to the cluster or is propagated along with a federated deployment.
 	// If another cluster propagates the namespace, we skip checking the owner tenant's status as the Selective Deployment entity manages this life-cycle.
@@ -553,6 +748,20 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 		}
 	}
 	if permitted {
+		if destination, elementExists := subnamespaceCopy.GetAnnotations()[corev1alpha1.SubNamespaceMoveTo]; elementExists && destination != subnamespaceCopy.GetNamespace() {
+			childNameHashed := subnamespaceCopy.GenerateChildName(namespaceLabels["edge-net.io/cluster-uid"])
+			if err := c.moveSubNamespace(subnamespaceCopy, namespace, childNameHashed, destination); err != nil {
+				// The move is retried until it succeeds or the annotation is removed
+				c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureMove, messageMoveFail)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionMoved, metav1.ConditionFalse, reasonMoveFailed, err.Error())
+				c.enqueueSubNamespaceAfter(subnamespaceCopy, time.Minute)
+				klog.Infoln(err)
+			} else {
+				c.recorder.Event(subnamespaceCopy, corev1.EventTypeNormal, successMoved, messageMoved)
+				return
+			}
+		}
+
 		var labels = map[string]string{"edge-net.io/generated": "true", "edge-net.io/kind": "sub"}
 		var childResourceQuota map[corev1.ResourceName]resource.Quantity
 		annotations := namespace.GetAnnotations()
@@ -563,6 +772,8 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 			c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureCollision, messageCollision)
 			subnamespaceCopy.Status.State = failure
 			subnamespaceCopy.Status.Message = messageCollision
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionNamespaceReady, metav1.ConditionFalse, reasonNameCollision, messageCollision)
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonNameCollision, messageCollision)
 			return
 		}
 
@@ -571,8 +782,11 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 				c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureSlice, messageSlice)
 				subnamespaceCopy.Status.State = failure
 				subnamespaceCopy.Status.Message = failureSlice
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionFalse, reasonSliceUnready, messageSlice)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonSliceUnready, messageSlice)
 				return
 			}
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionSliceBound, metav1.ConditionTrue, reasonSliceBound, fmt.Sprintf("Slice claim %s is bound", *sliceclaim))
 			annotations = map[string]string{"scheduler.alpha.kubernetes.io/node-selector": fmt.Sprintf("edge-net.io/access=private,edge-net.io/slice=%s", *sliceclaim)}
 		}
 
@@ -592,6 +806,8 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 
 		if parentResourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(subnamespaceCopy.GetNamespace()).Get(context.TODO(), fmt.Sprintf("%s-quota", namespaceLabels["edge-net.io/kind"]), metav1.GetOptions{}); err == nil {
 			if sufficientQuota := c.tuneParentResourceQuota(subnamespaceCopy, parentResourceQuota, childResourceQuota); !sufficientQuota {
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionFalse, reasonQuotaShortage, subnamespaceCopy.Status.Message)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonQuotaShortage, subnamespaceCopy.Status.Message)
 				return
 			}
 		}
@@ -599,8 +815,15 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 		ownerReferences := namespacev1.SetAsOwnerReference(namespace)
 		childInitiated := c.constructSubsidiaryNamespace(subnamespaceCopy, childNameHashed, childExist, annotations, labels, ownerReferences)
 		if !childInitiated {
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionNamespaceReady, metav1.ConditionFalse, reasonCreationFailed, subnamespaceCopy.Status.Message)
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonCreationFailed, subnamespaceCopy.Status.Message)
 			return
 		}
+		util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionNamespaceReady, metav1.ConditionTrue, reasonNamespaceCreated, fmt.Sprintf("Subsidiary namespace %s is in place", childNameHashed))
+		if err := c.applyTenantDefaults(subnamespaceCopy, childNameHashed, namespaceLabels["edge-net.io/tenant"]); err != nil {
+			c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureTenantDefaults, messageTenantDefaults)
+			klog.Infoln(err)
+		}
 
 		if subnamespaceCopy.GetResourceAllocation() != nil || subnamespaceCopy.GetSliceClaim() != nil {
 			quotaApplied := c.applyChildResourceQuota(subnamespaceCopy, childNameHashed)
@@ -610,19 +833,39 @@ func (c *Controller) processSubNamespace(subnamespaceCopy *corev1alpha1.SubNames
 					c.returnParentResourceQuota(subnamespaceCopy, parentResourceQuota)
 				}
 				c.tareChildResourceQuota(subnamespaceCopy, childNameHashed)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionFalse, reasonQuotaNotApplied, messageApplyFail)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonQuotaNotApplied, messageApplyFail)
 				return
 			}
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonQuotaApplied, messageApplied)
+		} else {
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonNoResourceRequired, "No resource allocation is requested")
 		}
 
 		if subnamespaceCopy.Spec.Workspace != nil {
 			done := c.handleInheritance(subnamespaceCopy, childNameHashed)
 			if !done {
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionInheritanceSynced, metav1.ConditionFalse, reasonInheritanceFailed, messageInheritanceFail)
+				util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonInheritanceFailed, messageInheritanceFail)
 				return
 			}
+			util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionInheritanceSynced, metav1.ConditionTrue, reasonInheritanceSynced, "Objects inherited from the parent namespace are in sync")
+
+			if subnamespaceCopy.IsFederated() {
+				// The workspace is usable locally even when a member cluster is out of reach, so the mirrors are retried later on
+				if federated := c.federateWorkspace(subnamespaceCopy, childNameHashed, namespaceLabels["edge-net.io/cluster-uid"]); !federated {
+					c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureFederation, messageFederationFail)
+					util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionFederated, metav1.ConditionFalse, reasonFederationFailed, messageFederationFail)
+					c.enqueueSubNamespaceAfter(subnamespaceCopy, time.Minute)
+				} else {
+					util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionFederated, metav1.ConditionTrue, reasonFederated, messageFederated)
+				}
+			}
 		}
 
 		subnamespaceCopy.Status.State = established
 		subnamespaceCopy.Status.Message = messageFormed
+		util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionTrue, reasonFormed, messageFormed)
 		c.recorder.Event(subnamespaceCopy, corev1.EventTypeNormal, successFormed, messageFormed)
 	}
 }
@@ -672,6 +915,142 @@ func (c *Controller) validateChildOwnership(parentNamespace *corev1.Namespace, m
 	return false, false
 }
 
+// moveSubNamespace moves the workspace to the destination namespace within the same tenant. The quota of the workspace is
+// taken from the new parent and returned to the current one, the child namespace changes hands along with its workloads,
+// and the subnamespace is recreated in the new parent with the name of its child recorded. Each step can be resumed
+// if a later one fails.
+func (c *Controller) moveSubNamespace(subnamespaceCopy *corev1alpha1.SubNamespace, parentNamespace *corev1.Namespace, childName, destination string) error {
+	if subnamespaceCopy.GetMode() != "workspace" || subnamespaceCopy.GetSliceClaim() != nil {
+		return fmt.Errorf("only workspaces without a slice claim can be moved")
+	}
+	if destination == childName {
+		return fmt.Errorf("workspace cannot be moved under itself")
+	}
+	destinationNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), destination, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	parentLabels := parentNamespace.GetLabels()
+	destinationLabels := destinationNamespace.GetLabels()
+	if destinationLabels["edge-net.io/tenant"] != parentLabels["edge-net.io/tenant"] || (destinationLabels["edge-net.io/kind"] != "core" && destinationLabels["edge-net.io/kind"] != "sub") {
+		return fmt.Errorf("namespace %s is not a part of tenant %s", destination, parentLabels["edge-net.io/tenant"])
+	}
+	// A workspace cannot be moved under any of its descendants either
+	for ancestor := destinationNamespace; ; {
+		ownerReference := metav1.GetControllerOf(ancestor)
+		if ownerReference == nil || ownerReference.Kind != "Namespace" {
+			break
+		}
+		if ownerReference.Name == childName {
+			return fmt.Errorf("workspace cannot be moved under its descendant %s", destination)
+		}
+		if ancestor, err = c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), ownerReference.Name, metav1.GetOptions{}); err != nil {
+			return err
+		}
+	}
+
+	recreated := false
+	if subnamespaceMoved, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(destination).Get(context.TODO(), subnamespaceCopy.GetName(), metav1.GetOptions{}); err == nil {
+		if subnamespaceMoved.GetAnnotations()[corev1alpha1.SubNamespaceChildName] != childName {
+			return fmt.Errorf("subnamespace %s already exists in %s", subnamespaceCopy.GetName(), destination)
+		}
+		recreated = true
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	childExists, childOwned := c.validateChildOwnership(parentNamespace, subnamespaceCopy.GetMode(), childName)
+	if !childExists {
+		return fmt.Errorf("namespace %s does not exist", childName)
+	}
+	if childOwned {
+		if subnamespaceCopy.GetResourceAllocation() != nil {
+			progress := subnamespaceCopy.GetAnnotations()[moveProgress]
+			returned := progress == fmt.Sprintf("%s/%s", destination, quotaReturned)
+			taken := returned || progress == fmt.Sprintf("%s/%s", destination, quotaTaken)
+			if !taken {
+				destinationResourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(destination).Get(context.TODO(), fmt.Sprintf("%s-quota", destinationLabels["edge-net.io/kind"]), metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				if sufficientQuota := c.tuneParentResourceQuota(subnamespaceCopy, destinationResourceQuota, nil); !sufficientQuota {
+					return fmt.Errorf("%s at %s", subnamespaceCopy.Status.Message, destination)
+				}
+				if err := c.recordMoveProgress(subnamespaceCopy, destination, quotaTaken); err != nil {
+					return err
+				}
+			}
+			if !returned {
+				if parentResourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(parentNamespace.GetName()).Get(context.TODO(), fmt.Sprintf("%s-quota", parentLabels["edge-net.io/kind"]), metav1.GetOptions{}); err == nil {
+					if quotaUpdated := c.returnParentResourceQuota(subnamespaceCopy, parentResourceQuota); !quotaUpdated {
+						return fmt.Errorf("%s at %s", subnamespaceCopy.Status.Message, parentNamespace.GetName())
+					}
+				}
+				if err := c.recordMoveProgress(subnamespaceCopy, destination, quotaReturned); err != nil {
+					return err
+				}
+			}
+		}
+
+		childNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		childNamespaceCopy := childNamespace.DeepCopy()
+		childNamespaceCopy.SetOwnerReferences(namespacev1.SetAsOwnerReference(destinationNamespace))
+		childLabels := childNamespaceCopy.GetLabels()
+		if childLabels == nil {
+			childLabels = make(map[string]string)
+		}
+		childLabels["edge-net.io/parent-namespace"] = destination
+		childNamespaceCopy.SetLabels(childLabels)
+		if _, err := c.kubeclientset.CoreV1().Namespaces().Update(context.TODO(), childNamespaceCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	} else if _, movedOwned := c.validateChildOwnership(destinationNamespace, subnamespaceCopy.GetMode(), childName); !movedOwned {
+		return fmt.Errorf("namespace %s is not a child of %s", childName, parentNamespace.GetName())
+	}
+
+	if !recreated {
+		annotations := make(map[string]string)
+		for key, value := range subnamespaceCopy.GetAnnotations() {
+			if key != corev1alpha1.SubNamespaceMoveTo && key != moveProgress {
+				annotations[key] = value
+			}
+		}
+		annotations[corev1alpha1.SubNamespaceChildName] = childName
+		subnamespaceMoved := &corev1alpha1.SubNamespace{ObjectMeta: metav1.ObjectMeta{Name: subnamespaceCopy.GetName(), Namespace: destination,
+			Labels: subnamespaceCopy.GetLabels(), Annotations: annotations}, Spec: *subnamespaceCopy.Spec.DeepCopy()}
+		if _, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(destination).Create(context.TODO(), subnamespaceMoved, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	// The child is no longer owned by the current parent, so deleting the subnamespace here leaves the child in place
+	err = c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).Delete(context.TODO(), subnamespaceCopy.GetName(), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// recordMoveProgress records the quota step that the move of the subnamespace to the destination has taken
+func (c *Controller) recordMoveProgress(subnamespaceCopy *corev1alpha1.SubNamespace, destination, step string) error {
+	subnamespaceRecorded := subnamespaceCopy.DeepCopy()
+	annotations := subnamespaceRecorded.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[moveProgress] = fmt.Sprintf("%s/%s", destination, step)
+	subnamespaceRecorded.SetAnnotations(annotations)
+	subnamespaceUpdated, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceRecorded.GetNamespace()).Update(context.TODO(), subnamespaceRecorded, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	// The status is still being worked on, so only the metadata is taken over
+	subnamespaceUpdated.ObjectMeta.DeepCopyInto(&subnamespaceCopy.ObjectMeta)
+	return nil
+}
+
 func (c *Controller) tuneParentResourceQuota(subnamespaceCopy *corev1alpha1.SubNamespace, parentResourceQuota *corev1.ResourceQuota, childResourceQuota map[corev1.ResourceName]resource.Quantity) bool {
 	remainingQuota := make(map[corev1.ResourceName]resource.Quantity)
 	if slice := subnamespaceCopy.GetSliceClaim(); slice == nil || slice != nil && subnamespaceCopy.GetResourceAllocation() != nil {
@@ -887,6 +1266,50 @@ func (c *Controller) constructSubsidiaryNamespace(subnamespaceCopy *corev1alpha1
 	return true
 }
 
+// applyTenantDefaults propagates the default container resources, the pod security level, and the namespace metadata
+// of the tenant to the child. A workspace gets them applied to its namespace, whereas a subtenant gets them copied into its spec.
+func (c *Controller) applyTenantDefaults(subnamespaceCopy *corev1alpha1.SubNamespace, childName, tenantName string) error {
+	tenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenantName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if subnamespaceCopy.GetMode() == "subtenant" {
+		subtenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), childName, metav1.GetOptions{})
+		if err != nil {
+			// The subtenant gets the defaults at a later sync if it is not created yet
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if reflect.DeepEqual(subtenant.Spec.LimitRange, tenant.Spec.LimitRange) && subtenant.Spec.PodSecurity == tenant.Spec.PodSecurity &&
+			reflect.DeepEqual(subtenant.Spec.NamespaceMetadata, tenant.Spec.NamespaceMetadata) {
+			return nil
+		}
+		subtenantCopy := subtenant.DeepCopy()
+		subtenantCopy.Spec.LimitRange = tenant.Spec.LimitRange
+		subtenantCopy.Spec.PodSecurity = tenant.Spec.PodSecurity
+		subtenantCopy.Spec.NamespaceMetadata = tenant.Spec.NamespaceMetadata
+		_, err = c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), subtenantCopy, metav1.UpdateOptions{})
+		return err
+	}
+
+	childNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	childNamespaceCopy := childNamespace.DeepCopy()
+	labels, annotations := tenant.SetNamespaceMetadata(tenant.SetPodSecurityLabels(childNamespaceCopy.GetLabels()), childNamespaceCopy.GetAnnotations())
+	childNamespaceCopy.SetLabels(labels)
+	childNamespaceCopy.SetAnnotations(annotations)
+	if !reflect.DeepEqual(childNamespace.GetLabels(), childNamespaceCopy.GetLabels()) || !reflect.DeepEqual(childNamespace.GetAnnotations(), childNamespaceCopy.GetAnnotations()) {
+		if _, err := c.kubeclientset.CoreV1().Namespaces().Update(context.TODO(), childNamespaceCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return access.ApplyTenantLimitRange(tenant, childName)
+}
+
 func (c *Controller) applyChildResourceQuota(subnamespaceCopy *corev1alpha1.SubNamespace, childName string) bool {
 	var childQuota map[corev1.ResourceName]resource.Quantity
 	var slice *string
@@ -994,15 +1417,150 @@ func (c *Controller) applyChildResourceQuota(subnamespaceCopy *corev1alpha1.SubN
 	return true
 }
 
+// federateWorkspace mirrors the namespace of a federated workspace along with its quota, roles, and role bindings into
+// the member clusters of enabled cluster peers, and records the outcome per member cluster. The mirrors are removed from
+// the member clusters of disabled peers.
+func (c *Controller) federateWorkspace(subnamespaceCopy *corev1alpha1.SubNamespace, childName, clusterUID string) bool {
+	clusterPeerRaw, err := c.edgenetclientset.CoreV1alpha1().ClusterPeers().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		klog.Infoln(err)
+		return false
+	}
+	childNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+	if err != nil {
+		klog.Infoln(err)
+		return false
+	}
+	var childResourceQuota *corev1.ResourceQuota
+	if subResourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(childName).Get(context.TODO(), "sub-quota", metav1.GetOptions{}); err == nil {
+		childResourceQuota = subResourceQuota
+	}
+	var roles []*rbacv1.Role
+	if roleRaw, err := c.kubeclientset.RbacV1().Roles(childName).List(context.TODO(), metav1.ListOptions{}); err == nil {
+		for _, roleRow := range roleRaw.Items {
+			roles = append(roles, roleRow.DeepCopy())
+		}
+	} else {
+		klog.Infoln(err)
+		return false
+	}
+	var roleBindings []*rbacv1.RoleBinding
+	if roleBindingRaw, err := c.kubeclientset.RbacV1().RoleBindings(childName).List(context.TODO(), metav1.ListOptions{}); err == nil {
+		for _, roleBindingRow := range roleBindingRaw.Items {
+			roleBindings = append(roleBindings, roleBindingRow.DeepCopy())
+		}
+	} else {
+		klog.Infoln(err)
+		return false
+	}
+
+	federated := true
+	var federationStatus []corev1alpha1.FederationStatus
+	for _, clusterPeerRow := range clusterPeerRaw.Items {
+		memberclientset, err := federation.GetMemberClientset(c.kubeclientset, clusterPeerRow.DeepCopy())
+		if !clusterPeerRow.Spec.Enabled {
+			if err == nil {
+				if err := federation.RemoveNamespace(memberclientset, clusterUID, childName); err != nil {
+					klog.Infoln(err)
+				}
+			}
+			continue
+		}
+		if err == nil {
+			err = federation.MirrorNamespace(memberclientset, clusterUID, childNamespace, childResourceQuota, roles, roleBindings)
+		}
+		if err != nil {
+			federated = false
+			federationStatus = append(federationStatus, corev1alpha1.FederationStatus{Cluster: clusterPeerRow.GetName(), State: failure, Message: err.Error()})
+			klog.Infoln(err)
+			continue
+		}
+		federationStatus = append(federationStatus, corev1alpha1.FederationStatus{Cluster: clusterPeerRow.GetName(), State: mirrored, Message: fmt.Sprintf("Namespace %s is mirrored", childName)})
+	}
+	subnamespaceCopy.Status.Federation = federationStatus
+	return federated
+}
+
+// removeFederatedWorkspace deletes the mirrors of a federated workspace from the member clusters. It goes through
+// all member clusters even if some of them fail, and returns the last error. Disabled cluster peers, whose mirrors
+// are removed as they get disabled, and peers without a usable kubeconfig are skipped and returned instead.
+func (c *Controller) removeFederatedWorkspace(childName, clusterUID string) ([]string, error) {
+	clusterPeerRaw, err := c.edgenetclientset.CoreV1alpha1().ClusterPeers().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var skipped []string
+	var removalErr error
+	for _, clusterPeerRow := range clusterPeerRaw.Items {
+		if !clusterPeerRow.Spec.Enabled {
+			skipped = append(skipped, clusterPeerRow.GetName())
+			continue
+		}
+		memberclientset, err := federation.GetMemberClientset(c.kubeclientset, clusterPeerRow.DeepCopy())
+		if err != nil {
+			klog.Infoln(err)
+			skipped = append(skipped, clusterPeerRow.GetName())
+			continue
+		}
+		if err := federation.RemoveNamespace(memberclientset, clusterUID, childName); err != nil {
+			klog.Infoln(err)
+			removalErr = err
+		}
+	}
+	return skipped, removalErr
+}
+
+// finalizeFederatedWorkspace removes the mirrors of a deleted federated workspace from the member clusters before
+// letting the workspace go. The local child is cleaned up once the workspace is gone.
+func (c *Controller) finalizeFederatedWorkspace(subnamespaceCopy *corev1alpha1.SubNamespace) error {
+	if !hasFederationFinalizer(subnamespaceCopy) {
+		return nil
+	}
+	namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), subnamespaceCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	clusterUID := namespace.GetLabels()["edge-net.io/cluster-uid"]
+	skipped, err := c.removeFederatedWorkspace(subnamespaceCopy.GenerateChildName(clusterUID), clusterUID)
+	if err != nil {
+		c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureFederation, messageFederationFail)
+		return err
+	}
+	if len(skipped) > 0 {
+		// Waiting for these peers could hold the workspace forever, so what is left behind is reported instead
+		c.recorder.Eventf(subnamespaceCopy, corev1.EventTypeWarning, failureMirrorRemoval, "%s: %s", messageMirrorRemoval, strings.Join(skipped, ", "))
+	}
+	finalizers := []string{}
+	for _, finalizer := range subnamespaceCopy.GetFinalizers() {
+		if finalizer != federationFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	subnamespaceCopy.SetFinalizers(finalizers)
+	_, err = c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).Update(context.TODO(), subnamespaceCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// hasFederationFinalizer checks whether the subnamespace holds the finalizer that removes its mirrors
+func hasFederationFinalizer(subnamespace *corev1alpha1.SubNamespace) bool {
+	for _, finalizer := range subnamespace.GetFinalizers() {
+		if finalizer == federationFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespace, childNamespace string) bool {
 	done := true
+	subnamespaceCopy.Status.Conflicts = nil
 	if subnamespaceCopy.Spec.Workspace.Inheritance["rbac"] {
 		if parentRaw, err := c.kubeclientset.RbacV1().Roles(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []rbacv1.Role
-			if childRaw, err := c.kubeclientset.RbacV1().Roles(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.RbacV1().Roles(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1011,7 +1569,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "Role", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*rbacv1.Role)
@@ -1019,8 +1578,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1046,10 +1603,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 		}
 		if parentRaw, err := c.kubeclientset.RbacV1().RoleBindings(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []rbacv1.RoleBinding
-			if childRaw, err := c.kubeclientset.RbacV1().RoleBindings(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.RbacV1().RoleBindings(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1058,7 +1615,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "RoleBinding", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*rbacv1.RoleBinding)
@@ -1066,8 +1624,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1097,10 +1653,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	if subnamespaceCopy.Spec.Workspace.Inheritance["networkpolicy"] {
 		if parentRaw, err := c.kubeclientset.NetworkingV1().NetworkPolicies(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []networkingv1.NetworkPolicy
-			if childRaw, err := c.kubeclientset.NetworkingV1().NetworkPolicies(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.NetworkingV1().NetworkPolicies(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1109,7 +1665,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "NetworkPolicy", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*networkingv1.NetworkPolicy)
@@ -1117,8 +1674,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1147,10 +1702,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	if subnamespaceCopy.Spec.Workspace.Inheritance["limitrange"] {
 		if parentRaw, err := c.kubeclientset.CoreV1().LimitRanges(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []corev1.LimitRange
-			if childRaw, err := c.kubeclientset.CoreV1().LimitRanges(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.CoreV1().LimitRanges(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1159,7 +1714,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "LimitRange", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*corev1.LimitRange)
@@ -1167,8 +1723,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1197,10 +1751,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	if subnamespaceCopy.Spec.Workspace.Inheritance["secret"] {
 		if parentRaw, err := c.kubeclientset.CoreV1().Secrets(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []corev1.Secret
-			if childRaw, err := c.kubeclientset.CoreV1().Secrets(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.CoreV1().Secrets(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1209,7 +1763,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "Secret", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*corev1.Secret)
@@ -1217,8 +1772,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1247,10 +1800,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	if subnamespaceCopy.Spec.Workspace.Inheritance["configmap"] {
 		if parentRaw, err := c.kubeclientset.CoreV1().ConfigMaps(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []corev1.ConfigMap
-			if childRaw, err := c.kubeclientset.CoreV1().ConfigMaps(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.CoreV1().ConfigMaps(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1259,7 +1812,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "ConfigMap", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*corev1.ConfigMap)
@@ -1267,8 +1821,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1297,10 +1849,10 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	if subnamespaceCopy.Spec.Workspace.Inheritance["serviceaccount"] {
 		if parentRaw, err := c.kubeclientset.CoreV1().ServiceAccounts(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			var childItems []corev1.ServiceAccount
-			if childRaw, err := c.kubeclientset.CoreV1().ServiceAccounts(childNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
+			if childRaw, err := c.kubeclientset.CoreV1().ServiceAccounts(childNamespace).List(context.TODO(), metav1.ListOptions{}); err == nil {
 				childItems = childRaw.Items
 			}
-			inheritance := Inheritance{}
+			inheritance := Inheritance{ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
 			inheritance.Child = make([]interface{}, len(childItems))
 			for k, v := range childItems {
 				inheritance.Child[k] = v.DeepCopy()
@@ -1309,7 +1861,8 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 			for k, v := range parentRaw.Items {
 				inheritance.Parent[k] = v.DeepCopy()
 			}
-			createList, updateList, deleteList := inheritance.GetOperationList()
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			appendConflicts(subnamespaceCopy, "ServiceAccount", conflicts)
 			if len(createList) > 0 {
 				for _, obj := range createList {
 					role := obj.(*corev1.ServiceAccount)
@@ -1317,8 +1870,6 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 						if !errors.IsAlreadyExists(err) {
 							done = false
 							klog.Infoln(err)
-						} else {
-							// TODO: Warning
 						}
 					}
 				}
@@ -1345,6 +1896,31 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 		c.kubeclientset.CoreV1().ServiceAccounts(childNamespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"})
 	}
 
+	if !c.handleAllowedInheritance(subnamespaceCopy, childNamespace) {
+		done = false
+	}
+
+	if len(subnamespaceCopy.Status.Conflicts) > 0 {
+		var conflicts, overwritten []string
+		for _, conflict := range subnamespaceCopy.Status.Conflicts {
+			conflicts = append(conflicts, fmt.Sprintf("%s/%s", conflict.Kind, conflict.Name))
+			switch conflict.Kind {
+			case "Role", "RoleBinding", "NetworkPolicy":
+				overwritten = append(overwritten, fmt.Sprintf("%s/%s", conflict.Kind, conflict.Name))
+			}
+		}
+		message := fmt.Sprintf("%s: %s", messageConflict, strings.Join(conflicts, ", "))
+		c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureConflict, message)
+		util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionInheritanceConflict, metav1.ConditionTrue, reasonConflictDetected, message)
+		if subnamespaceCopy.Spec.Workspace.ConflictPolicy == corev1alpha1.ConflictPolicyFail {
+			done = false
+		} else if len(overwritten) > 0 {
+			c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureOverwritten, fmt.Sprintf("%s: %s", messageOverwritten, strings.Join(overwritten, ", ")))
+		}
+	} else {
+		util.SetCondition(&subnamespaceCopy.Status.Conditions, subnamespaceCopy.GetGeneration(), corev1alpha1.ConditionInheritanceConflict, metav1.ConditionFalse, reasonNoConflict, "No object collides with the objects inherited from the parent namespace")
+	}
+
 	if !done {
 		c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, failureInheritance, messageInheritanceFail)
 		subnamespaceCopy.Status.State = failure
@@ -1353,32 +1929,207 @@ func (c *Controller) handleInheritance(subnamespaceCopy *corev1alpha1.SubNamespa
 	return done
 }
 
+// appendConflicts records the child objects of a kind that collide with the parent objects in the status
+func appendConflicts(subnamespaceCopy *corev1alpha1.SubNamespace, kind string, conflicts []string) {
+	for _, name := range conflicts {
+		subnamespaceCopy.Status.Conflicts = append(subnamespaceCopy.Status.Conflicts, corev1alpha1.InheritanceConflict{Kind: kind, Name: name})
+	}
+}
+
+// handleAllowedInheritance propagates the objects of the kinds in the inheritance allow-list from the parent namespace to the child
+func (c *Controller) handleAllowedInheritance(subnamespaceCopy *corev1alpha1.SubNamespace, childNamespace string) bool {
+	allowList, err := c.getInheritanceAllowList()
+	if err != nil {
+		klog.Infoln(err)
+		return false
+	}
+
+	done := true
+	for key, resource := range allowList {
+		if subnamespaceCopy.Spec.Workspace.Inheritance[key] {
+			parentRaw, err := c.dynamicclientset.Resource(resource).Namespace(subnamespaceCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				done = false
+				klog.Infoln(err)
+				continue
+			}
+			// Without the objects of the child, the objects to delete and the conflicts cannot be told apart
+			childRaw, err := c.dynamicclientset.Resource(resource).Namespace(childNamespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				done = false
+				klog.Infoln(err)
+				continue
+			}
+			inheritance := Inheritance{ChildNamespace: childNamespace, ConflictPolicy: subnamespaceCopy.Spec.Workspace.ConflictPolicy}
+			inheritance.Child = make([]interface{}, len(childRaw.Items))
+			for k, v := range childRaw.Items {
+				inheritance.Child[k] = v.DeepCopy()
+			}
+			inheritance.Parent = make([]interface{}, len(parentRaw.Items))
+			for k, v := range parentRaw.Items {
+				inheritance.Parent[k] = v.DeepCopy()
+			}
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			if len(parentRaw.Items) > 0 {
+				appendConflicts(subnamespaceCopy, parentRaw.Items[0].GetKind(), conflicts)
+			}
+			for _, obj := range createList {
+				object := obj.(*unstructured.Unstructured)
+				unstructured.RemoveNestedField(object.Object, "status")
+				unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+				unstructured.RemoveNestedField(object.Object, "metadata", "creationTimestamp")
+				unstructured.RemoveNestedField(object.Object, "metadata", "ownerReferences")
+				if _, err := c.dynamicclientset.Resource(resource).Namespace(childNamespace).Create(context.TODO(), object, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+					done = false
+					klog.Infoln(err)
+				}
+			}
+			for _, obj := range updateList {
+				object := obj.(*unstructured.Unstructured)
+				if _, err := c.dynamicclientset.Resource(resource).Namespace(childNamespace).Update(context.TODO(), object, metav1.UpdateOptions{}); err != nil {
+					done = false
+					klog.Infoln(err)
+				}
+			}
+			for objName := range deleteList {
+				if err := c.dynamicclientset.Resource(resource).Namespace(childNamespace).Delete(context.TODO(), objName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+					done = false
+					klog.Infoln(err)
+				}
+			}
+		} else if err := c.dynamicclientset.Resource(resource).Namespace(childNamespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err != nil {
+			done = false
+			klog.Infoln(err)
+		}
+	}
+	return done
+}
+
+// getInheritanceAllowList returns the resources that cluster admins allow workspaces to inherit, keyed by the name used in the inheritance
+// of a workspace. The kinds that have dedicated handling are left out.
+func (c *Controller) getInheritanceAllowList() (map[string]schema.GroupVersionResource, error) {
+	allowList := make(map[string]schema.GroupVersionResource)
+	configMap, err := c.kubeclientset.CoreV1().ConfigMaps("edgenet").Get(context.TODO(), inheritanceAllowList, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return allowList, nil
+		}
+		return nil, err
+	}
+	for key, value := range configMap.Data {
+		switch key {
+		case "rbac", "networkpolicy", "limitrange", "secret", "configmap", "serviceaccount":
+			continue
+		}
+		resource, err := parseGroupVersionResource(strings.TrimSpace(value))
+		if err != nil {
+			klog.Infoln(err)
+			continue
+		}
+		allowList[key] = resource
+	}
+	return allowList, nil
+}
+
+// parseGroupVersionResource parses a resource in the form of group/version/resource, or version/resource for the core group
+func parseGroupVersionResource(value string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(value, "/")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}, nil
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("%s is not in the form of group/version/resource", value)
+}
+
 type Inheritance struct {
 	Child          []interface{}
 	Parent         []interface{}
 	ChildNamespace string
+	ConflictPolicy string
 }
 
-func (i Inheritance) GetOperationList() ([]interface{}, []interface{}, map[string]interface{}) {
+// GetOperationList returns the objects to create, update, and delete in the child namespace, along with the names of the child objects
+// that collide with the parent objects. A child object collides when it is not generated by the inheritance or it is marked as overridden,
+// and it differs from the parent object with the same name. A local role, role binding, or network policy is overwritten as the parent always wins,
+// but it still collides, so that the overwrite is reported, and the fail policy keeps it as it is.
+func (i Inheritance) GetOperationList() ([]interface{}, []interface{}, map[string]interface{}, []string) {
 	var createList []interface{}
 	var updateList []interface{}
+	var conflicts []string
 	comparisonSlice := make(map[string]interface{})
 	for _, childObj := range i.Child {
 		comparisonSlice[childObj.(metav1.Object).GetName()] = childObj
 	}
 	for _, parentObj := range i.Parent {
-		if _, ok := comparisonSlice[parentObj.(metav1.Object).GetName()]; ok {
-			childObj := i.prepareForUpdate(comparisonSlice[parentObj.(metav1.Object).GetName()], parentObj)
-			if childObj != nil {
-				updateList = append(updateList, childObj)
-			}
+		if parentObj.(metav1.Object).GetAnnotations()[corev1alpha1.InheritanceSkip] == "true" || isNamespaceDefault(parentObj) {
+			continue
+		}
+		if childObj, ok := comparisonSlice[parentObj.(metav1.Object).GetName()]; ok {
 			delete(comparisonSlice, parentObj.(metav1.Object).GetName())
+			if isInherited(childObj) {
+				if childObj := i.prepareForUpdate(childObj, parentObj); childObj != nil {
+					updateList = append(updateList, childObj)
+				}
+				continue
+			}
+			if i.ConflictPolicy == corev1alpha1.ConflictPolicyParentWins || isEnforced(parentObj) {
+				annotations := childObj.(metav1.Object).GetAnnotations()
+				delete(annotations, corev1alpha1.InheritanceOverride)
+				childObj.(metav1.Object).SetAnnotations(annotations)
+				if childObj := i.prepareForUpdate(childObj, parentObj); childObj != nil {
+					if isEnforced(parentObj) {
+						conflicts = append(conflicts, parentObj.(metav1.Object).GetName())
+						if i.ConflictPolicy == corev1alpha1.ConflictPolicyFail {
+							continue
+						}
+					}
+					updateList = append(updateList, childObj)
+				}
+			} else if childObj := i.prepareForUpdate(childObj, parentObj); childObj != nil {
+				conflicts = append(conflicts, parentObj.(metav1.Object).GetName())
+			}
 		} else {
 			childObj := i.prepareForCreate(parentObj)
 			createList = append(createList, childObj)
 		}
 	}
-	return createList, updateList, comparisonSlice
+	// Only the objects that the inheritance manages are removed along with their parents
+	for name, childObj := range comparisonSlice {
+		if !isInherited(childObj) {
+			delete(comparisonSlice, name)
+		}
+	}
+	return createList, updateList, comparisonSlice, conflicts
+}
+
+// isNamespaceDefault tells whether the object is one that every namespace gets on its own, either from Kubernetes or from the tenant
+func isNamespaceDefault(obj interface{}) bool {
+	switch obj.(type) {
+	case *corev1.ServiceAccount:
+		return obj.(metav1.Object).GetName() == "default"
+	case *corev1.ConfigMap:
+		return obj.(metav1.Object).GetName() == "kube-root-ca.crt"
+	case *corev1.LimitRange:
+		return obj.(metav1.Object).GetName() == corev1alpha1.TenantLimitRange
+	}
+	return false
+}
+
+// isInherited tells whether the inheritance manages the child object, which is generated and not overridden locally
+func isInherited(obj interface{}) bool {
+	return obj.(metav1.Object).GetLabels()["edge-net.io/generated"] == "true" && (isEnforced(obj) || obj.(metav1.Object).GetAnnotations()[corev1alpha1.InheritanceOverride] != "true")
+}
+
+// isEnforced tells whether the object guards the access to the workspace, in which case the parent object always wins
+// regardless of the override annotation and the conflict policy
+func isEnforced(obj interface{}) bool {
+	switch obj.(type) {
+	case *rbacv1.Role, *rbacv1.RoleBinding, *networkingv1.NetworkPolicy:
+		return true
+	}
+	return false
 }
 
 func (i Inheritance) prepareForCreate(obj interface{}) interface{} {
@@ -1386,6 +2137,10 @@ func (i Inheritance) prepareForCreate(obj interface{}) interface{} {
 	obj.(metav1.Object).SetUID(types.UID(uuid.New().String()))
 	obj.(metav1.Object).SetResourceVersion("")
 	obj.(metav1.Object).SetLabels(map[string]string{"edge-net.io/generated": "true"})
+	// The parent object can be an override of its own parent, which does not hold for the child
+	annotations := obj.(metav1.Object).GetAnnotations()
+	delete(annotations, corev1alpha1.InheritanceOverride)
+	obj.(metav1.Object).SetAnnotations(annotations)
 	return obj
 }
 
@@ -1458,7 +2213,7 @@ func (i Inheritance) prepareForUpdate(childObj, parentObj interface{}) interface
 			childSecretCopy.SetLabels(parentLabels)
 			childForUpdate = childSecretCopy
 		}
-	case corev1.ConfigMap:
+	case *corev1.ConfigMap:
 		childConfigMapCopy := childObj.(*corev1.ConfigMap)
 		parentLabels := parentObjectForUpdate.GetLabels()
 		if parentLabels == nil {
@@ -1473,6 +2228,34 @@ func (i Inheritance) prepareForUpdate(childObj, parentObj interface{}) interface
 			childConfigMapCopy.SetLabels(parentLabels)
 			childForUpdate = childConfigMapCopy
 		}
+	case *unstructured.Unstructured:
+		childObjectCopy := childObj.(*unstructured.Unstructured)
+		parentLabels := parentObjectForUpdate.GetLabels()
+		if parentLabels == nil {
+			parentLabels = make(map[string]string)
+		}
+		parentLabels["edge-net.io/generated"] = "true"
+		// Everything but the metadata and the status of the object is what the parent hands down
+		changed := !reflect.DeepEqual(childObjectCopy.GetLabels(), parentLabels)
+		for field, value := range parentObjectForUpdate.Object {
+			if field == "metadata" || field == "status" {
+				continue
+			}
+			if !reflect.DeepEqual(childObjectCopy.Object[field], value) {
+				childObjectCopy.Object[field] = value
+				changed = true
+			}
+		}
+		for field := range childObjectCopy.Object {
+			if _, elementExists := parentObjectForUpdate.Object[field]; !elementExists && field != "metadata" && field != "status" {
+				delete(childObjectCopy.Object, field)
+				changed = true
+			}
+		}
+		if changed {
+			childObjectCopy.SetLabels(parentLabels)
+			childForUpdate = childObjectCopy
+		}
 	case *corev1.ServiceAccount:
 		childServiceAccountCopy := childObj.(*corev1.ServiceAccount)
 		parentLabels := parentObjectForUpdate.GetLabels()
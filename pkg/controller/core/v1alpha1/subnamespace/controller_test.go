@@ -11,6 +11,7 @@ import (
 	"time"
 
 	corev1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/federation"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
@@ -22,11 +23,17 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/klog"
 )
 
@@ -40,6 +47,9 @@ type TestGroup struct {
 
 var kubeclientset kubernetes.Interface = testclient.NewSimpleClientset()
 var edgenetclientset versioned.Interface = edgenettestclient.NewSimpleClientset()
+var dynamicclientset = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+	{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"}: "PodDisruptionBudgetList",
+})
 
 func TestMain(m *testing.M) {
 	klog.SetOutput(ioutil.Discard)
@@ -51,11 +61,16 @@ func TestMain(m *testing.M) {
 
 	stopCh := signals.SetupSignalHandler()
 
+	informerOption := kubeinformers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+		listOptions.LabelSelector = "edge-net.io/tenant"
+	})
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeclientset, 0)
+	namespaceInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeclientset, 0, informerOption)
 	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, 0)
 
 	controller := NewController(kubeclientset,
 		edgenetclientset,
+		dynamicclientset,
 		kubeInformerFactory.Rbac().V1().Roles(),
 		kubeInformerFactory.Rbac().V1().RoleBindings(),
 		kubeInformerFactory.Networking().V1().NetworkPolicies(),
@@ -63,8 +78,11 @@ func TestMain(m *testing.M) {
 		kubeInformerFactory.Core().V1().Secrets(),
 		kubeInformerFactory.Core().V1().ConfigMaps(),
 		kubeInformerFactory.Core().V1().ServiceAccounts(),
+		namespaceInformerFactory.Core().V1().Namespaces(),
 		edgenetInformerFactory.Core().V1alpha1().SubNamespaces())
 
+	kubeInformerFactory.Start(stopCh)
+	namespaceInformerFactory.Start(stopCh)
 	edgenetInformerFactory.Start(stopCh)
 
 	go func() {
@@ -470,3 +488,489 @@ func TestQuota(t *testing.T) {
 	_, err = kubeclientset.CoreV1().Namespaces().Get(context.TODO(), childName3, metav1.GetOptions{})
 	util.Equals(t, true, errors.IsNotFound(err))
 }
+
+func TestTenantDefaults(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	tenant.Spec.PodSecurity = "baseline"
+	tenant.Spec.NamespaceMetadata = &corev1alpha.NamespaceMetadata{Labels: map[string]string{"team": "edge"}, Annotations: map[string]string{"cost-center": "lip6"}}
+	tenant.Spec.LimitRange = &corev1alpha.ContainerLimitRange{
+		Default:        map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("500m"), "memory": resource.MustParse("512Mi")},
+		DefaultRequest: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("100m"), "memory": resource.MustParse("128Mi")},
+	}
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	defer func() {
+		tenant.Spec.PodSecurity = ""
+		tenant.Spec.LimitRange = nil
+		tenant.Spec.NamespaceMetadata = nil
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	}()
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.SetName("defaults")
+	subnamespace.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("500m")
+	subnamespace.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("512Mi")
+	childName := subnamespace.GenerateChildName("")
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{})
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	childNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "baseline", childNamespace.GetLabels()["pod-security.kubernetes.io/enforce"])
+	util.Equals(t, "edge", childNamespace.GetLabels()["team"])
+	util.Equals(t, "lip6", childNamespace.GetAnnotations()["cost-center"])
+	limitRange, err := kubeclientset.CoreV1().LimitRanges(childName).Get(context.TODO(), corev1alpha.TenantLimitRange, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, tenant.MakeLimitRange(childName).Spec, limitRange.Spec)
+}
+
+func TestAllowedInheritance(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	pdbResource := schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"}
+	allowList := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: inheritanceAllowList, Namespace: "edgenet"},
+		Data: map[string]string{"poddisruptionbudget": "policy/v1beta1/poddisruptionbudgets", "rbac": "rbac.authorization.k8s.io/v1/roles"}}
+	kubeclientset.CoreV1().ConfigMaps(allowList.GetNamespace()).Create(context.TODO(), allowList, metav1.CreateOptions{})
+	defer kubeclientset.CoreV1().ConfigMaps(allowList.GetNamespace()).Delete(context.TODO(), allowList.GetName(), metav1.DeleteOptions{})
+	podDisruptionBudget := &unstructured.Unstructured{}
+	podDisruptionBudget.SetAPIVersion("policy/v1beta1")
+	podDisruptionBudget.SetKind("PodDisruptionBudget")
+	podDisruptionBudget.SetName("edgenet-test")
+	podDisruptionBudget.SetNamespace(g.tenantObj.GetName())
+	unstructured.SetNestedField(podDisruptionBudget.Object, int64(1), "spec", "minAvailable")
+	_, err := dynamicclientset.Resource(pdbResource).Namespace(g.tenantObj.GetName()).Create(context.TODO(), podDisruptionBudget, metav1.CreateOptions{})
+	util.OK(t, err)
+	defer dynamicclientset.Resource(pdbResource).Namespace(g.tenantObj.GetName()).Delete(context.TODO(), podDisruptionBudget.GetName(), metav1.DeleteOptions{})
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.SetName("allowed")
+	subnamespace.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("500m")
+	subnamespace.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("512Mi")
+	subnamespace.Spec.Workspace.Inheritance["poddisruptionbudget"] = true
+	childName := subnamespace.GenerateChildName("")
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{})
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	childPodDisruptionBudget, err := dynamicclientset.Resource(pdbResource).Namespace(childName).Get(context.TODO(), podDisruptionBudget.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "true", childPodDisruptionBudget.GetLabels()["edge-net.io/generated"])
+	minAvailable, _, _ := unstructured.NestedInt64(childPodDisruptionBudget.Object, "spec", "minAvailable")
+	util.Equals(t, int64(1), minAvailable)
+
+	t.Run("update", func(t *testing.T) {
+		parentPodDisruptionBudget, err := dynamicclientset.Resource(pdbResource).Namespace(g.tenantObj.GetName()).Get(context.TODO(), podDisruptionBudget.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		unstructured.SetNestedField(parentPodDisruptionBudget.Object, int64(2), "spec", "minAvailable")
+		dynamicclientset.Resource(pdbResource).Namespace(g.tenantObj.GetName()).Update(context.TODO(), parentPodDisruptionBudget, metav1.UpdateOptions{})
+		subnamespace, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "allowed", metav1.GetOptions{})
+		util.OK(t, err)
+		subnamespace.Spec.Workspace.Inheritance["rbac"] = false
+		edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), subnamespace, metav1.UpdateOptions{})
+		time.Sleep(450 * time.Millisecond)
+		childPodDisruptionBudget, err := dynamicclientset.Resource(pdbResource).Namespace(childName).Get(context.TODO(), podDisruptionBudget.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		minAvailable, _, _ := unstructured.NestedInt64(childPodDisruptionBudget.Object, "spec", "minAvailable")
+		util.Equals(t, int64(2), minAvailable)
+	})
+}
+
+func TestAllowedInheritanceFailure(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	allowList := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: inheritanceAllowList, Namespace: "edgenet"},
+		Data: map[string]string{"poddisruptionbudget": "policy/v1beta1/poddisruptionbudgets"}}
+	failingclientset := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"}: "PodDisruptionBudgetList",
+	})
+	// Neither the objects of the child can be listed, nor the inherited ones deleted
+	failingclientset.PrependReactor("list", "poddisruptionbudgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "child" {
+			return true, nil, fmt.Errorf("list failed")
+		}
+		return false, nil, nil
+	})
+	failingclientset.PrependReactor("delete-collection", "poddisruptionbudgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("deletion failed")
+	})
+	c := &Controller{kubeclientset: testclient.NewSimpleClientset(allowList), dynamicclientset: failingclientset}
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.Spec.Workspace.Inheritance = map[string]bool{"poddisruptionbudget": true}
+	util.Equals(t, false, c.handleAllowedInheritance(subnamespace, "child"))
+	subnamespace.Spec.Workspace.Inheritance = map[string]bool{"poddisruptionbudget": false}
+	util.Equals(t, false, c.handleAllowedInheritance(subnamespace, "child"))
+}
+
+func TestInheritanceConflict(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	parentConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "edgenet-settings", Namespace: g.tenantObj.GetName()}, Data: map[string]string{"mode": "parent"}}
+	kubeclientset.CoreV1().ConfigMaps(parentConfigMap.GetNamespace()).Create(context.TODO(), parentConfigMap, metav1.CreateOptions{})
+	defer kubeclientset.CoreV1().ConfigMaps(parentConfigMap.GetNamespace()).Delete(context.TODO(), parentConfigMap.GetName(), metav1.DeleteOptions{})
+	skippedConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "edgenet-private", Namespace: g.tenantObj.GetName(), Annotations: map[string]string{corev1alpha.InheritanceSkip: "true"}}}
+	kubeclientset.CoreV1().ConfigMaps(skippedConfigMap.GetNamespace()).Create(context.TODO(), skippedConfigMap, metav1.CreateOptions{})
+	defer kubeclientset.CoreV1().ConfigMaps(skippedConfigMap.GetNamespace()).Delete(context.TODO(), skippedConfigMap.GetName(), metav1.DeleteOptions{})
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.SetName("conflict")
+	subnamespace.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("500m")
+	subnamespace.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("512Mi")
+	subnamespace.Spec.Workspace.Inheritance["configmap"] = true
+	childName := subnamespace.GenerateChildName("")
+	localConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: parentConfigMap.GetName(), Namespace: childName}, Data: map[string]string{"mode": "child"}}
+	kubeclientset.CoreV1().ConfigMaps(childName).Create(context.TODO(), localConfigMap, metav1.CreateOptions{})
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{})
+	_, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	_, err = kubeclientset.CoreV1().ConfigMaps(childName).Get(context.TODO(), skippedConfigMap.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	childConfigMap, err := kubeclientset.CoreV1().ConfigMaps(childName).Get(context.TODO(), parentConfigMap.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "child", childConfigMap.Data["mode"])
+	subnamespace, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), subnamespace.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, established, subnamespace.Status.State)
+	util.Equals(t, []corev1alpha.InheritanceConflict{{Kind: "ConfigMap", Name: parentConfigMap.GetName()}}, subnamespace.Status.Conflicts)
+	util.Equals(t, metav1.ConditionTrue, meta.FindStatusCondition(subnamespace.Status.Conditions, corev1alpha.ConditionInheritanceConflict).Status)
+
+	t.Run("fail", func(t *testing.T) {
+		subnamespace, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "conflict", metav1.GetOptions{})
+		util.OK(t, err)
+		subnamespace.Spec.Workspace.ConflictPolicy = corev1alpha.ConflictPolicyFail
+		edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), subnamespace, metav1.UpdateOptions{})
+		time.Sleep(450 * time.Millisecond)
+		subnamespace, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "conflict", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, subnamespace.Status.State)
+		util.Equals(t, metav1.ConditionFalse, meta.FindStatusCondition(subnamespace.Status.Conditions, corev1alpha.ConditionInheritanceSynced).Status)
+	})
+	t.Run("parent wins", func(t *testing.T) {
+		subnamespace, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "conflict", metav1.GetOptions{})
+		util.OK(t, err)
+		subnamespace.Spec.Workspace.ConflictPolicy = corev1alpha.ConflictPolicyParentWins
+		edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), subnamespace, metav1.UpdateOptions{})
+		time.Sleep(450 * time.Millisecond)
+		childConfigMap, err := kubeclientset.CoreV1().ConfigMaps(childName).Get(context.TODO(), parentConfigMap.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "parent", childConfigMap.Data["mode"])
+		util.Equals(t, "true", childConfigMap.GetLabels()["edge-net.io/generated"])
+		subnamespace, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "conflict", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, established, subnamespace.Status.State)
+		util.Equals(t, 0, len(subnamespace.Status.Conflicts))
+	})
+	t.Run("override", func(t *testing.T) {
+		childConfigMap, err := kubeclientset.CoreV1().ConfigMaps(childName).Get(context.TODO(), parentConfigMap.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		childConfigMap.SetAnnotations(map[string]string{corev1alpha.InheritanceOverride: "true"})
+		childConfigMap.Data = map[string]string{"mode": "override"}
+		kubeclientset.CoreV1().ConfigMaps(childName).Update(context.TODO(), childConfigMap, metav1.UpdateOptions{})
+		subnamespace, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), "conflict", metav1.GetOptions{})
+		util.OK(t, err)
+		subnamespace.Spec.Workspace.ConflictPolicy = corev1alpha.ConflictPolicyChildWins
+		edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), subnamespace, metav1.UpdateOptions{})
+		time.Sleep(450 * time.Millisecond)
+		childConfigMap, err = kubeclientset.CoreV1().ConfigMaps(childName).Get(context.TODO(), parentConfigMap.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "override", childConfigMap.Data["mode"])
+	})
+}
+
+func TestEnforcedInheritance(t *testing.T) {
+	parentRole := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "edgenet-test", Namespace: "parent"},
+		Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}}
+	childRole := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "edgenet-test", Namespace: "child"},
+		Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}}
+
+	cases := map[string]struct {
+		labels      map[string]string
+		annotations map[string]string
+		policy      string
+		updated     bool
+		conflicts   []string
+	}{
+		"overridden":        {map[string]string{"edge-net.io/generated": "true"}, map[string]string{corev1alpha.InheritanceOverride: "true"}, corev1alpha.ConflictPolicyChildWins, true, nil},
+		"local":             {nil, nil, corev1alpha.ConflictPolicyChildWins, true, []string{"edgenet-test"}},
+		"local/parent-wins": {nil, nil, corev1alpha.ConflictPolicyParentWins, true, []string{"edgenet-test"}},
+		"local/fail":        {nil, nil, corev1alpha.ConflictPolicyFail, false, []string{"edgenet-test"}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			childRoleCopy := childRole.DeepCopy()
+			childRoleCopy.SetLabels(tc.labels)
+			childRoleCopy.SetAnnotations(tc.annotations)
+			inheritance := Inheritance{ChildNamespace: "child", ConflictPolicy: tc.policy,
+				Parent: []interface{}{parentRole.DeepCopy()}, Child: []interface{}{childRoleCopy}}
+			createList, updateList, deleteList, conflicts := inheritance.GetOperationList()
+			util.Equals(t, 0, len(createList))
+			if tc.updated {
+				util.Equals(t, 1, len(updateList))
+				util.Equals(t, parentRole.Rules, updateList[0].(*rbacv1.Role).Rules)
+			} else {
+				util.Equals(t, 0, len(updateList))
+			}
+			util.Equals(t, 0, len(deleteList))
+			util.Equals(t, tc.conflicts, conflicts)
+		})
+	}
+}
+
+func TestContinuousSync(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.SetName("synced")
+	subnamespace.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("1000m")
+	subnamespace.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("1Gi")
+	subnamespace.Spec.Workspace.Inheritance["secret"] = true
+	subnamespace.Spec.Workspace.Sync = true
+	childName := subnamespace.GenerateChildName("")
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{})
+	_, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+	nested := subnamespace.DeepCopy()
+	nested.SetName("synced-nested")
+	nested.SetNamespace(childName)
+	nested.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("500m")
+	nested.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("512Mi")
+	nestedChildName := nested.GenerateChildName("")
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(childName).Delete(context.TODO(), nested.GetName(), metav1.DeleteOptions{})
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(childName).Create(context.TODO(), nested, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	// The secret does not carry a tenant label, as most objects in parent namespaces do not
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: g.tenantObj.GetName()},
+		Data: map[string][]byte{"token": []byte("initial")}}
+	_, err = kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+	util.OK(t, err)
+	defer kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Delete(context.TODO(), secret.GetName(), metav1.DeleteOptions{})
+	time.Sleep(900 * time.Millisecond)
+	for _, namespace := range []string{childName, nestedChildName} {
+		childSecret, err := kubeclientset.CoreV1().Secrets(namespace).Get(context.TODO(), secret.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []byte("initial"), childSecret.Data["token"])
+	}
+
+	t.Run("rotate", func(t *testing.T) {
+		secret, err := kubeclientset.CoreV1().Secrets(g.tenantObj.GetName()).Get(context.TODO(), "registry-credentials", metav1.GetOptions{})
+		util.OK(t, err)
+		secret.Data = map[string][]byte{"token": []byte("rotated")}
+		_, err = kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Update(context.TODO(), secret, metav1.UpdateOptions{})
+		util.OK(t, err)
+		time.Sleep(900 * time.Millisecond)
+		for _, namespace := range []string{childName, nestedChildName} {
+			childSecret, err := kubeclientset.CoreV1().Secrets(namespace).Get(context.TODO(), secret.GetName(), metav1.GetOptions{})
+			util.OK(t, err)
+			util.Equals(t, []byte("rotated"), childSecret.Data["token"])
+		}
+	})
+}
+
+func TestFederation(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	memberclientset := testclient.NewSimpleClientset()
+	federation.CreateMemberClientset = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return memberclientset, nil
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "member-kubeconfig", Namespace: "edgenet"}, Data: map[string][]byte{federation.KubeconfigKey: []byte("member")}}
+	kubeclientset.CoreV1().Secrets(secret.GetNamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+	memberPeer := &corev1alpha.ClusterPeer{ObjectMeta: metav1.ObjectMeta{Name: "member"}, Spec: corev1alpha.ClusterPeerSpec{SecretName: secret.GetName(), Enabled: true}}
+	edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), memberPeer, metav1.CreateOptions{})
+	defer edgenetclientset.CoreV1alpha1().ClusterPeers().Delete(context.TODO(), memberPeer.GetName(), metav1.DeleteOptions{})
+	brokenPeer := &corev1alpha.ClusterPeer{ObjectMeta: metav1.ObjectMeta{Name: "broken"}, Spec: corev1alpha.ClusterPeerSpec{SecretName: "broken-kubeconfig", Enabled: true}}
+	edgenetclientset.CoreV1alpha1().ClusterPeers().Create(context.TODO(), brokenPeer, metav1.CreateOptions{})
+	defer edgenetclientset.CoreV1alpha1().ClusterPeers().Delete(context.TODO(), brokenPeer.GetName(), metav1.DeleteOptions{})
+
+	subnamespace := g.subNamespaceObj.DeepCopy()
+	subnamespace.SetName("federated")
+	subnamespace.Spec.Workspace.Scope = "federated"
+	subnamespace.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("500m")
+	subnamespace.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("512Mi")
+	childName := subnamespace.GenerateChildName("")
+	_, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	_, err = memberclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+	util.OK(t, err)
+	subResourceQuota, err := kubeclientset.CoreV1().ResourceQuotas(childName).Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	mirrorResourceQuota, err := memberclientset.CoreV1().ResourceQuotas(childName).Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, subResourceQuota.Spec.Hard, mirrorResourceQuota.Spec.Hard)
+	_, err = memberclientset.RbacV1().Roles(childName).Get(context.TODO(), "edgenet-test", metav1.GetOptions{})
+	util.OK(t, err)
+
+	subnamespace, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), subnamespace.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, established, subnamespace.Status.State)
+	util.Equals(t, 2, len(subnamespace.Status.Federation))
+	for _, federationStatus := range subnamespace.Status.Federation {
+		switch federationStatus.Cluster {
+		case memberPeer.GetName():
+			util.Equals(t, mirrored, federationStatus.State)
+		case brokenPeer.GetName():
+			util.Equals(t, failure, federationStatus.State)
+		}
+	}
+	util.Equals(t, metav1.ConditionFalse, meta.FindStatusCondition(subnamespace.Status.Conditions, corev1alpha.ConditionFederated).Status)
+
+	// The fake clientset ignores finalizers, so the deletion is marked the way the API server does it
+	util.Equals(t, true, hasFederationFinalizer(subnamespace))
+	deletionTimestamp := metav1.Now()
+	subnamespace.SetDeletionTimestamp(&deletionTimestamp)
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), subnamespace, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+	_, err = memberclientset.CoreV1().Namespaces().Get(context.TODO(), childName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	// The member cluster without a kubeconfig is skipped rather than holding the workspace
+	subnamespace, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), subnamespace.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, false, hasFederationFinalizer(subnamespace))
+	edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{})
+}
+
+func TestFederationScopeSpelling(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	federated := g.subNamespaceObj.DeepCopy()
+	federated.Spec.Workspace.Scope = "federated"
+	legacy := g.subNamespaceObj.DeepCopy()
+	legacy.Spec.Workspace.Scope = "federation"
+	util.Equals(t, true, legacy.IsFederated())
+	util.Equals(t, federated.GenerateChildName("cluster"), legacy.GenerateChildName("cluster"))
+	util.Equals(t, false, g.subNamespaceObj.IsFederated())
+}
+
+func TestMove(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	source := g.subNamespaceObj.DeepCopy()
+	source.SetName("move-source")
+	source.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("1000m")
+	source.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("1Gi")
+	sourceChildName := source.GenerateChildName("")
+	target := g.subNamespaceObj.DeepCopy()
+	target.SetName("move-target")
+	target.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("2000m")
+	target.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("2Gi")
+	targetChildName := target.GenerateChildName("")
+	_, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), source, metav1.CreateOptions{})
+	util.OK(t, err)
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), target, metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Delete(context.TODO(), target.GetName(), metav1.DeleteOptions{})
+
+	coreResourceQuota, err := kubeclientset.CoreV1().ResourceQuotas(g.tenantObj.GetName()).Get(context.TODO(), "core-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	coreQuotaCPU := coreResourceQuota.Spec.Hard.Cpu().Value()
+
+	source, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), source.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	source.SetAnnotations(map[string]string{corev1alpha.SubNamespaceMoveTo: targetChildName})
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), source, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(450 * time.Millisecond)
+
+	_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), source.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	moved, err := edgenetclientset.CoreV1alpha1().SubNamespaces(targetChildName).Get(context.TODO(), source.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, sourceChildName, moved.GenerateChildName(""))
+	util.Equals(t, established, moved.Status.State)
+	defer edgenetclientset.CoreV1alpha1().SubNamespaces(targetChildName).Delete(context.TODO(), moved.GetName(), metav1.DeleteOptions{})
+
+	childNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), sourceChildName, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, targetChildName, metav1.GetControllerOf(childNamespace).Name)
+	util.Equals(t, targetChildName, childNamespace.GetLabels()["edge-net.io/parent-namespace"])
+	coreResourceQuota, err = kubeclientset.CoreV1().ResourceQuotas(g.tenantObj.GetName()).Get(context.TODO(), "core-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, coreQuotaCPU+1, coreResourceQuota.Spec.Hard.Cpu().Value())
+	targetResourceQuota, err := kubeclientset.CoreV1().ResourceQuotas(targetChildName).Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, int64(1), targetResourceQuota.Spec.Hard.Cpu().Value())
+
+	t.Run("move under descendant", func(t *testing.T) {
+		target, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), target.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		target.SetAnnotations(map[string]string{corev1alpha.SubNamespaceMoveTo: sourceChildName})
+		_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), target, metav1.UpdateOptions{})
+		util.OK(t, err)
+		time.Sleep(450 * time.Millisecond)
+		target, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), target.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, metav1.ConditionFalse, meta.FindStatusCondition(target.Status.Conditions, corev1alpha.ConditionMoved).Status)
+		_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(sourceChildName).Get(context.TODO(), target.GetName(), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+	t.Run("retry after failed namespace update", func(t *testing.T) {
+		retried := g.subNamespaceObj.DeepCopy()
+		retried.SetName("move-retry")
+		retried.Spec.Workspace.ResourceAllocation["cpu"] = resource.MustParse("1000m")
+		retried.Spec.Workspace.ResourceAllocation["memory"] = resource.MustParse("1Gi")
+		retriedChildName := retried.GenerateChildName("")
+		_, err := edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Create(context.TODO(), retried, metav1.CreateOptions{})
+		util.OK(t, err)
+		time.Sleep(450 * time.Millisecond)
+		coreResourceQuota, err := kubeclientset.CoreV1().ResourceQuotas(g.tenantObj.GetName()).Get(context.TODO(), "core-quota", metav1.GetOptions{})
+		util.OK(t, err)
+		coreQuotaCPU := coreResourceQuota.Spec.Hard.Cpu().Value()
+		targetResourceQuota, err := kubeclientset.CoreV1().ResourceQuotas(targetChildName).Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+		util.OK(t, err)
+		targetQuotaCPU := targetResourceQuota.Spec.Hard.Cpu().Value()
+
+		// The child namespace cannot change hands at the first attempt, after the quota steps are taken
+		failed := false
+		kubeclientset.(*testclient.Clientset).PrependReactor("update", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if namespace := action.(k8stesting.UpdateAction).GetObject().(*corev1.Namespace); namespace.GetName() == retriedChildName && !failed {
+				failed = true
+				return true, nil, fmt.Errorf("update failed")
+			}
+			return false, nil, nil
+		})
+		retried, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Get(context.TODO(), retried.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		retried.SetAnnotations(map[string]string{corev1alpha.SubNamespaceMoveTo: targetChildName})
+		_, err = edgenetclientset.CoreV1alpha1().SubNamespaces(g.tenantObj.GetName()).Update(context.TODO(), retried, metav1.UpdateOptions{})
+		util.OK(t, err)
+		time.Sleep(450 * time.Millisecond)
+
+		util.Equals(t, true, failed)
+		moved, err := edgenetclientset.CoreV1alpha1().SubNamespaces(targetChildName).Get(context.TODO(), retried.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		defer edgenetclientset.CoreV1alpha1().SubNamespaces(targetChildName).Delete(context.TODO(), moved.GetName(), metav1.DeleteOptions{})
+		_, elementExists := moved.GetAnnotations()[moveProgress]
+		util.Equals(t, false, elementExists)
+		childNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), retriedChildName, metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, targetChildName, metav1.GetControllerOf(childNamespace).Name)
+		coreResourceQuota, err = kubeclientset.CoreV1().ResourceQuotas(g.tenantObj.GetName()).Get(context.TODO(), "core-quota", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, coreQuotaCPU+1, coreResourceQuota.Spec.Hard.Cpu().Value())
+		targetResourceQuota, err = kubeclientset.CoreV1().ResourceQuotas(targetChildName).Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, targetQuotaCPU-1, targetResourceQuota.Spec.Hard.Cpu().Value())
+	})
+}
@@ -18,8 +18,11 @@ package tenant
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/EdgeNet-project/edgenet/pkg/access"
@@ -29,6 +32,7 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	antreav1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	antrea "antrea.io/antrea/pkg/client/clientset/versioned"
@@ -37,6 +41,8 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -63,19 +69,94 @@ const (
 	messageBindingFailed                    = "Role binding failed"
 	failureNetworkPolicy                    = "Not Applied"
 	messageNetworkPolicyFailed              = "Applying network policy failed"
+	failureLimitRange                       = "Not Applied"
+	messageLimitRangeFailed                 = "Applying default container resources failed"
+	failureWorkloadStop                     = "Not Stopped"
+	messageWorkloadStopFailed               = "Stopping workloads failed"
 	failureSubNamespaceDeletion             = "Not Removed"
 	messageSubNamespaceDeletionFailed       = "Subsidiary namespace clean up failed"
+	failureSliceRelease                     = "Not Released"
+	messageSliceReleaseFailed               = "Releasing slices failed"
+	failureNodeContributionDetachment       = "Not Detached"
+	messageNodeContributionDetachmentFailed = "Detaching node contributions failed"
 	failureClusterRoleDeletion              = "Not Removed"
 	messageClusterRoleDeletionFailed        = "Cluster role clean up failed"
-	failureClusterRoleBindingDeletion       = "Not Removed"
-	messageClusterRoleBindingDeletionFailed = "Cluster role binding clean up failed"
-	failureRoleBindingDeletion              = "Not Removed"
-	messageRoleBindingDeletionFailed        = "Role binding clean up failed"
+	failureExport                           = "Export Failed"
+	messageExportFailed                     = "Granting read-only access for the export failed"
+	successExport                           = "Exporting"
+	messageExport                           = "Tenant is being deleted, its resources can be exported with read-only access until the export window expires"
+	successCleanedUp                        = "Cleaned Up"
+	messageCleanedUp                        = "Tenant resources are cleaned up"
+	messageTerminating                      = "Tenant is being deleted"
 	failureRoleBindingCreation              = "Not Created"
 	messageRoleBindingCreationFailed        = "Role binding creation for tenant failed"
+	failureMemberRevocation                 = "Revocation Failed"
+	messageMemberRevocationFailed           = "Role revocation of removed member failed"
+	successOwnershipTransferred             = "Ownership Transferred"
+	successSuspended                        = "Suspended"
+	messageSuspended                        = "Tenant is suspended, its workloads are scaled to zero and non-owner access is revoked"
+	failureSuspension                       = "Suspension Failed"
+	messageSuspensionFailed                 = "Tenant suspension failed"
+	successResumed                          = "Resumed"
+	messageResumed                          = "Tenant workloads and access are restored"
+	failureResumption                       = "Resumption Failed"
+	messageResumptionFailed                 = "Restoring the suspended tenant failed"
+	messageOwnershipTransferred             = "Tenant owner roles are bound to the new owner"
 	failure                                 = "Failure"
 	pending                                 = "Pending"
 	established                             = "Established"
+	suspended                               = "Suspended"
+	terminating                             = "Terminating"
+)
+
+// Reasons of the status conditions of the tenant resource
+const (
+	reasonNamespaceCreated        = "NamespaceCreated"
+	reasonNamespaceCreationFailed = "NamespaceCreationFailed"
+	reasonNetworkPolicyApplied    = "NetworkPolicyApplied"
+	reasonNetworkPolicyFailed     = "NetworkPolicyFailed"
+	reasonRoleBound               = "RoleBound"
+	reasonBindingFailed           = "BindingFailed"
+	reasonEstablished             = "Established"
+	reasonPending                 = "Pending"
+	reasonSuspended               = "Suspended"
+	reasonSuspensionFailed        = "SuspensionFailed"
+	reasonResumed                 = "Resumed"
+	reasonResumptionFailed        = "ResumptionFailed"
+	reasonTerminating             = "Terminating"
+	reasonExporting               = "Exporting"
+	reasonExportFailed            = "ExportFailed"
+	reasonCleanupFailed           = "CleanupFailed"
+	reasonCleanedUp               = "CleanedUp"
+)
+
+// tenantFinalizer lets the controller clean up what the tenant leaves behind before the tenant is deleted
+const tenantFinalizer = "core.edgenet.io/tenant"
+
+// exportRoleBinding binds the built-in view role to the owner of a deleted tenant during the export window
+const exportRoleBinding = "edgenet:tenant-export"
+
+// suspensionQuota is the resource quota that denies new pods in the namespaces of a suspended tenant
+const suspensionQuota = "suspension-quota"
+
+// Annotations recording what the suspension changes, which lets the controller restore it when the tenant is enabled again.
+// The tenant itself is marked before its suspension begins, and the mark is cleared once it is resumed.
+const (
+	suspendedTenant    = "edge-net.io/suspended-tenant"
+	suspendedReplicas  = "edge-net.io/suspended-replicas"
+	suspendedCronJob   = "edge-net.io/suspended-cronjob"
+	suspendedDaemonSet = "edge-net.io/suspended-daemonset"
+	suspendedSubjects  = "edge-net.io/suspended-subjects"
+)
+
+// suspensionNodeSelector is the node selector that no node matches, which keeps the pods of daemon sets off the nodes while the tenant is suspended
+const suspensionNodeSelector = "edge-net.io/suspended"
+
+// Network profiles that determine the isolation level of tenant namespaces
+const (
+	restricted = "restricted"
+	baseline   = "baseline"
+	privileged = "privileged"
 )
 
 // The main structure of controller
@@ -231,7 +312,22 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
-	c.ProcessTenant(tenant.DeepCopy())
+	if tenant.GetDeletionTimestamp() != nil {
+		return c.finalizeTenant(tenant.DeepCopy())
+	}
+	if !hasFinalizer(tenant) {
+		// The tenant is processed once the update event of the finalizer arrives
+		tenantCopy := tenant.DeepCopy()
+		tenantCopy.SetFinalizers(append(tenantCopy.GetFinalizers(), tenantFinalizer))
+		_, err := c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		return err
+	}
+	tenantCopy := tenant.DeepCopy()
+	if err := c.removeMembers(tenantCopy); err != nil {
+		return err
+	}
+
+	c.ProcessTenant(tenantCopy)
 
 	c.recorder.Event(tenant, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
@@ -251,15 +347,16 @@ func (c *Controller) enqueueTenant(obj interface{}) {
 }
 
 func (c *Controller) ProcessTenant(tenantCopy *corev1alpha1.Tenant) {
-	oldStatus := tenantCopy.Status
+	oldStatus := tenantCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, tenantCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, tenantCopy.Status) {
 			if _, err := c.edgenetclientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{}); err != nil {
 				klog.Infoln(err)
 			}
 		}
 	}
 	defer statusUpdate()
+	tenantCopy.Status.ObservedGeneration = tenantCopy.GetGeneration()
 
 	systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
 	if err != nil {
@@ -268,6 +365,30 @@ func (c *Controller) ProcessTenant(tenantCopy *corev1alpha1.Tenant) {
 	}
 
 	if tenantCopy.Spec.Enabled {
+		// The contact person always belongs to the tenant as its owner
+		if !access.IsTenantMember(tenantCopy, tenantCopy.Spec.Contact.Email) {
+			tenantCopy.Status.Members = append(tenantCopy.Status.Members, corev1alpha1.Member{Email: tenantCopy.Spec.Contact.Email, Role: "edgenet:tenant-owner", Joined: metav1.Now()})
+		}
+		// Only a marked tenant is resumed. Resuming is idempotent, so it also restores what a suspension that failed halfway has changed,
+		// and the mark stays until resuming succeeds.
+		if _, marked := tenantCopy.GetAnnotations()[suspendedTenant]; marked {
+			err := c.resume(tenantCopy)
+			if err == nil {
+				err = c.markSuspended(tenantCopy, false)
+			}
+			if err != nil {
+				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureResumption, messageResumptionFailed)
+				tenantCopy.Status.State = failure
+				tenantCopy.Status.Message = messageResumptionFailed
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonResumptionFailed, err.Error())
+				klog.Infoln(err)
+				return
+			}
+			c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successResumed, messageResumed)
+			tenantCopy.Status.State = established
+			tenantCopy.Status.Message = messageResumed
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionSuspended, metav1.ConditionFalse, reasonResumed, messageResumed)
+		}
 		// When a tenant is deleted, the owner references feature drives the namespace to be automatically removed
 		ownerReferences := []metav1.OwnerReference{tenantCopy.MakeOwnerReference()}
 		// Create the cluster roles
@@ -278,15 +399,26 @@ func (c *Controller) ProcessTenant(tenantCopy *corev1alpha1.Tenant) {
 		}
 		err = c.createCoreNamespace(tenantCopy, ownerReferences, string(systemNamespace.GetUID()))
 		if err == nil || errors.IsAlreadyExists(err) {
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionNamespaceReady, metav1.ConditionTrue, reasonNamespaceCreated, "Core namespace is in place")
 			// Apply network policies
-			err = c.applyNetworkPolicy(tenantCopy.GetName(), string(tenantCopy.GetUID()), string(systemNamespace.GetUID()), tenantCopy.Spec.ClusterNetworkPolicy, ownerReferences)
-			if err != nil && !errors.IsAlreadyExists(err) {
+			err = c.applyNetworkPolicy(tenantCopy.GetName(), string(tenantCopy.GetUID()), string(systemNamespace.GetUID()), tenantCopy.Spec.NetworkProfile, tenantCopy.Spec.ClusterNetworkPolicy, ownerReferences)
+			if err != nil {
 				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureNetworkPolicy, messageNetworkPolicyFailed)
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionNetworkPolicyApplied, metav1.ConditionFalse, reasonNetworkPolicyFailed, err.Error())
+			} else {
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionNetworkPolicyApplied, metav1.ConditionTrue, reasonNetworkPolicyApplied, fmt.Sprintf("Network profile %s is applied", tenantCopy.Spec.NetworkProfile))
+			}
+			if err := access.ApplyTenantLimitRange(tenantCopy, tenantCopy.GetName()); err != nil {
+				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureLimitRange, messageLimitRangeFailed)
+				klog.Infoln(err)
 			}
 
 			// Cluster role binding
+			clusterRoleBound := true
 			if err := access.CreateObjectSpecificClusterRoleBinding(tenantOwnerClusterRole, tenantCopy.Spec.Contact.Email, map[string]string{"edge-net.io/generated": "true"}, []metav1.OwnerReference{}); err != nil {
 				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureRoleBindingCreation, messageRoleBindingCreationFailed)
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, messageRoleBindingCreationFailed)
+				clusterRoleBound = false
 			}
 			// Role binding
 			clusterRoleName := "edgenet:tenant-owner"
@@ -300,41 +432,811 @@ func (c *Controller) ProcessTenant(tenantCopy *corev1alpha1.Tenant) {
 				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 				tenantCopy.Status.State = failure
 				tenantCopy.Status.Message = messageBindingFailed
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, messageBindingFailed)
 				klog.Infoln(err)
 			} else if errors.IsAlreadyExists(err) {
-				if roleBinding, err := c.kubeclientset.RbacV1().RoleBindings(tenantCopy.GetName()).Get(context.TODO(), roleBind.GetName(), metav1.GetOptions{}); err == nil {
+				// The subjects are replaced as a whole so that a new contact person takes over from the previous one at once
+				roleBinding, err := c.kubeclientset.RbacV1().RoleBindings(tenantCopy.GetName()).Get(context.TODO(), roleBind.GetName(), metav1.GetOptions{})
+				if err == nil {
 					roleBindingCopy := roleBinding.DeepCopy()
 					roleBindingCopy.RoleRef = roleBind.RoleRef
 					roleBindingCopy.Subjects = roleBind.Subjects
 					roleBindingCopy.SetLabels(roleBind.GetLabels())
-					c.kubeclientset.RbacV1().RoleBindings(tenantCopy.GetName()).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{})
+					_, err = c.kubeclientset.RbacV1().RoleBindings(tenantCopy.GetName()).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{})
+				}
+				if err != nil {
+					c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
+					tenantCopy.Status.State = failure
+					tenantCopy.Status.Message = messageBindingFailed
+					util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, messageBindingFailed)
+					klog.Infoln(err)
+				} else if clusterRoleBound {
+					util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionRoleBound, metav1.ConditionTrue, reasonRoleBound, "Tenant owner roles are bound")
+					c.announceOwner(tenantCopy, string(systemNamespace.GetUID()))
 				}
 			} else {
 				c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successEstablished, messageEstablished)
 				tenantCopy.Status.State = established
 				tenantCopy.Status.Message = successEstablished
+				if clusterRoleBound {
+					util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionRoleBound, metav1.ConditionTrue, reasonRoleBound, "Tenant owner roles are bound")
+					c.announceOwner(tenantCopy, string(systemNamespace.GetUID()))
+				}
 			}
+		} else {
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionNamespaceReady, metav1.ConditionFalse, reasonNamespaceCreationFailed, err.Error())
 		}
+		setReadyCondition(tenantCopy)
 	} else {
-		// Delete all subsidiary namespaces
-		if namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s,edge-net.io/tenant-uid=%s,edge-net.io/cluster-uid=%s,edge-net.io/kind=sub", tenantCopy.GetName(), string(tenantCopy.GetUID()), string(systemNamespace.GetUID()))}); err == nil {
-			for _, namespaceRow := range namespaceRaw.Items {
-				c.kubeclientset.CoreV1().Namespaces().Delete(context.TODO(), namespaceRow.GetName(), metav1.DeleteOptions{})
+		// Disabling a tenant suspends it, which keeps its namespaces and data so that enabling it again restores everything
+		err := c.markSuspended(tenantCopy, true)
+		if err == nil {
+			err = c.suspend(tenantCopy, true)
+		}
+		if err != nil {
+			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureSuspension, messageSuspensionFailed)
+			tenantCopy.Status.State = failure
+			tenantCopy.Status.Message = messageSuspensionFailed
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionSuspended, metav1.ConditionFalse, reasonSuspensionFailed, err.Error())
+			klog.Infoln(err)
+		} else {
+			if tenantCopy.Status.State != suspended {
+				c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successSuspended, messageSuspended)
+			}
+			tenantCopy.Status.State = suspended
+			tenantCopy.Status.Message = messageSuspended
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionSuspended, metav1.ConditionTrue, reasonSuspended, messageSuspended)
+		}
+		util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonSuspended, messageSuspended)
+	}
+}
+
+// setReadyCondition derives the ready condition of the tenant from the conditions of the steps that make it up
+func setReadyCondition(tenantCopy *corev1alpha1.Tenant) {
+	for _, conditionType := range []string{corev1alpha1.ConditionNamespaceReady, corev1alpha1.ConditionNetworkPolicyApplied, corev1alpha1.ConditionRoleBound} {
+		condition := meta.FindStatusCondition(tenantCopy.Status.Conditions, conditionType)
+		if condition == nil {
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionUnknown, reasonPending, fmt.Sprintf("%s is not determined yet", conditionType))
+			return
+		}
+		if condition.Status != metav1.ConditionTrue {
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, condition.Reason, condition.Message)
+			return
+		}
+	}
+	util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionTrue, reasonEstablished, messageEstablished)
+}
+
+// removeMembers revokes the roles of the users listed in the member removal annotation, and of the previous owner
+// once the ownership is transferred, in all namespaces of the tenant and drops them from the members of the tenant.
+// The contact person always stays a member. The users whose roles cannot be revoked are kept in the annotation
+// and an error is returned so that the removal is retried.
+func (c *Controller) removeMembers(tenantCopy *corev1alpha1.Tenant) error {
+	removal, annotated := tenantCopy.GetAnnotations()[corev1alpha1.TenantMemberRemoval]
+	previousOwner := tenantCopy.Status.Owner
+	if strings.EqualFold(previousOwner, tenantCopy.Spec.Contact.Email) {
+		previousOwner = ""
+	}
+	if !annotated && previousOwner == "" {
+		return nil
+	}
+
+	removed := []string{}
+	revoke := func(email string) bool {
+		if strings.EqualFold(email, tenantCopy.Spec.Contact.Email) {
+			return true
+		}
+		if err := access.RevokeTenantMemberRoles(tenantCopy.GetName(), email); err != nil {
+			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureMemberRevocation, messageMemberRevocationFailed)
+			klog.Infoln(err)
+			return false
+		}
+		removed = append(removed, email)
+		return true
+	}
+	var err error
+	if previousOwner != "" && !revoke(previousOwner) {
+		err = fmt.Errorf("roles of the previous owner %s in tenant %s could not be revoked", previousOwner, tenantCopy.GetName())
+	}
+	remaining := []string{}
+	for _, email := range strings.Split(removal, ",") {
+		if email = strings.TrimSpace(email); email != "" && !revoke(email) {
+			remaining = append(remaining, email)
+		}
+	}
+
+	members := []corev1alpha1.Member{}
+	for _, member := range tenantCopy.Status.Members {
+		if !containsEmail(removed, member.Email) {
+			members = append(members, member)
+		}
+	}
+	if len(members) != len(tenantCopy.Status.Members) {
+		tenantCopy.Status.Members = members
+		tenantUpdated, err := c.edgenetclientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		tenantUpdated.DeepCopyInto(tenantCopy)
+	}
+	if annotated {
+		annotations := tenantCopy.GetAnnotations()
+		if len(remaining) == 0 {
+			delete(annotations, corev1alpha1.TenantMemberRemoval)
+		} else {
+			annotations[corev1alpha1.TenantMemberRemoval] = strings.Join(remaining, ",")
+		}
+		tenantCopy.SetAnnotations(annotations)
+		tenantUpdated, err := c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		tenantUpdated.DeepCopyInto(tenantCopy)
+		if len(remaining) != 0 {
+			return fmt.Errorf("roles of the members %s in tenant %s could not be revoked", strings.Join(remaining, ", "), tenantCopy.GetName())
+		}
+	}
+	return err
+}
+
+// containsEmail checks whether the email address is in the list, ignoring the case
+func containsEmail(emails []string, email string) bool {
+	for _, emailRow := range emails {
+		if strings.EqualFold(emailRow, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// suspend stops the workloads in all namespaces of the tenant and revokes the access of everyone but the tenant owner,
+// unless the owner is not retained either. What it changes is recorded in annotations so that resume can restore the tenant as it was.
+// Bare pods are left running as nothing could create them again once the tenant is resumed.
+func (c *Controller) suspend(tenantCopy *corev1alpha1.Tenant, retainOwner bool) error {
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		namespace := namespaceRow.GetName()
+		// The quota keeps the pods of jobs, daemon sets, and the like from starting while the tenant is suspended
+		resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: suspensionQuota, Labels: map[string]string{"edge-net.io/generated": "true"}},
+			Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}}}
+		if _, err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).Create(context.TODO(), resourceQuota, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+
+		deploymentRaw, err := c.kubeclientset.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, deploymentRow := range deploymentRaw.Items {
+			if deploymentRow.Spec.Replicas == nil || *deploymentRow.Spec.Replicas == 0 {
+				continue
+			}
+			deploymentCopy := deploymentRow.DeepCopy()
+			deploymentCopy.SetAnnotations(setAnnotation(deploymentCopy.GetAnnotations(), suspendedReplicas, strconv.Itoa(int(*deploymentCopy.Spec.Replicas))))
+			deploymentCopy.Spec.Replicas = new(int32)
+			if _, err := c.kubeclientset.AppsV1().Deployments(namespace).Update(context.TODO(), deploymentCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		statefulSetRaw, err := c.kubeclientset.AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, statefulSetRow := range statefulSetRaw.Items {
+			if statefulSetRow.Spec.Replicas == nil || *statefulSetRow.Spec.Replicas == 0 {
+				continue
+			}
+			statefulSetCopy := statefulSetRow.DeepCopy()
+			statefulSetCopy.SetAnnotations(setAnnotation(statefulSetCopy.GetAnnotations(), suspendedReplicas, strconv.Itoa(int(*statefulSetCopy.Spec.Replicas))))
+			statefulSetCopy.Spec.Replicas = new(int32)
+			if _, err := c.kubeclientset.AppsV1().StatefulSets(namespace).Update(context.TODO(), statefulSetCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		cronJobRaw, err := c.kubeclientset.BatchV1beta1().CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, cronJobRow := range cronJobRaw.Items {
+			if cronJobRow.Spec.Suspend != nil && *cronJobRow.Spec.Suspend {
+				continue
+			}
+			cronJobCopy := cronJobRow.DeepCopy()
+			cronJobCopy.SetAnnotations(setAnnotation(cronJobCopy.GetAnnotations(), suspendedCronJob, "true"))
+			suspend := true
+			cronJobCopy.Spec.Suspend = &suspend
+			if _, err := c.kubeclientset.BatchV1beta1().CronJobs(namespace).Update(context.TODO(), cronJobCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		daemonSetRaw, err := c.kubeclientset.AppsV1().DaemonSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, daemonSetRow := range daemonSetRaw.Items {
+			if _, suspended := daemonSetRow.GetAnnotations()[suspendedDaemonSet]; suspended {
+				continue
+			}
+			daemonSetCopy := daemonSetRow.DeepCopy()
+			daemonSetCopy.SetAnnotations(setAnnotation(daemonSetCopy.GetAnnotations(), suspendedDaemonSet, "true"))
+			daemonSetCopy.Spec.Template.Spec.NodeSelector = setAnnotation(daemonSetCopy.Spec.Template.Spec.NodeSelector, suspensionNodeSelector, "true")
+			if _, err := c.kubeclientset.AppsV1().DaemonSets(namespace).Update(context.TODO(), daemonSetCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		// The running pods of jobs are deleted, and the quota keeps the jobs from creating them again until the tenant is resumed
+		podRaw, err := c.kubeclientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, podRow := range podRaw.Items {
+			if podRow.Status.Phase == corev1.PodSucceeded || podRow.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			if owner := metav1.GetControllerOf(&podRow); owner == nil || owner.Kind != "Job" {
+				continue
+			}
+			if err := c.kubeclientset.CoreV1().Pods(namespace).Delete(context.TODO(), podRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		// Every subject but the owner is put aside, including the ones bound while the tenant is suspended
+		roleBindingRaw, err := c.kubeclientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, roleBindingRow := range roleBindingRaw.Items {
+			if roleBindingRow.GetName() == exportRoleBinding {
+				continue
+			}
+			roleBindingCopy := roleBindingRow.DeepCopy()
+			subjects, subjectsJSON, err := setAsideSubjects(roleBindingRow.Subjects, roleBindingRow.GetAnnotations(), tenantCopy.Spec.Contact.Email, retainOwner)
+			if err != nil {
+				return err
+			}
+			if len(subjects) == len(roleBindingRow.Subjects) {
+				continue
+			}
+			roleBindingCopy.Subjects = subjects
+			roleBindingCopy.SetAnnotations(setAnnotation(roleBindingCopy.GetAnnotations(), suspendedSubjects, subjectsJSON))
+			if _, err := c.kubeclientset.RbacV1().RoleBindings(namespace).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The cluster role bindings grant access to the tenant object and to the other cluster-scoped objects of the tenant
+	clusterRoleBindings, err := c.getClusterRoleBindings(tenantCopy)
+	if err != nil {
+		return err
+	}
+	for _, clusterRoleBindingRow := range clusterRoleBindings {
+		clusterRoleBindingCopy := clusterRoleBindingRow.DeepCopy()
+		subjects, subjectsJSON, err := setAsideSubjects(clusterRoleBindingRow.Subjects, clusterRoleBindingRow.GetAnnotations(), tenantCopy.Spec.Contact.Email, retainOwner)
+		if err != nil {
+			return err
+		}
+		if len(subjects) == len(clusterRoleBindingRow.Subjects) {
+			continue
+		}
+		clusterRoleBindingCopy.Subjects = subjects
+		clusterRoleBindingCopy.SetAnnotations(setAnnotation(clusterRoleBindingCopy.GetAnnotations(), suspendedSubjects, subjectsJSON))
+		if _, err := c.kubeclientset.RbacV1().ClusterRoleBindings().Update(context.TODO(), clusterRoleBindingCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resume undoes what suspend has done in the namespaces of the tenant, relying on the annotations it leaves
+func (c *Controller) resume(tenantCopy *corev1alpha1.Tenant) error {
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		namespace := namespaceRow.GetName()
+		if err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).Delete(context.TODO(), suspensionQuota, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		deploymentRaw, err := c.kubeclientset.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, deploymentRow := range deploymentRaw.Items {
+			replicas, suspended := getSuspendedReplicas(deploymentRow.GetAnnotations())
+			if !suspended {
+				continue
+			}
+			deploymentCopy := deploymentRow.DeepCopy()
+			deploymentCopy.Spec.Replicas = &replicas
+			delete(deploymentCopy.Annotations, suspendedReplicas)
+			if _, err := c.kubeclientset.AppsV1().Deployments(namespace).Update(context.TODO(), deploymentCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		statefulSetRaw, err := c.kubeclientset.AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, statefulSetRow := range statefulSetRaw.Items {
+			replicas, suspended := getSuspendedReplicas(statefulSetRow.GetAnnotations())
+			if !suspended {
+				continue
+			}
+			statefulSetCopy := statefulSetRow.DeepCopy()
+			statefulSetCopy.Spec.Replicas = &replicas
+			delete(statefulSetCopy.Annotations, suspendedReplicas)
+			if _, err := c.kubeclientset.AppsV1().StatefulSets(namespace).Update(context.TODO(), statefulSetCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		cronJobRaw, err := c.kubeclientset.BatchV1beta1().CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, cronJobRow := range cronJobRaw.Items {
+			if _, suspended := cronJobRow.GetAnnotations()[suspendedCronJob]; !suspended {
+				continue
+			}
+			cronJobCopy := cronJobRow.DeepCopy()
+			suspend := false
+			cronJobCopy.Spec.Suspend = &suspend
+			delete(cronJobCopy.Annotations, suspendedCronJob)
+			if _, err := c.kubeclientset.BatchV1beta1().CronJobs(namespace).Update(context.TODO(), cronJobCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+		daemonSetRaw, err := c.kubeclientset.AppsV1().DaemonSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, daemonSetRow := range daemonSetRaw.Items {
+			if _, suspended := daemonSetRow.GetAnnotations()[suspendedDaemonSet]; !suspended {
+				continue
+			}
+			daemonSetCopy := daemonSetRow.DeepCopy()
+			delete(daemonSetCopy.Spec.Template.Spec.NodeSelector, suspensionNodeSelector)
+			delete(daemonSetCopy.Annotations, suspendedDaemonSet)
+			if _, err := c.kubeclientset.AppsV1().DaemonSets(namespace).Update(context.TODO(), daemonSetCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+
+		roleBindingRaw, err := c.kubeclientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, roleBindingRow := range roleBindingRaw.Items {
+			if _, suspended := roleBindingRow.GetAnnotations()[suspendedSubjects]; !suspended {
+				continue
+			}
+			roleBindingCopy := roleBindingRow.DeepCopy()
+			roleBindingCopy.Subjects = restoreSubjects(roleBindingCopy.Subjects, roleBindingCopy.GetAnnotations())
+			delete(roleBindingCopy.Annotations, suspendedSubjects)
+			if _, err := c.kubeclientset.RbacV1().RoleBindings(namespace).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	clusterRoleBindings, err := c.getClusterRoleBindings(tenantCopy)
+	if err != nil {
+		return err
+	}
+	for _, clusterRoleBindingRow := range clusterRoleBindings {
+		if _, suspended := clusterRoleBindingRow.GetAnnotations()[suspendedSubjects]; !suspended {
+			continue
+		}
+		clusterRoleBindingCopy := clusterRoleBindingRow.DeepCopy()
+		clusterRoleBindingCopy.Subjects = restoreSubjects(clusterRoleBindingCopy.Subjects, clusterRoleBindingCopy.GetAnnotations())
+		delete(clusterRoleBindingCopy.Annotations, suspendedSubjects)
+		if _, err := c.kubeclientset.RbacV1().ClusterRoleBindings().Update(context.TODO(), clusterRoleBindingCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markSuspended adds the suspension mark to the tenant or clears it, taking over the metadata of the updated tenant
+func (c *Controller) markSuspended(tenantCopy *corev1alpha1.Tenant, mark bool) error {
+	if _, marked := tenantCopy.GetAnnotations()[suspendedTenant]; marked == mark {
+		return nil
+	}
+	tenantMarked := tenantCopy.DeepCopy()
+	if mark {
+		tenantMarked.SetAnnotations(setAnnotation(tenantMarked.GetAnnotations(), suspendedTenant, "true"))
+	} else {
+		delete(tenantMarked.Annotations, suspendedTenant)
+	}
+	tenantUpdated, err := c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenantMarked, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	// The status is still being worked on, so only the metadata is taken over
+	tenantUpdated.ObjectMeta.DeepCopyInto(&tenantCopy.ObjectMeta)
+	return nil
+}
+
+// getClusterRoleBindings returns the cluster role bindings of the tenant, which are the ones carrying the tenant label
+// and the ones sharing their names with the object-specific cluster roles of the tenant
+func (c *Controller) getClusterRoleBindings(tenantCopy *corev1alpha1.Tenant) ([]rbacv1.ClusterRoleBinding, error) {
+	labelSelector := fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())
+	clusterRoleBindingRaw, err := c.kubeclientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleBindings := clusterRoleBindingRaw.Items
+	listed := make(map[string]bool)
+	for _, clusterRoleBindingRow := range clusterRoleBindings {
+		listed[clusterRoleBindingRow.GetName()] = true
+	}
+	clusterRoleRaw, err := c.kubeclientset.RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	for _, clusterRoleRow := range clusterRoleRaw.Items {
+		if listed[clusterRoleRow.GetName()] {
+			continue
+		}
+		clusterRoleBinding, err := c.kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleRow.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		clusterRoleBindings = append(clusterRoleBindings, *clusterRoleBinding)
+		listed[clusterRoleBinding.GetName()] = true
+	}
+	return clusterRoleBindings, nil
+}
+
+// setAnnotation adds the key to the given annotations, or any other string map, creating the map if necessary
+func setAnnotation(annotations map[string]string, key, value string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[key] = value
+	return annotations
+}
+
+// getSuspendedReplicas returns the number of replicas a workload had before the suspension
+func getSuspendedReplicas(annotations map[string]string) (int32, bool) {
+	value, elementExists := annotations[suspendedReplicas]
+	if !elementExists {
+		return 0, false
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil {
+		klog.Infoln(err)
+		return 0, false
+	}
+	return int32(replicas), true
+}
+
+// getSuspendedSubjects returns the subjects put aside while suspending the tenant
+func getSuspendedSubjects(annotations map[string]string) []rbacv1.Subject {
+	subjects := []rbacv1.Subject{}
+	if value, elementExists := annotations[suspendedSubjects]; elementExists {
+		if err := json.Unmarshal([]byte(value), &subjects); err != nil {
+			klog.Infoln(err)
+		}
+	}
+	return subjects
+}
+
+// setAsideSubjects returns the subjects that remain bound during the suspension, which is only the owner if retained,
+// and the subjects put aside in JSON, including the ones put aside earlier
+func setAsideSubjects(subjects []rbacv1.Subject, annotations map[string]string, owner string, retainOwner bool) ([]rbacv1.Subject, string, error) {
+	remaining := []rbacv1.Subject{}
+	setAside := getSuspendedSubjects(annotations)
+	for _, subjectRow := range subjects {
+		if retainOwner && subjectRow.Kind == "User" && strings.EqualFold(subjectRow.Name, owner) {
+			remaining = append(remaining, subjectRow)
+		} else if !containsSubject(setAside, subjectRow) {
+			setAside = append(setAside, subjectRow)
+		}
+	}
+	setAsideJSON, err := json.Marshal(setAside)
+	if err != nil {
+		return nil, "", err
+	}
+	return remaining, string(setAsideJSON), nil
+}
+
+// restoreSubjects adds the subjects put aside while suspending the tenant back to the given subjects
+func restoreSubjects(subjects []rbacv1.Subject, annotations map[string]string) []rbacv1.Subject {
+	for _, subjectRow := range getSuspendedSubjects(annotations) {
+		if !containsSubject(subjects, subjectRow) {
+			subjects = append(subjects, subjectRow)
+		}
+	}
+	return subjects
+}
+
+// containsSubject checks whether the subject is in the list
+func containsSubject(subjects []rbacv1.Subject, subject rbacv1.Subject) bool {
+	for _, subjectRow := range subjects {
+		if subjectRow.Kind == subject.Kind && subjectRow.Name == subject.Name && subjectRow.Namespace == subject.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// finalizeTenant cleans up what the tenant leaves behind in order before letting the tenant go. If the tenant has an export
+// window, its namespaces are kept with read-only access for the owner until the window expires.
+func (c *Controller) finalizeTenant(tenantCopy *corev1alpha1.Tenant) error {
+	if !hasFinalizer(tenantCopy) {
+		return nil
+	}
+	oldStatus := tenantCopy.Status.DeepCopy()
+	statusUpdate := func() {
+		if !reflect.DeepEqual(*oldStatus, tenantCopy.Status) {
+			if _, err := c.edgenetclientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{}); err != nil {
+				klog.Infoln(err)
+			}
+		}
+	}
+	tenantCopy.Status.State = terminating
+	tenantCopy.Status.Message = messageTerminating
+	util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionReady, metav1.ConditionFalse, reasonTerminating, messageTerminating)
+
+	if tenantCopy.Spec.ExportWindow != nil {
+		if tenantCopy.Status.ExportExpiry == nil {
+			exportExpiry := metav1.NewTime(tenantCopy.GetDeletionTimestamp().Add(tenantCopy.Spec.ExportWindow.Duration))
+			tenantCopy.Status.ExportExpiry = &exportExpiry
+		}
+		if remaining := time.Until(tenantCopy.Status.ExportExpiry.Time); remaining > 0 {
+			defer statusUpdate()
+			if err := c.grantExportAccess(tenantCopy); err != nil {
+				c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureExport, messageExportFailed)
+				tenantCopy.Status.Message = messageExportFailed
+				util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionCleanedUp, metav1.ConditionFalse, reasonExportFailed, err.Error())
+				return err
+			}
+			if !meta.IsStatusConditionPresentAndEqual(tenantCopy.Status.Conditions, corev1alpha1.ConditionCleanedUp, metav1.ConditionFalse) {
+				c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successExport, messageExport)
+			}
+			tenantCopy.Status.Message = messageExport
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionCleanedUp, metav1.ConditionFalse, reasonExporting, messageExport)
+			c.workqueue.AddAfter(tenantCopy.GetName(), remaining)
+			return nil
+		}
+	}
+
+	// Each step relies on the previous one, the cleanup stops at the first step that fails and starts over at the next sync
+	cleanupSteps := []struct {
+		cleanup func(*corev1alpha1.Tenant) error
+		event   string
+		message string
+	}{
+		{c.stopWorkloads, failureWorkloadStop, messageWorkloadStopFailed},
+		{c.removeSubNamespaces, failureSubNamespaceDeletion, messageSubNamespaceDeletionFailed},
+		{c.releaseSlices, failureSliceRelease, messageSliceReleaseFailed},
+		{c.detachNodeContributions, failureNodeContributionDetachment, messageNodeContributionDetachmentFailed},
+		{c.removeClusterRoles, failureClusterRoleDeletion, messageClusterRoleDeletionFailed},
+	}
+	for _, step := range cleanupSteps {
+		if err := step.cleanup(tenantCopy); err != nil {
+			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, step.event, step.message)
+			tenantCopy.Status.Message = step.message
+			util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionCleanedUp, metav1.ConditionFalse, reasonCleanupFailed, err.Error())
+			statusUpdate()
+			return err
+		}
+	}
+	c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successCleanedUp, messageCleanedUp)
+	tenantCopy.Status.Message = messageCleanedUp
+	util.SetCondition(&tenantCopy.Status.Conditions, tenantCopy.GetGeneration(), corev1alpha1.ConditionCleanedUp, metav1.ConditionTrue, reasonCleanedUp, messageCleanedUp)
+	statusUpdate()
+
+	tenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenantCopy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	tenantCopy = tenant.DeepCopy()
+	finalizers := []string{}
+	for _, finalizer := range tenantCopy.GetFinalizers() {
+		if finalizer != tenantFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	tenantCopy.SetFinalizers(finalizers)
+	_, err = c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// grantExportAccess stops the workloads of the tenant, revokes the access of everyone including the owner,
+// and binds the built-in view role to the owner in all namespaces of the tenant
+func (c *Controller) grantExportAccess(tenantCopy *corev1alpha1.Tenant) error {
+	if err := c.suspend(tenantCopy, false); err != nil {
+		return err
+	}
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		roleRef := rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}
+		rbSubjects := []rbacv1.Subject{{Kind: "User", Name: tenantCopy.Spec.Contact.Email, APIGroup: "rbac.authorization.k8s.io"}}
+		roleBind := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: exportRoleBinding, Namespace: namespaceRow.GetName(), Labels: map[string]string{"edge-net.io/generated": "true"}},
+			Subjects: rbSubjects, RoleRef: roleRef}
+		if _, err := c.kubeclientset.RbacV1().RoleBindings(namespaceRow.GetName()).Create(context.TODO(), roleBind, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopWorkloads scales the workloads of the tenant to zero and revokes all access to its namespaces, including the one granted for the export
+func (c *Controller) stopWorkloads(tenantCopy *corev1alpha1.Tenant) error {
+	if err := c.suspend(tenantCopy, false); err != nil {
+		return err
+	}
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		if err := c.kubeclientset.RbacV1().RoleBindings(namespaceRow.GetName()).Delete(context.TODO(), exportRoleBinding, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeSubNamespaces deletes the subnamespaces of the tenant together with the subsidiary namespaces they create
+func (c *Controller) removeSubNamespaces(tenantCopy *corev1alpha1.Tenant) error {
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		subNamespaceRaw, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(namespaceRow.GetName()).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, subNamespaceRow := range subNamespaceRaw.Items {
+			if err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subNamespaceRow.GetNamespace()).Delete(context.TODO(), subNamespaceRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	for _, namespaceRow := range namespaceRaw.Items {
+		if namespaceRow.GetLabels()["edge-net.io/kind"] != "sub" {
+			continue
+		}
+		if err := c.kubeclientset.CoreV1().Namespaces().Delete(context.TODO(), namespaceRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseSlices deletes the slices claimed from the namespaces of the tenant so that the slice controller frees their nodes
+func (c *Controller) releaseSlices(tenantCopy *corev1alpha1.Tenant) error {
+	namespaceRaw, err := c.kubeclientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())})
+	if err != nil {
+		return err
+	}
+	namespaces := make(map[string]bool)
+	for _, namespaceRow := range namespaceRaw.Items {
+		namespaces[namespaceRow.GetName()] = true
+	}
+	sliceRaw, err := c.edgenetclientset.CoreV1alpha1().Slices().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, sliceRow := range sliceRaw.Items {
+		if sliceRow.Spec.ClaimRef == nil || !namespaces[sliceRow.Spec.ClaimRef.Namespace] {
+			continue
+		}
+		if err := c.edgenetclientset.CoreV1alpha1().Slices().Delete(context.TODO(), sliceRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// detachNodeContributions unties the nodes contributed by the tenant from it so that the nodes outlive the tenant.
+// The VPN peers of the contributed nodes that no longer exist are removed along the way.
+func (c *Controller) detachNodeContributions(tenantCopy *corev1alpha1.Tenant) error {
+	nodeContributionRaw, err := c.edgenetclientset.CoreV1alpha1().NodeContributions().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, nodeContributionRow := range nodeContributionRaw.Items {
+		if nodeContributionRow.Spec.Tenant == nil || *nodeContributionRow.Spec.Tenant != tenantCopy.GetName() {
+			continue
+		}
+		nodeName := fmt.Sprintf("%s.edge-net.io", nodeContributionRow.GetName())
+		if nodeObj, err := c.kubeclientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{}); err == nil {
+			ownerReferences := []metav1.OwnerReference{}
+			for _, ownerReference := range nodeObj.GetOwnerReferences() {
+				if ownerReference.UID != tenantCopy.GetUID() {
+					ownerReferences = append(ownerReferences, ownerReference)
+				}
+			}
+			if len(ownerReferences) != len(nodeObj.GetOwnerReferences()) {
+				nodeCopy := nodeObj.DeepCopy()
+				nodeCopy.SetOwnerReferences(ownerReferences)
+				if _, err := c.kubeclientset.CoreV1().Nodes().Update(context.TODO(), nodeCopy, metav1.UpdateOptions{}); err != nil {
+					return err
+				}
+			}
+		} else if errors.IsNotFound(err) {
+			if err := c.edgenetclientset.NetworkingV1alpha1().VPNPeers().Delete(context.TODO(), nodeName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
 			}
 		} else {
-			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureSubNamespaceDeletion, messageSubNamespaceDeletionFailed)
+			return err
 		}
-		// Delete all roles, role bindings, and subsidiary namespaces
-		if err := c.kubeclientset.RbacV1().ClusterRoles().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s,edge-net.io/tenant-uid=%s,edge-net.io/cluster-uid=%s", tenantCopy.GetName(), string(tenantCopy.GetUID()), string(systemNamespace.GetUID()))}); err != nil {
-			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureClusterRoleDeletion, messageClusterRoleDeletionFailed)
+		nodeContributionCopy := nodeContributionRow.DeepCopy()
+		nodeContributionCopy.Spec.Tenant = nil
+		if _, err := c.edgenetclientset.CoreV1alpha1().NodeContributions().Update(context.TODO(), nodeContributionCopy, metav1.UpdateOptions{}); err != nil {
+			return err
 		}
-		if err := c.kubeclientset.RbacV1().ClusterRoleBindings().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: fmt.Sprintf("edge-net.io/tenant=%s,edge-net.io/tenant-uid=%s,edge-net.io/cluster-uid=%s", tenantCopy.GetName(), string(tenantCopy.GetUID()), string(systemNamespace.GetUID()))}); err != nil {
-			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureClusterRoleBindingDeletion, messageClusterRoleBindingDeletionFailed)
+	}
+	return nil
+}
+
+// removeClusterRoles deletes the cluster roles generated for the tenant and the cluster role bindings of these roles
+func (c *Controller) removeClusterRoles(tenantCopy *corev1alpha1.Tenant) error {
+	labelSelector := fmt.Sprintf("edge-net.io/tenant=%s", tenantCopy.GetName())
+	clusterRoleBindingRaw, err := c.kubeclientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return err
+	}
+	for _, clusterRoleBindingRow := range clusterRoleBindingRaw.Items {
+		if err := c.kubeclientset.RbacV1().ClusterRoleBindings().Delete(context.TODO(), clusterRoleBindingRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	clusterRoleRaw, err := c.kubeclientset.RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return err
+	}
+	for _, clusterRoleRow := range clusterRoleRaw.Items {
+		// The bindings of object-specific cluster roles share their names, but they do not always carry the tenant label
+		if err := c.kubeclientset.RbacV1().ClusterRoleBindings().Delete(context.TODO(), clusterRoleRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
 		}
-		if err := c.kubeclientset.RbacV1().RoleBindings(tenantCopy.GetName()).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{}); err != nil {
-			c.recorder.Event(tenantCopy, corev1.EventTypeWarning, failureRoleBindingDeletion, messageRoleBindingDeletionFailed)
+		if err := c.kubeclientset.RbacV1().ClusterRoles().Delete(context.TODO(), clusterRoleRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
 		}
 	}
+	return nil
+}
+
+// hasFinalizer checks whether the tenant holds the finalizer of the controller
+func hasFinalizer(tenant *corev1alpha1.Tenant) bool {
+	for _, finalizer := range tenant.GetFinalizers() {
+		if finalizer == tenantFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// announceOwner records the owner whose roles are bound and notifies both the previous and the new owner
+// once the ownership of the tenant has changed hands
+func (c *Controller) announceOwner(tenantCopy *corev1alpha1.Tenant, clusterUID string) {
+	if strings.EqualFold(tenantCopy.Status.Owner, tenantCopy.Spec.Contact.Email) {
+		return
+	}
+	if tenantCopy.Status.Owner != "" {
+		c.recorder.Event(tenantCopy, corev1.EventTypeNormal, successOwnershipTransferred, messageOwnershipTransferred)
+		access.SendEmailForTenantOwnershipTransfer(tenantCopy, tenantCopy.Status.Owner, "tenant-ownership-transferred", "[EdgeNet] Tenant ownership transferred",
+			clusterUID, []string{tenantCopy.Status.Owner, tenantCopy.Spec.Contact.Email})
+	}
+	tenantCopy.Status.Owner = tenantCopy.Spec.Contact.Email
 }
 
 func (c *Controller) createCoreNamespace(tenantCopy *corev1alpha1.Tenant, ownerReferences []metav1.OwnerReference, clusterUID string) error {
@@ -343,11 +1245,13 @@ func (c *Controller) createCoreNamespace(tenantCopy *corev1alpha1.Tenant, ownerR
 	// Namespace labels indicate this namespace created by a tenant, not by a team or slice
 	labels := map[string]string{"edge-net.io/kind": "core", "edge-net.io/tenant": tenantCopy.GetName(),
 		"edge-net.io/tenant-uid": string(tenantCopy.GetUID()), "edge-net.io/cluster-uid": clusterUID}
-	coreNamespace.SetLabels(labels)
+	labels = tenantCopy.SetPodSecurityLabels(labels)
 	annotations := map[string]string{"scheduler.alpha.kubernetes.io/node-selector": "edge-net.io/access=public,edge-net.io/slice=none"}
 	if nodeSelector, elementExists := tenantCopy.GetAnnotations()["scheduler.alpha.kubernetes.io/node-selector"]; elementExists {
 		annotations["scheduler.alpha.kubernetes.io/node-selector"] = nodeSelector
 	}
+	labels, annotations = tenantCopy.SetNamespaceMetadata(labels, annotations)
+	coreNamespace.SetLabels(labels)
 	coreNamespace.SetAnnotations(annotations)
 	_, err := c.kubeclientset.CoreV1().Namespaces().Create(context.TODO(), coreNamespace, metav1.CreateOptions{})
 	if err != nil && !errors.IsAlreadyExists(err) {
@@ -367,13 +1271,17 @@ func (c *Controller) createCoreNamespace(tenantCopy *corev1alpha1.Tenant, ownerR
 	}
 }
 
-func (c *Controller) applyNetworkPolicy(tenant, tenantUID, clusterUID string, clusterNetworkPolicyEnabled bool, ownerReferences []metav1.OwnerReference) error {
-	// TODO: Apply a network policy to the core namespace according to spec
+func (c *Controller) applyNetworkPolicy(tenant, tenantUID, clusterUID, networkProfile string, clusterNetworkPolicyEnabled bool, ownerReferences []metav1.OwnerReference) error {
 	// Restricted only allows intra-tenant communication
 	// Baseline allows intra-tenant communication plus ingress from external traffic
 	// Privileged allows all kind of traffics
+	if networkProfile == "" {
+		networkProfile = baseline
+	}
+	if networkProfile != restricted && networkProfile != baseline && networkProfile != privileged {
+		return fmt.Errorf("unknown network profile: %s", networkProfile)
+	}
 
-	var err error
 	labelSelector := metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			"edge-net.io/subtenant":   "false",
@@ -384,97 +1292,175 @@ func (c *Controller) applyNetworkPolicy(tenant, tenantUID, clusterUID string, cl
 	}
 	port := intstr.IntOrString{IntVal: 1}
 	endPort := int32(32768)
+
+	// Tear down the network policies of the other profiles to switch between them
+	for _, profile := range []string{restricted, baseline, privileged} {
+		if profile == networkProfile {
+			continue
+		}
+		if err := c.kubeclientset.NetworkingV1().NetworkPolicies(tenant).Delete(context.TODO(), profile, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			klog.Infoln(err)
+			return err
+		}
+	}
+
 	networkPolicy := new(networkingv1.NetworkPolicy)
-	networkPolicy.SetName("baseline")
+	networkPolicy.SetName(networkProfile)
+	networkPolicy.SetLabels(map[string]string{"edge-net.io/generated": "true", "edge-net.io/network-profile": networkProfile})
 	networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{"Ingress"}
-	networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
-		{
-			From: []networkingv1.NetworkPolicyPeer{
-				{
-					NamespaceSelector: &labelSelector,
-				},
-				{
-					IPBlock: &networkingv1.IPBlock{
-						CIDR:   "0.0.0.0/0",
-						Except: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+	switch networkProfile {
+	case restricted:
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &labelSelector,
 					},
 				},
 			},
-			Ports: []networkingv1.NetworkPolicyPort{
-				{
-					Port:    &port,
-					EndPort: &endPort,
+		}
+	case baseline:
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &labelSelector,
+					},
+					{
+						IPBlock: &networkingv1.IPBlock{
+							CIDR:   "0.0.0.0/0",
+							Except: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+						},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Port:    &port,
+						EndPort: &endPort,
+					},
 				},
 			},
-		},
+		}
+	case privileged:
+		// An empty rule matches all sources and ports
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+	}
+	if _, err := c.kubeclientset.NetworkingV1().NetworkPolicies(tenant).Create(context.TODO(), networkPolicy, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			klog.Infoln(err)
+			return err
+		}
+		// Revert the changes made to the network policy out of the profile
+		if currentNetworkPolicy, err := c.kubeclientset.NetworkingV1().NetworkPolicies(tenant).Get(context.TODO(), networkPolicy.GetName(), metav1.GetOptions{}); err == nil {
+			if !reflect.DeepEqual(currentNetworkPolicy.Spec, networkPolicy.Spec) || !reflect.DeepEqual(currentNetworkPolicy.GetLabels(), networkPolicy.GetLabels()) {
+				networkPolicyCopy := currentNetworkPolicy.DeepCopy()
+				networkPolicyCopy.Spec = networkPolicy.Spec
+				networkPolicyCopy.SetLabels(networkPolicy.GetLabels())
+				if _, err := c.kubeclientset.NetworkingV1().NetworkPolicies(tenant).Update(context.TODO(), networkPolicyCopy, metav1.UpdateOptions{}); err != nil {
+					klog.Infoln(err)
+					return err
+				}
+			}
+		}
 	}
-	_, err = c.kubeclientset.NetworkingV1().NetworkPolicies(tenant).Create(context.TODO(), networkPolicy, metav1.CreateOptions{})
-	klog.Infoln(err)
+
 	if clusterNetworkPolicyEnabled {
 		drop := antreav1alpha1.RuleActionDrop
 		allow := antreav1alpha1.RuleActionAllow
 		clusterNetworkPolicy := new(antreav1alpha1.ClusterNetworkPolicy)
 		clusterNetworkPolicy.SetName(tenant)
 		clusterNetworkPolicy.SetOwnerReferences(ownerReferences)
+		clusterNetworkPolicy.SetLabels(map[string]string{"edge-net.io/generated": "true", "edge-net.io/network-profile": networkProfile})
 		clusterNetworkPolicy.Spec.Tier = "tenant"
 		clusterNetworkPolicy.Spec.Priority = 5
-		clusterNetworkPolicy.Spec.Ingress = []antreav1alpha1.Rule{
-			{
-				Action: &allow,
-				From: []antreav1alpha1.NetworkPolicyPeer{
-					{
-						NamespaceSelector: &labelSelector,
-					},
+		intraTenantRule := antreav1alpha1.Rule{
+			Action: &allow,
+			From: []antreav1alpha1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &labelSelector,
 				},
-				Ports: []antreav1alpha1.NetworkPolicyPort{
-					{
-						Port:    &port,
-						EndPort: &endPort,
-					},
+			},
+			Ports: []antreav1alpha1.NetworkPolicyPort{
+				{
+					Port:    &port,
+					EndPort: &endPort,
 				},
 			},
-			{
-				Action: &drop,
-				From: []antreav1alpha1.NetworkPolicyPeer{
-					{
-						IPBlock: &antreav1alpha1.IPBlock{
-							CIDR: "10.0.0.0/8",
+		}
+		switch networkProfile {
+		case restricted:
+			clusterNetworkPolicy.Spec.Ingress = []antreav1alpha1.Rule{
+				intraTenantRule,
+				{
+					Action: &drop,
+					From: []antreav1alpha1.NetworkPolicyPeer{
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "0.0.0.0/0",
+							},
 						},
 					},
-					{
-						IPBlock: &antreav1alpha1.IPBlock{
-							CIDR: "172.16.0.0/12",
+				},
+			}
+		case baseline:
+			clusterNetworkPolicy.Spec.Ingress = []antreav1alpha1.Rule{
+				intraTenantRule,
+				{
+					Action: &drop,
+					From: []antreav1alpha1.NetworkPolicyPeer{
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "10.0.0.0/8",
+							},
+						},
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "172.16.0.0/12",
+							},
+						},
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "192.168.0.0/16",
+							},
 						},
 					},
-					{
-						IPBlock: &antreav1alpha1.IPBlock{
-							CIDR: "192.168.0.0/16",
+					Ports: []antreav1alpha1.NetworkPolicyPort{
+						{
+							Port:    &port,
+							EndPort: &endPort,
 						},
 					},
 				},
-				Ports: []antreav1alpha1.NetworkPolicyPort{
-					{
-						Port:    &port,
-						EndPort: &endPort,
+				{
+					Action: &allow,
+					From: []antreav1alpha1.NetworkPolicyPeer{
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "0.0.0.0/0",
+							},
+						},
 					},
-				},
-			},
-			{
-				Action: &allow,
-				From: []antreav1alpha1.NetworkPolicyPeer{
-					{
-						IPBlock: &antreav1alpha1.IPBlock{
-							CIDR: "0.0.0.0/0",
+					Ports: []antreav1alpha1.NetworkPolicyPort{
+						{
+							Port:    &port,
+							EndPort: &endPort,
 						},
 					},
 				},
-				Ports: []antreav1alpha1.NetworkPolicyPort{
-					{
-						Port:    &port,
-						EndPort: &endPort,
+			}
+		case privileged:
+			clusterNetworkPolicy.Spec.Ingress = []antreav1alpha1.Rule{
+				{
+					Action: &allow,
+					From: []antreav1alpha1.NetworkPolicyPeer{
+						{
+							IPBlock: &antreav1alpha1.IPBlock{
+								CIDR: "0.0.0.0/0",
+							},
+						},
 					},
 				},
-			},
+			}
 		}
 		clusterNetworkPolicy.Spec.AppliedTo = []antreav1alpha1.NetworkPolicyPeer{
 			{
@@ -482,10 +1468,28 @@ func (c *Controller) applyNetworkPolicy(tenant, tenantUID, clusterUID string, cl
 			},
 		}
 
-		_, err = c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Create(context.TODO(), clusterNetworkPolicy, metav1.CreateOptions{})
-		klog.Infoln(err)
+		if _, err := c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Create(context.TODO(), clusterNetworkPolicy, metav1.CreateOptions{}); err != nil {
+			if !errors.IsAlreadyExists(err) {
+				klog.Infoln(err)
+				return err
+			}
+			if currentClusterNetworkPolicy, err := c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Get(context.TODO(), clusterNetworkPolicy.GetName(), metav1.GetOptions{}); err == nil {
+				if !reflect.DeepEqual(currentClusterNetworkPolicy.Spec, clusterNetworkPolicy.Spec) || !reflect.DeepEqual(currentClusterNetworkPolicy.GetLabels(), clusterNetworkPolicy.GetLabels()) {
+					clusterNetworkPolicyCopy := currentClusterNetworkPolicy.DeepCopy()
+					clusterNetworkPolicyCopy.Spec = clusterNetworkPolicy.Spec
+					clusterNetworkPolicyCopy.SetLabels(clusterNetworkPolicy.GetLabels())
+					if _, err := c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Update(context.TODO(), clusterNetworkPolicyCopy, metav1.UpdateOptions{}); err != nil {
+						klog.Infoln(err)
+						return err
+					}
+				}
+			}
+		}
 	} else {
-		c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Delete(context.TODO(), tenant, metav1.DeleteOptions{})
+		if err := c.antreaclientset.CrdV1alpha1().ClusterNetworkPolicies().Delete(context.TODO(), tenant, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			klog.Infoln(err)
+			return err
+		}
 	}
-	return err
+	return nil
 }
@@ -23,7 +23,13 @@ import (
 	antreaversioned "antrea.io/antrea/pkg/client/clientset/versioned"
 	antreatestclient "antrea.io/antrea/pkg/client/clientset/versioned/fake"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -166,3 +172,436 @@ func TestCreate(t *testing.T) {
 		util.OK(t, err)
 	})
 }
+
+func TestReadyCondition(t *testing.T) {
+	tenant := &corev1alpha.Tenant{}
+	setReadyCondition(tenant)
+	util.Equals(t, metav1.ConditionUnknown, meta.FindStatusCondition(tenant.Status.Conditions, corev1alpha.ConditionReady).Status)
+
+	util.SetCondition(&tenant.Status.Conditions, tenant.GetGeneration(), corev1alpha.ConditionNamespaceReady, metav1.ConditionTrue, reasonNamespaceCreated, "Core namespace is in place")
+	util.SetCondition(&tenant.Status.Conditions, tenant.GetGeneration(), corev1alpha.ConditionNetworkPolicyApplied, metav1.ConditionFalse, reasonNetworkPolicyFailed, "network policy failed")
+	util.SetCondition(&tenant.Status.Conditions, tenant.GetGeneration(), corev1alpha.ConditionRoleBound, metav1.ConditionTrue, reasonRoleBound, "Tenant owner roles are bound")
+	setReadyCondition(tenant)
+	ready := meta.FindStatusCondition(tenant.Status.Conditions, corev1alpha.ConditionReady)
+	util.Equals(t, metav1.ConditionFalse, ready.Status)
+	util.Equals(t, reasonNetworkPolicyFailed, ready.Reason)
+
+	util.SetCondition(&tenant.Status.Conditions, tenant.GetGeneration(), corev1alpha.ConditionNetworkPolicyApplied, metav1.ConditionTrue, reasonNetworkPolicyApplied, "Network profile is applied")
+	setReadyCondition(tenant)
+	util.Equals(t, metav1.ConditionTrue, meta.FindStatusCondition(tenant.Status.Conditions, corev1alpha.ConditionReady).Status)
+}
+
+func TestNetworkProfile(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("network-profile-test")
+	tenant.Spec.NetworkProfile = restricted
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	_, err := kubeclientset.NetworkingV1().NetworkPolicies(tenant.GetName()).Get(context.TODO(), restricted, metav1.GetOptions{})
+	util.OK(t, err)
+
+	tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	tenant.Spec.NetworkProfile = privileged
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	_, err = kubeclientset.NetworkingV1().NetworkPolicies(tenant.GetName()).Get(context.TODO(), privileged, metav1.GetOptions{})
+	util.OK(t, err)
+	_, err = kubeclientset.NetworkingV1().NetworkPolicies(tenant.GetName()).Get(context.TODO(), restricted, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+}
+
+func TestMembers(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("members-test")
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, true, access.IsTenantMember(tenant, tenant.Spec.Contact.Email))
+	util.Equals(t, 1, len(tenant.Status.Members))
+
+	// A member holding roles in the core and a subsidiary namespace of the tenant
+	subNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "members-test-sub", Labels: map[string]string{"edge-net.io/kind": "sub", "edge-net.io/tenant": tenant.GetName()}}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), subNamespace, metav1.CreateOptions{})
+	member := rbacv1.Subject{Kind: "User", Name: "jane.doe@edge-net.org", APIGroup: "rbac.authorization.k8s.io"}
+	other := rbacv1.Subject{Kind: "User", Name: "tom.public@edge-net.org", APIGroup: "rbac.authorization.k8s.io"}
+	roleRef := rbacv1.RoleRef{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"}
+	coreRoleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleRef.Name, Namespace: tenant.GetName(), Labels: map[string]string{"edge-net.io/generated": "true"}},
+		Subjects: []rbacv1.Subject{member}, RoleRef: roleRef}
+	kubeclientset.RbacV1().RoleBindings(coreRoleBinding.GetNamespace()).Create(context.TODO(), coreRoleBinding, metav1.CreateOptions{})
+	subRoleBinding := coreRoleBinding.DeepCopy()
+	subRoleBinding.SetNamespace(subNamespace.GetName())
+	subRoleBinding.Subjects = []rbacv1.Subject{member, other}
+	kubeclientset.RbacV1().RoleBindings(subRoleBinding.GetNamespace()).Create(context.TODO(), subRoleBinding, metav1.CreateOptions{})
+
+	util.OK(t, access.AddTenantMember(tenant.GetName(), member.Name, roleRef.Name))
+	time.Sleep(250 * time.Millisecond)
+	tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, 2, len(tenant.Status.Members))
+
+	t.Run("remove member", func(t *testing.T) {
+		tenant.SetAnnotations(map[string]string{corev1alpha.TenantMemberRemoval: member.Name})
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		_, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), roleRef.Name, metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		roleBinding, err := kubeclientset.RbacV1().RoleBindings(subNamespace.GetName()).Get(context.TODO(), roleRef.Name, metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []rbacv1.Subject{other}, roleBinding.Subjects)
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 1, len(tenant.Status.Members))
+		util.Equals(t, false, access.IsTenantMember(tenant, member.Name))
+		_, exists := tenant.GetAnnotations()[corev1alpha.TenantMemberRemoval]
+		util.Equals(t, false, exists)
+	})
+	t.Run("remove contact", func(t *testing.T) {
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		tenant.SetAnnotations(map[string]string{corev1alpha.TenantMemberRemoval: tenant.Spec.Contact.Email})
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, access.IsTenantMember(tenant, tenant.Spec.Contact.Email))
+		_, err = kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), "edgenet:tenant-owner", metav1.GetOptions{})
+		util.OK(t, err)
+	})
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("ownership-test")
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, tenant.Spec.Contact.Email, tenant.Status.Owner)
+	previousOwner := tenant.Spec.Contact.Email
+
+	// The ownership transfer request swaps the contact in a single update
+	newOwner := corev1alpha.Contact{Email: "jane.public@edge-net.org", FirstName: "Jane", LastName: "Public", Phone: "+33NUMBER"}
+	tenant.Spec.Contact = newOwner
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	subjects := []rbacv1.Subject{{Kind: "User", Name: newOwner.Email, APIGroup: "rbac.authorization.k8s.io"}}
+	roleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), "edgenet:tenant-owner", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, subjects, roleBinding.Subjects)
+	clusterRoleBinding, err := kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), fmt.Sprintf("edgenet:%s:tenants:%s-owner", tenant.GetName(), tenant.GetName()), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, subjects, clusterRoleBinding.Subjects)
+	tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, newOwner.Email, tenant.Status.Owner)
+	util.Equals(t, 1, len(tenant.Status.Members))
+	util.Equals(t, true, access.IsTenantMember(tenant, newOwner.Email))
+	util.Equals(t, false, access.IsTenantMember(tenant, previousOwner))
+}
+
+func TestSuspension(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("suspension-test")
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+
+	replicas := int32(3)
+	controller := true
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: tenant.GetName()}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	kubeclientset.AppsV1().Deployments(deployment.GetNamespace()).Create(context.TODO(), deployment, metav1.CreateOptions{})
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: tenant.GetName()}, Spec: appsv1.StatefulSetSpec{Replicas: &replicas}}
+	kubeclientset.AppsV1().StatefulSets(statefulSet.GetNamespace()).Create(context.TODO(), statefulSet, metav1.CreateOptions{})
+	cronJob := &batchv1beta1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: tenant.GetName()}}
+	kubeclientset.BatchV1beta1().CronJobs(cronJob.GetNamespace()).Create(context.TODO(), cronJob, metav1.CreateOptions{})
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: tenant.GetName()}}
+	kubeclientset.AppsV1().DaemonSets(daemonSet.GetNamespace()).Create(context.TODO(), daemonSet, metav1.CreateOptions{})
+	jobPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "batch-x7k2p", Namespace: tenant.GetName(),
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "Job", Name: "batch", UID: "batch", Controller: &controller}}}}
+	kubeclientset.CoreV1().Pods(jobPod.GetNamespace()).Create(context.TODO(), jobPod, metav1.CreateOptions{})
+	barePod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: tenant.GetName()}}
+	kubeclientset.CoreV1().Pods(barePod.GetNamespace()).Create(context.TODO(), barePod, metav1.CreateOptions{})
+	member := rbacv1.Subject{Kind: "User", Name: "jane.doe@edge-net.org", APIGroup: "rbac.authorization.k8s.io"}
+	roleRef := rbacv1.RoleRef{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleRef.Name, Namespace: tenant.GetName()}, Subjects: []rbacv1.Subject{member}, RoleRef: roleRef}
+	kubeclientset.RbacV1().RoleBindings(roleBinding.GetNamespace()).Create(context.TODO(), roleBinding, metav1.CreateOptions{})
+	owner := rbacv1.Subject{Kind: "User", Name: tenant.Spec.Contact.Email, APIGroup: "rbac.authorization.k8s.io"}
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "edgenet:suspension-test:slices:web", Labels: map[string]string{"edge-net.io/tenant": tenant.GetName()}},
+		Subjects: []rbacv1.Subject{owner, member}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "edgenet:suspension-test:slices:web"}}
+	kubeclientset.RbacV1().ClusterRoleBindings().Create(context.TODO(), clusterRoleBinding, metav1.CreateOptions{})
+
+	tenant.Spec.Enabled = false
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	t.Run("suspend", func(t *testing.T) {
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, suspended, tenant.Status.State)
+		_, err = kubeclientset.CoreV1().Namespaces().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		_, err = kubeclientset.CoreV1().ResourceQuotas(tenant.GetName()).Get(context.TODO(), suspensionQuota, metav1.GetOptions{})
+		util.OK(t, err)
+		deployment, err := kubeclientset.AppsV1().Deployments(tenant.GetName()).Get(context.TODO(), deployment.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, int32(0), *deployment.Spec.Replicas)
+		statefulSet, err := kubeclientset.AppsV1().StatefulSets(tenant.GetName()).Get(context.TODO(), statefulSet.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, int32(0), *statefulSet.Spec.Replicas)
+		cronJob, err := kubeclientset.BatchV1beta1().CronJobs(tenant.GetName()).Get(context.TODO(), cronJob.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, *cronJob.Spec.Suspend)
+		daemonSet, err := kubeclientset.AppsV1().DaemonSets(tenant.GetName()).Get(context.TODO(), daemonSet.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, map[string]string{suspensionNodeSelector: "true"}, daemonSet.Spec.Template.Spec.NodeSelector)
+		_, err = kubeclientset.CoreV1().Pods(tenant.GetName()).Get(context.TODO(), jobPod.GetName(), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		_, err = kubeclientset.CoreV1().Pods(tenant.GetName()).Get(context.TODO(), barePod.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		roleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), roleBinding.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 0, len(roleBinding.Subjects))
+		ownerRoleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), "edgenet:tenant-owner", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 1, len(ownerRoleBinding.Subjects))
+		clusterRoleBinding, err := kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleBinding.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []rbacv1.Subject{owner}, clusterRoleBinding.Subjects)
+		_, marked := tenant.GetAnnotations()[suspendedTenant]
+		util.Equals(t, true, marked)
+	})
+	t.Run("resume", func(t *testing.T) {
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		tenant.Spec.Enabled = true
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, established, tenant.Status.State)
+		_, err = kubeclientset.CoreV1().ResourceQuotas(tenant.GetName()).Get(context.TODO(), suspensionQuota, metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		deployment, err := kubeclientset.AppsV1().Deployments(tenant.GetName()).Get(context.TODO(), deployment.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, replicas, *deployment.Spec.Replicas)
+		statefulSet, err := kubeclientset.AppsV1().StatefulSets(tenant.GetName()).Get(context.TODO(), statefulSet.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, replicas, *statefulSet.Spec.Replicas)
+		cronJob, err := kubeclientset.BatchV1beta1().CronJobs(tenant.GetName()).Get(context.TODO(), cronJob.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, false, *cronJob.Spec.Suspend)
+		daemonSet, err := kubeclientset.AppsV1().DaemonSets(tenant.GetName()).Get(context.TODO(), daemonSet.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 0, len(daemonSet.Spec.Template.Spec.NodeSelector))
+		roleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), roleBinding.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []rbacv1.Subject{member}, roleBinding.Subjects)
+		clusterRoleBinding, err := kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleBinding.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []rbacv1.Subject{owner, member}, clusterRoleBinding.Subjects)
+		_, marked := tenant.GetAnnotations()[suspendedTenant]
+		util.Equals(t, false, marked)
+	})
+	t.Run("resume after failed suspension", func(t *testing.T) {
+		// A suspension that fails halfway leaves the quota, the scaled down workloads, and the mark behind
+		resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: suspensionQuota, Namespace: tenant.GetName()},
+			Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")}}}
+		kubeclientset.CoreV1().ResourceQuotas(resourceQuota.GetNamespace()).Create(context.TODO(), resourceQuota, metav1.CreateOptions{})
+		deployment, err := kubeclientset.AppsV1().Deployments(tenant.GetName()).Get(context.TODO(), deployment.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		deployment.SetAnnotations(map[string]string{suspendedReplicas: "3"})
+		deployment.Spec.Replicas = new(int32)
+		kubeclientset.AppsV1().Deployments(deployment.GetNamespace()).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		tenant.SetAnnotations(map[string]string{suspendedTenant: "true"})
+		tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		util.OK(t, err)
+		util.SetCondition(&tenant.Status.Conditions, tenant.GetGeneration(), corev1alpha.ConditionSuspended, metav1.ConditionFalse, reasonSuspensionFailed, "Suspension failed")
+		edgenetclientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+
+		_, err = kubeclientset.CoreV1().ResourceQuotas(tenant.GetName()).Get(context.TODO(), suspensionQuota, metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		deployment, err = kubeclientset.AppsV1().Deployments(tenant.GetName()).Get(context.TODO(), deployment.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, replicas, *deployment.Spec.Replicas)
+		tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, meta.IsStatusConditionPresentAndEqual(tenant.Status.Conditions, corev1alpha.ConditionSuspended, metav1.ConditionFalse))
+		util.Equals(t, reasonResumed, meta.FindStatusCondition(tenant.Status.Conditions, corev1alpha.ConditionSuspended).Reason)
+	})
+}
+
+func TestFinalizeTenant(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("finalizer-test")
+	tenant.SetUID("finalizer-test")
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, true, hasFinalizer(tenant))
+	clusterRoleName := fmt.Sprintf("edgenet:%s:tenants:%s-owner", tenant.GetName(), tenant.GetName())
+	_, err = kubeclientset.RbacV1().ClusterRoles().Get(context.TODO(), clusterRoleName, metav1.GetOptions{})
+	util.OK(t, err)
+
+	subNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "finalizer-test-sub", Labels: map[string]string{"edge-net.io/kind": "sub", "edge-net.io/tenant": tenant.GetName()}}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), subNamespace, metav1.CreateOptions{})
+	slice := &corev1alpha.Slice{ObjectMeta: metav1.ObjectMeta{Name: "finalizer-test"},
+		Spec: corev1alpha.SliceSpec{ClaimRef: &corev1.ObjectReference{Name: "finalizer-test", Namespace: subNamespace.GetName()}}}
+	edgenetclientset.CoreV1alpha1().Slices().Create(context.TODO(), slice, metav1.CreateOptions{})
+	tenantName := tenant.GetName()
+	nodeContribution := &corev1alpha.NodeContribution{ObjectMeta: metav1.ObjectMeta{Name: "finalizer-test"}, Spec: corev1alpha.NodeContributionSpec{Tenant: &tenantName}}
+	edgenetclientset.CoreV1alpha1().NodeContributions().Create(context.TODO(), nodeContribution, metav1.CreateOptions{})
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "finalizer-test.edge-net.io", OwnerReferences: []metav1.OwnerReference{tenant.MakeOwnerReference()}}}
+	kubeclientset.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+
+	// The fake clientset does not handle finalizers, so the deletion is imitated by setting the deletion timestamp
+	deletionTimestamp := metav1.Now()
+	tenant.SetDeletionTimestamp(&deletionTimestamp)
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	_, err = kubeclientset.CoreV1().Namespaces().Get(context.TODO(), subNamespace.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	_, err = edgenetclientset.CoreV1alpha1().Slices().Get(context.TODO(), slice.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	nodeContribution, err = edgenetclientset.CoreV1alpha1().NodeContributions().Get(context.TODO(), nodeContribution.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, true, nodeContribution.Spec.Tenant == nil)
+	node, err = kubeclientset.CoreV1().Nodes().Get(context.TODO(), node.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, 0, len(node.GetOwnerReferences()))
+	_, err = kubeclientset.RbacV1().ClusterRoles().Get(context.TODO(), clusterRoleName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	_, err = kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, false, hasFinalizer(tenant))
+}
+
+func TestExportWindow(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("export-test")
+	tenant.Spec.ExportWindow = &metav1.Duration{Duration: time.Hour}
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+
+	deletionTimestamp := metav1.Now()
+	tenant.SetDeletionTimestamp(&deletionTimestamp)
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	tenant, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, terminating, tenant.Status.State)
+	util.Equals(t, true, tenant.Status.ExportExpiry != nil)
+	util.Equals(t, true, hasFinalizer(tenant))
+	roleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), exportRoleBinding, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "view", roleBinding.RoleRef.Name)
+	ownerRoleBinding, err := kubeclientset.RbacV1().RoleBindings(tenant.GetName()).Get(context.TODO(), "edgenet:tenant-owner", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, 0, len(ownerRoleBinding.Subjects))
+}
+
+func TestTenantDefaults(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("defaults-test")
+	tenant.Spec.PodSecurity = restricted
+	tenant.Spec.LimitRange = &corev1alpha.ContainerLimitRange{
+		DefaultRequest: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("100m"), "memory": resource.MustParse("128Mi")},
+	}
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	coreNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, restricted, coreNamespace.GetLabels()["pod-security.kubernetes.io/enforce"])
+	limitRange, err := kubeclientset.CoreV1().LimitRanges(tenant.GetName()).Get(context.TODO(), corev1alpha.TenantLimitRange, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, tenant.MakeLimitRange(tenant.GetName()).Spec, limitRange.Spec)
+
+	t.Run("remove defaults", func(t *testing.T) {
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		tenant.Spec.PodSecurity = ""
+		tenant.Spec.LimitRange = nil
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		coreNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		_, labelExists := coreNamespace.GetLabels()["pod-security.kubernetes.io/enforce"]
+		util.Equals(t, false, labelExists)
+		_, err = kubeclientset.CoreV1().LimitRanges(tenant.GetName()).Get(context.TODO(), corev1alpha.TenantLimitRange, metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+}
+
+func TestNamespaceMetadata(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	tenant := g.tenantObj.DeepCopy()
+	tenant.SetName("metadata-test")
+	tenant.Spec.NamespaceMetadata = &corev1alpha.NamespaceMetadata{
+		Labels:      map[string]string{"team": "edge", "project": "testbed", "edge-net.io/kind": "sub"},
+		Annotations: map[string]string{"cost-center": "lip6"},
+	}
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), tenant, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	coreNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "edge", coreNamespace.GetLabels()["team"])
+	util.Equals(t, "testbed", coreNamespace.GetLabels()["project"])
+	util.Equals(t, "core", coreNamespace.GetLabels()["edge-net.io/kind"])
+	util.Equals(t, "lip6", coreNamespace.GetAnnotations()["cost-center"])
+
+	t.Run("remove metadata", func(t *testing.T) {
+		tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		tenant.Spec.NamespaceMetadata = &corev1alpha.NamespaceMetadata{Labels: map[string]string{"team": "edge"}}
+		edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+		time.Sleep(250 * time.Millisecond)
+		coreNamespace, err := kubeclientset.CoreV1().Namespaces().Get(context.TODO(), tenant.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "edge", coreNamespace.GetLabels()["team"])
+		_, labelExists := coreNamespace.GetLabels()["project"]
+		util.Equals(t, false, labelExists)
+		_, annotationExists := coreNamespace.GetAnnotations()["cost-center"]
+		util.Equals(t, false, annotationExists)
+	})
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +34,7 @@ import (
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/core/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/core/v1alpha1"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -64,6 +67,13 @@ const (
 	messageTuned            = "Core resource quota tuned"
 	successDeleted          = "Deleted"
 	messageDeleted          = "Subnamespace created latest deleted to balance resource consumption"
+	messageDeletedPriority  = "Subnamespace with the lowest eviction priority deleted to balance resource consumption"
+	successScaledDown       = "ScaledDown"
+	messageScaledDown       = "Subnamespace resource allocation scaled down proportionally to balance resource consumption"
+	warningFrozen           = "Frozen"
+	messageFrozen           = "New pods are denied since the assigned quota cannot compensate the subnamespaces"
+	successUnfrozen         = "Unfrozen"
+	messageUnfrozen         = "New pods are allowed again as the assigned quota compensates the subnamespaces"
 	successRemoved          = "Removed"
 	messageRemoved          = "Expired Claim / Drop removed smoothly"
 	warningNotRemoved       = "Not Removed"
@@ -75,6 +85,34 @@ const (
 	trueStr                 = "True"
 	falseStr                = "False"
 	unknownStr              = "Unknown"
+	warningInvalidPolicy    = "InvalidPolicy"
+	messageInvalidPolicy    = "Eviction policy is unknown, no subnamespace is evicted"
+)
+
+// Eviction policies applied when the assigned quota cannot compensate the subnamespaces
+const (
+	deleteNewest     = "delete-newest"
+	deleteByPriority = "delete-by-priority"
+	scaleDown        = "scale-down"
+	freeze           = "freeze"
+)
+
+// evictionPriority is the annotation that ranks subnamespaces for the delete-by-priority policy,
+// the ones with the lowest priority get deleted first.
+const evictionPriority = "edge-net.io/eviction-priority"
+
+// freezeQuota is the resource quota that denies new pods in a frozen namespace
+const freezeQuota = "freeze-quota"
+
+// suspensionQuota is the resource quota that the tenant controller creates to deny new pods while the tenant is suspended
+const suspensionQuota = "suspension-quota"
+
+// Reasons of the status conditions of the tenantresourcequota resource
+const (
+	reasonApplied       = "Applied"
+	reasonTuned         = "Tuned"
+	reasonQuotaShortage = "QuotaShortage"
+	reasonNotFound      = "NotFound"
 )
 
 // Controller is the controller implementation for Tenant Resource Quota resources
@@ -128,26 +166,11 @@ func NewController(
 
 	klog.V(4).Infoln("Setting up event handlers")
 	// Set up an event handler for when Tenant Resource Quota resources change
-	var getClosestExpiryDate = func(stale bool, objects ...map[string]corev1alpha1.ResourceTuning) (*metav1.Time, bool) {
-		var closestDate *metav1.Time
-		expiryDateExists := false
-		for _, obj := range objects {
-			for _, value := range obj {
-				if value.Expiry != nil && time.Until(value.Expiry.Time) > 0 {
-					if stale || !expiryDateExists || closestDate.Sub(value.Expiry.Time) >= 0 {
-						expiryDateExists = true
-						closestDate = value.Expiry
-					}
-				}
-			}
-		}
-		return closestDate, expiryDateExists
-	}
 	tenantresourcequotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			tenantResourceQuota := obj.(*corev1alpha1.TenantResourceQuota)
-			if expiryDate, exists := getClosestExpiryDate(false, tenantResourceQuota.Spec.Claim, tenantResourceQuota.Spec.Drop); exists {
-				controller.enqueueTenantResourceQuotaAfter(tenantResourceQuota, time.Until(expiryDate.Time))
+			if closestDate, exists := getClosestDate(false, tenantResourceQuota.Spec.Claim, tenantResourceQuota.Spec.Drop); exists {
+				controller.enqueueTenantResourceQuotaAfter(tenantResourceQuota, time.Until(closestDate.Time))
 			}
 			controller.enqueueTenantResourceQuota(obj)
 		},
@@ -159,10 +182,10 @@ func NewController(
 					return
 				}
 			}
-			if newExpiryDate, exists := getClosestExpiryDate(false, newTenantResourceQuota.Spec.Claim, newTenantResourceQuota.Spec.Drop); exists {
-				if previousExpiryDate, exists := getClosestExpiryDate(true, oldTenantResourceQuota.Spec.Claim, oldTenantResourceQuota.Spec.Drop); !exists ||
-					(exists && previousExpiryDate.Sub(newExpiryDate.Time) > 0) {
-					controller.enqueueTenantResourceQuotaAfter(newTenantResourceQuota, time.Until(newExpiryDate.Time))
+			if newClosestDate, exists := getClosestDate(false, newTenantResourceQuota.Spec.Claim, newTenantResourceQuota.Spec.Drop); exists {
+				if previousClosestDate, exists := getClosestDate(true, oldTenantResourceQuota.Spec.Claim, oldTenantResourceQuota.Spec.Drop); !exists ||
+					(exists && previousClosestDate.Sub(newClosestDate.Time) > 0) {
+					controller.enqueueTenantResourceQuotaAfter(newTenantResourceQuota, time.Until(newClosestDate.Time))
 				}
 			}
 			controller.enqueueTenantResourceQuota(new)
@@ -353,11 +376,34 @@ func (c *Controller) syncHandler(key string) error {
 	}
 
 	c.processTenantResourceQuota(tenantresourcequota.DeepCopy())
+	// Requeue the tenant resource quota to apply the claims and drops on time as they start or expire
+	if closestDate, exists := getClosestDate(false, tenantresourcequota.Spec.Claim, tenantresourcequota.Spec.Drop); exists {
+		c.enqueueTenantResourceQuotaAfter(tenantresourcequota, time.Until(closestDate.Time))
+	}
 
 	c.recorder.Event(tenantresourcequota, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
 }
 
+// getClosestDate returns the closest date in the future on which a claim or drop starts or expires
+func getClosestDate(stale bool, objects ...map[string]corev1alpha1.ResourceTuning) (*metav1.Time, bool) {
+	var closestDate *metav1.Time
+	dateExists := false
+	for _, obj := range objects {
+		for _, value := range obj {
+			for _, date := range []*metav1.Time{value.Start, value.Expiry} {
+				if date != nil && time.Until(date.Time) > 0 {
+					if stale || !dateExists || closestDate.Sub(date.Time) >= 0 {
+						dateExists = true
+						closestDate = date
+					}
+				}
+			}
+		}
+	}
+	return closestDate, dateExists
+}
+
 // enqueueTenantResourceQuota takes a TenantResourceQuota resource and converts it into a namespace/name
 // string which is then put onto the work queue. This method should *not* be
 // passed resources of any type other than TenantResourceQuota.
@@ -372,7 +418,7 @@ func (c *Controller) enqueueTenantResourceQuota(obj interface{}) {
 }
 
 // enqueueTenantResourceQuotaAfter takes a TenantResourceQuota resource and converts it into a namespace/name
-// string which is then put onto the work queue after the expiry date of a claim/drop to delete the so-said claim/drop.
+// string which is then put onto the work queue after the start or expiry date of a claim/drop to apply or delete the so-said claim/drop.
 // This method should *not* be passed resources of any type other than TenantResourceQuota.
 func (c *Controller) enqueueTenantResourceQuotaAfter(obj interface{}, after time.Duration) {
 	var key string
@@ -385,15 +431,16 @@ func (c *Controller) enqueueTenantResourceQuotaAfter(obj interface{}, after time
 }
 
 func (c *Controller) processTenantResourceQuota(tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota) {
-	oldStatus := tenantResourceQuotaCopy.Status
+	oldStatus := tenantResourceQuotaCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, tenantResourceQuotaCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, tenantResourceQuotaCopy.Status) {
 			if _, err := c.edgenetclientset.CoreV1alpha1().TenantResourceQuotas().UpdateStatus(context.TODO(), tenantResourceQuotaCopy, metav1.UpdateOptions{}); err != nil {
 				klog.V(4).Infoln(err)
 			}
 		}
 	}
 	defer statusUpdate()
+	tenantResourceQuotaCopy.Status.ObservedGeneration = tenantResourceQuotaCopy.GetGeneration()
 
 	permitted := false
 	systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
@@ -445,6 +492,7 @@ func (c *Controller) processTenantResourceQuota(tenantResourceQuotaCopy *corev1a
 				c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successApplied, messageApplied)
 				tenantResourceQuotaCopy.Status.State = success
 				tenantResourceQuotaCopy.Status.Message = messageApplied
+				util.SetCondition(&tenantResourceQuotaCopy.Status.Conditions, tenantResourceQuotaCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonApplied, messageApplied)
 			}
 		}
 
@@ -454,29 +502,34 @@ func (c *Controller) processTenantResourceQuota(tenantResourceQuotaCopy *corev1a
 
 func (c *Controller) tuneResourceQuotaAcrossNamespaces(coreNamespace, clusterUID string, tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota) {
 	c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successTraversalStarted, messageTraversalStarted)
-	aggregateQuota := make(map[corev1.ResourceName]resource.Quantity)
-	lastInSubNamespace := c.NamespaceTraversal(coreNamespace, clusterUID, aggregateQuota)
+	aggregation := newQuotaAggregation()
+	c.NamespaceTraversal(coreNamespace, clusterUID, aggregation)
+	aggregateQuota := aggregation.hard
 	assignedQuota := tenantResourceQuotaCopy.Fetch()
+	tenantResourceQuotaCopy.Status.Assigned = assignedQuota
+	tenantResourceQuotaCopy.Status.Allocated = aggregation.allocated
+	tenantResourceQuotaCopy.Status.Used = aggregation.used
+	tenantResourceQuotaCopy.Status.Namespaces = aggregation.namespaces
 	if resourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(coreNamespace).Get(context.TODO(), "core-quota", metav1.GetOptions{}); err == nil {
+		// The core namespace gets what remains of the assigned quota after the subsidiary namespaces take their share,
+		// which is negative for the resources in shortage
 		var coreResourceQuota = make(corev1.ResourceList)
 		canEntirelyCompansate := true
 		for assignedResource, assignedQuantity := range assignedQuota {
+			remainingQuantity := assignedQuantity.DeepCopy()
 			if aggregateQuantity, elementExists := aggregateQuota[assignedResource]; elementExists {
-				subnamespaceQuantity := aggregateQuantity
+				subnamespaceQuantity := aggregateQuantity.DeepCopy()
 				if coreQuantity, elementExists := resourceQuota.Spec.Hard[assignedResource]; elementExists {
 					subnamespaceQuantity.Sub(coreQuantity)
 				}
-				if assignedQuantity.Cmp(subnamespaceQuantity) == -1 {
+				if remainingQuantity.Cmp(subnamespaceQuantity) == -1 {
 					canEntirelyCompansate = false
-					break
-				} else {
-					assignedQuantity.Sub(subnamespaceQuantity)
-					coreResourceQuota[assignedResource] = assignedQuantity
 				}
-			} else {
-				coreResourceQuota[assignedResource] = assignedQuantity
+				remainingQuantity.Sub(subnamespaceQuantity)
 			}
+			coreResourceQuota[assignedResource] = remainingQuantity
 		}
+		tenantResourceQuotaCopy.Status.Remaining = coreResourceQuota
 		if canEntirelyCompansate {
 			if !reflect.DeepEqual(resourceQuota.Spec.Hard, coreResourceQuota) {
 				resourceQuotaCopy := resourceQuota.DeepCopy()
@@ -484,64 +537,311 @@ func (c *Controller) tuneResourceQuotaAcrossNamespaces(coreNamespace, clusterUID
 				c.kubeclientset.CoreV1().ResourceQuotas(coreNamespace).Update(context.TODO(), resourceQuotaCopy, metav1.UpdateOptions{})
 				c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successTuned, messageTuned)
 			}
+			c.unfreeze(tenantResourceQuotaCopy, aggregation)
+			util.SetCondition(&tenantResourceQuotaCopy.Status.Conditions, tenantResourceQuotaCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionTrue, reasonTuned, messageTuned)
 		} else {
-			if lastInSubNamespace != nil {
-				c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successDeleted, messageDeleted)
-				c.edgenetclientset.CoreV1alpha1().SubNamespaces(lastInSubNamespace.GetNamespace()).Delete(context.TODO(), lastInSubNamespace.GetName(), metav1.DeleteOptions{})
+			util.SetCondition(&tenantResourceQuotaCopy.Status.Conditions, tenantResourceQuotaCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionFalse, reasonQuotaShortage, "Assigned quota cannot compensate the subsidiary namespaces")
+			if c.evict(coreNamespace, clusterUID, tenantResourceQuotaCopy, aggregation) {
 				time.Sleep(200 * time.Millisecond)
 				defer c.tuneResourceQuotaAcrossNamespaces(coreNamespace, clusterUID, tenantResourceQuotaCopy)
 			}
-			// TODO: Fill in the else branch
 		}
 	} else {
 		c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeWarning, warningNotFound, messageNotFound)
 		tenantResourceQuotaCopy.Status.State = failure
 		tenantResourceQuotaCopy.Status.Message = messageNotFound
+		util.SetCondition(&tenantResourceQuotaCopy.Status.Conditions, tenantResourceQuotaCopy.GetGeneration(), corev1alpha1.ConditionQuotaApplied, metav1.ConditionFalse, reasonNotFound, messageNotFound)
+	}
+}
+
+// evict applies the eviction policy of the tenant resource quota when the assigned quota cannot compensate
+// the subnamespaces. It returns true if a subnamespace is deleted or scaled down so that the quota gets tuned once more.
+func (c *Controller) evict(coreNamespace, clusterUID string, tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota, aggregation *quotaAggregation) bool {
+	switch tenantResourceQuotaCopy.Spec.EvictionPolicy {
+	case deleteNewest, "":
+		// deleteNewest is the default policy
+		if subnamespace := aggregation.newest(); subnamespace != nil {
+			return c.deleteSubNamespace(tenantResourceQuotaCopy, subnamespace, messageDeleted)
+		}
+	case deleteByPriority:
+		if subnamespace := aggregation.lowestPriority(); subnamespace != nil {
+			return c.deleteSubNamespace(tenantResourceQuotaCopy, subnamespace, messageDeletedPriority)
+		}
+	case scaleDown:
+		return c.scaleDown(coreNamespace, tenantResourceQuotaCopy, aggregation)
+	case freeze:
+		c.freeze(clusterUID, tenantResourceQuotaCopy, aggregation)
+	default:
+		c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeWarning, warningInvalidPolicy, messageInvalidPolicy)
+		klog.Infof("Unknown eviction policy %s of %s", tenantResourceQuotaCopy.Spec.EvictionPolicy, tenantResourceQuotaCopy.GetName())
 	}
+	return false
 }
 
-func (c *Controller) NamespaceTraversal(coreNamespace, clusterUID string, aggregateQuota map[corev1.ResourceName]resource.Quantity) *corev1alpha1.SubNamespace {
-	var lastInDate metav1.Time
-	var lastInSubNamespace *corev1alpha1.SubNamespace
-	c.traverse(coreNamespace, coreNamespace, clusterUID, aggregateQuota, lastInSubNamespace, &lastInDate)
-	return lastInSubNamespace
+func (c *Controller) deleteSubNamespace(tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota, subnamespace *corev1alpha1.SubNamespace, message string) bool {
+	if err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespace.GetNamespace()).Delete(context.TODO(), subnamespace.GetName(), metav1.DeleteOptions{}); err != nil {
+		klog.Infoln(err)
+		return false
+	}
+	c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successDeleted, fmt.Sprintf("%s: %s/%s", message, subnamespace.GetNamespace(), subnamespace.GetName()))
+	c.recorder.Event(subnamespace, corev1.EventTypeWarning, successDeleted, message)
+	return true
+}
+
+// scaleDown shrinks the resource allocation of the subnamespaces in the core namespace in proportion to the shortage.
+// The whole hierarchy, the core namespace included, gets scaled by the same ratio so that the core namespace keeps its share.
+// Those located deeper in the hierarchy get tuned by the subnamespace controller as their parents shrink.
+// It returns true if an allocation changes.
+func (c *Controller) scaleDown(coreNamespace string, tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota, aggregation *quotaAggregation) bool {
+	assignedQuota := tenantResourceQuotaCopy.Fetch()
+	ratios := make(map[corev1.ResourceName]float64)
+	for key, hardQuantity := range aggregation.hard {
+		assignedQuantity, elementExists := assignedQuota[key]
+		if !elementExists || hardQuantity.Cmp(assignedQuantity) <= 0 {
+			continue
+		}
+		ratios[key] = 0
+		if assignedQuantity.Sign() > 0 {
+			ratios[key] = float64(assignedQuantity.MilliValue()) / float64(hardQuantity.MilliValue())
+		}
+	}
+	if len(ratios) == 0 {
+		return false
+	}
+
+	scaled := false
+	for _, subnamespace := range aggregation.subnamespaces {
+		if subnamespace.GetNamespace() != coreNamespace {
+			continue
+		}
+		// The ratio applies to the quotas in place rather than the allocation, so that scaling down again
+		// before the subnamespace controller catches up does not shrink the allocation any further
+		subtreeQuota := aggregation.subtreeHard(coreNamespace, subnamespace.GetName())
+		subnamespaceCopy := subnamespace.DeepCopy()
+		resourceAllocation := make(map[corev1.ResourceName]resource.Quantity)
+		changed := false
+		for key, value := range subnamespaceCopy.GetResourceAllocation() {
+			if ratio, elementExists := ratios[key]; elementExists {
+				if subtreeQuantity, elementExists := subtreeQuota[key]; elementExists {
+					if scaledQuantity := resource.NewMilliQuantity(int64(float64(subtreeQuantity.MilliValue())*ratio), value.Format); scaledQuantity.Cmp(value) == -1 {
+						value = *scaledQuantity
+						changed = true
+					}
+				}
+			}
+			resourceAllocation[key] = value
+		}
+		if !changed {
+			continue
+		}
+		subnamespaceCopy.SetResourceAllocation(resourceAllocation)
+		if _, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(subnamespaceCopy.GetNamespace()).Update(context.TODO(), subnamespaceCopy, metav1.UpdateOptions{}); err != nil {
+			klog.Infoln(err)
+			continue
+		}
+		scaled = true
+		c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successScaledDown, fmt.Sprintf("%s: %s/%s", messageScaledDown, subnamespaceCopy.GetNamespace(), subnamespaceCopy.GetName()))
+		c.recorder.Event(subnamespaceCopy, corev1.EventTypeWarning, successScaledDown, messageScaledDown)
+	}
+	return scaled
 }
 
-func (c *Controller) traverse(coreNamespace, namespace, clusterUID string, aggregateQuota map[corev1.ResourceName]resource.Quantity, lastInSubNamespace *corev1alpha1.SubNamespace, lastInDate *metav1.Time) {
+// freeze denies new pods throughout the namespace hierarchy, and notifies the tenant contact once.
+// The running workloads remain untouched.
+func (c *Controller) freeze(clusterUID string, tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota, aggregation *quotaAggregation) {
+	var frozenNamespaces []string
+	for _, namespaceUsage := range aggregation.namespaces {
+		resourceQuota := corev1.ResourceQuota{}
+		resourceQuota.Name = freezeQuota
+		resourceQuota.SetLabels(map[string]string{"edge-net.io/generated": "true"})
+		resourceQuota.Spec = corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("0"),
+			},
+		}
+		if _, err := c.kubeclientset.CoreV1().ResourceQuotas(namespaceUsage.Name).Create(context.TODO(), resourceQuota.DeepCopy(), metav1.CreateOptions{}); err == nil {
+			frozenNamespaces = append(frozenNamespaces, namespaceUsage.Name)
+		} else if !errors.IsAlreadyExists(err) {
+			klog.Infof("Couldn't freeze %s: %s", namespaceUsage.Name, err)
+		}
+	}
+	if len(frozenNamespaces) == 0 {
+		return
+	}
+
+	c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeWarning, warningFrozen, messageFrozen)
+	for _, subnamespace := range aggregation.subnamespaces {
+		c.recorder.Event(subnamespace.DeepCopy(), corev1.EventTypeWarning, warningFrozen, messageFrozen)
+	}
+	if tenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenantResourceQuotaCopy.GetName(), metav1.GetOptions{}); err == nil {
+		access.SendEmailForTenantResourceQuota(tenant, frozenNamespaces, "tenant-resource-quota-frozen", "[EdgeNet] Tenant quota exceeded", clusterUID, []string{tenant.Spec.Contact.Email})
+	}
+}
+
+// unfreeze lifts the restriction on new pods once the assigned quota compensates the subnamespaces again.
+func (c *Controller) unfreeze(tenantResourceQuotaCopy *corev1alpha1.TenantResourceQuota, aggregation *quotaAggregation) {
+	if len(aggregation.frozen) == 0 {
+		return
+	}
+	for _, namespace := range aggregation.frozen {
+		if err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).Delete(context.TODO(), freezeQuota, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			klog.Infof("Couldn't unfreeze %s: %s", namespace, err)
+		}
+	}
+	c.recorder.Event(tenantResourceQuotaCopy, corev1.EventTypeNormal, successUnfrozen, messageUnfrozen)
+	for _, subnamespace := range aggregation.subnamespaces {
+		c.recorder.Event(subnamespace.DeepCopy(), corev1.EventTypeNormal, successUnfrozen, messageUnfrozen)
+	}
+}
+
+// NamespaceTraversal walks through the namespace hierarchy of a tenant to collect the resource quotas and their consumption
+func (c *Controller) NamespaceTraversal(coreNamespace, clusterUID string, aggregation *quotaAggregation) {
+	c.traverse(coreNamespace, coreNamespace, "", "", clusterUID, aggregation)
+	sort.Slice(aggregation.namespaces, func(i, j int) bool {
+		return aggregation.namespaces[i].Name < aggregation.namespaces[j].Name
+	})
+}
+
+func (c *Controller) traverse(coreNamespace, namespace, subnamespace, parent, clusterUID string, aggregation *quotaAggregation) {
 	// This task becomes expensive when the hierarchy chain is gigantic with a substantial depth.
 	// So Goroutines come into play.
 	var wg sync.WaitGroup
-	c.accumulateQuota(namespace, aggregateQuota)
-	subNamespaceRaw, _ := c.edgenetclientset.CoreV1alpha1().SubNamespaces(namespace).List(context.TODO(), metav1.ListOptions{})
-	if len(subNamespaceRaw.Items) != 0 {
+	c.accumulateQuota(namespace, subnamespace, parent, aggregation)
+	subNamespaceRaw, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err == nil && len(subNamespaceRaw.Items) != 0 {
+		aggregation.mutex.Lock()
+		aggregation.subnamespaces = append(aggregation.subnamespaces, subNamespaceRaw.Items...)
+		aggregation.mutex.Unlock()
 		for _, subNamespaceRow := range subNamespaceRaw.Items {
 			wg.Add(1)
-			if lastInDate.IsZero() || lastInDate.Sub(subNamespaceRow.GetCreationTimestamp().Time) >= 0 {
-				lastInSubNamespace = subNamespaceRow.DeepCopy()
-				*lastInDate = subNamespaceRow.GetCreationTimestamp()
-			}
-			go func(childName string) {
+			go func(childName, subnamespace string) {
 				defer wg.Done()
-				c.traverse(coreNamespace, childName, clusterUID, aggregateQuota, lastInSubNamespace, lastInDate)
-			}(subNamespaceRow.GenerateChildName(clusterUID))
+				c.traverse(coreNamespace, childName, subnamespace, namespace, clusterUID, aggregation)
+			}(subNamespaceRow.GenerateChildName(clusterUID), subNamespaceRow.GetName())
 		}
 		wg.Wait()
 	}
 }
 
-// accumulateQuota adds each resource quota to the total to its aggregation.
-func (c *Controller) accumulateQuota(namespace string, aggregateQuota map[corev1.ResourceName]resource.Quantity) {
-	resourceQuotasRaw, _ := c.kubeclientset.CoreV1().ResourceQuotas(namespace).List(context.TODO(), metav1.ListOptions{})
-	if len(resourceQuotasRaw.Items) != 0 {
+// accumulateQuota adds the resource quotas in a namespace, and their consumption, to the aggregation.
+func (c *Controller) accumulateQuota(namespace, subnamespace, parent string, aggregation *quotaAggregation) {
+	namespaceUsage := corev1alpha1.NamespaceUsage{
+		Name:         namespace,
+		SubNamespace: subnamespace,
+		Parent:       parent,
+		Hard:         make(map[corev1.ResourceName]resource.Quantity),
+		Used:         make(map[corev1.ResourceName]resource.Quantity),
+	}
+	frozen := false
+	resourceQuotasRaw, err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err == nil && len(resourceQuotasRaw.Items) != 0 {
 		for _, resourceQuotasRow := range resourceQuotasRaw.Items {
-			for key, value := range resourceQuotasRow.Spec.Hard {
-				if aggregateQuantity, elementExists := aggregateQuota[key]; elementExists {
-					aggregateQuantity.Add(value)
-					aggregateQuota[key] = aggregateQuantity
-				} else {
-					aggregateQuota[key] = value
-				}
+			if resourceQuotasRow.GetName() == freezeQuota {
+				frozen = true
+				continue
+			}
+			if resourceQuotasRow.GetName() == suspensionQuota {
+				continue
 			}
+			addQuantities(namespaceUsage.Hard, resourceQuotasRow.Spec.Hard)
+			addQuantities(namespaceUsage.Used, resourceQuotasRow.Status.Used)
+		}
+	}
+
+	aggregation.mutex.Lock()
+	defer aggregation.mutex.Unlock()
+	addQuantities(aggregation.hard, namespaceUsage.Hard)
+	addQuantities(aggregation.used, namespaceUsage.Used)
+	if subnamespace != "" {
+		addQuantities(aggregation.allocated, namespaceUsage.Hard)
+	}
+	if frozen {
+		aggregation.frozen = append(aggregation.frozen, namespace)
+	}
+	aggregation.namespaces = append(aggregation.namespaces, namespaceUsage)
+}
+
+// quotaAggregation gathers the resource quotas and their consumption throughout the namespace hierarchy
+type quotaAggregation struct {
+	mutex      sync.Mutex
+	hard       map[corev1.ResourceName]resource.Quantity
+	allocated  map[corev1.ResourceName]resource.Quantity
+	used       map[corev1.ResourceName]resource.Quantity
+	namespaces []corev1alpha1.NamespaceUsage
+	// subnamespaces in the hierarchy, and the namespaces where new pods are denied
+	subnamespaces []corev1alpha1.SubNamespace
+	frozen        []string
+}
+
+func newQuotaAggregation() *quotaAggregation {
+	return &quotaAggregation{
+		hard:      make(map[corev1.ResourceName]resource.Quantity),
+		allocated: make(map[corev1.ResourceName]resource.Quantity),
+		used:      make(map[corev1.ResourceName]resource.Quantity),
+	}
+}
+
+// subtreeHard sums the hard quotas of the namespace that the subnamespace in the parent namespace generates, and of those under it.
+func (a *quotaAggregation) subtreeHard(parent, subnamespace string) map[corev1.ResourceName]resource.Quantity {
+	subtree := make(map[corev1.ResourceName]resource.Quantity)
+	parents := make(map[string]string)
+	root := ""
+	for _, namespaceUsage := range a.namespaces {
+		parents[namespaceUsage.Name] = namespaceUsage.Parent
+		if namespaceUsage.Parent == parent && namespaceUsage.SubNamespace == subnamespace {
+			root = namespaceUsage.Name
+		}
+	}
+	if root == "" {
+		return subtree
+	}
+	for _, namespaceUsage := range a.namespaces {
+		// The depth is bounded by the number of namespaces, which guards against a malformed hierarchy
+		for namespace, depth := namespaceUsage.Name, 0; namespace != "" && depth <= len(a.namespaces); namespace, depth = parents[namespace], depth+1 {
+			if namespace == root {
+				addQuantities(subtree, namespaceUsage.Hard)
+				break
+			}
+		}
+	}
+	return subtree
+}
+
+// newest returns the subnamespace created latest in the hierarchy.
+func (a *quotaAggregation) newest() *corev1alpha1.SubNamespace {
+	var newest *corev1alpha1.SubNamespace
+	for i, subnamespace := range a.subnamespaces {
+		if newest == nil || subnamespace.GetCreationTimestamp().After(newest.GetCreationTimestamp().Time) {
+			newest = &a.subnamespaces[i]
+		}
+	}
+	return newest
+}
+
+// lowestPriority returns the subnamespace with the lowest eviction priority in the hierarchy. Subnamespaces
+// without a valid priority annotation count as zero, and the one created latest goes first among equals.
+func (a *quotaAggregation) lowestPriority() *corev1alpha1.SubNamespace {
+	var lowest *corev1alpha1.SubNamespace
+	lowestPriority := 0
+	for i, subnamespace := range a.subnamespaces {
+		priority, _ := strconv.Atoi(subnamespace.GetAnnotations()[evictionPriority])
+		if lowest == nil || priority < lowestPriority ||
+			(priority == lowestPriority && subnamespace.GetCreationTimestamp().After(lowest.GetCreationTimestamp().Time)) {
+			lowest = &a.subnamespaces[i]
+			lowestPriority = priority
+		}
+	}
+	return lowest
+}
+
+// addQuantities adds each quantity to the total of its resource.
+func addQuantities(total, addition map[corev1.ResourceName]resource.Quantity) {
+	for key, value := range addition {
+		if totalQuantity, elementExists := total[key]; elementExists {
+			totalQuantity.Add(value)
+			total[key] = totalQuantity
+		} else {
+			total[key] = value.DeepCopy()
 		}
 	}
 }
@@ -19,12 +19,14 @@ import (
 	"github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
 )
 
@@ -243,6 +245,11 @@ func TestStartController(t *testing.T) {
 	tenantResourceQuota, err := edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), tenantResourceQuotaObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 	util.Equals(t, success, tenantResourceQuota.Status.State)
+	claimedCPU := g.claimObj.ResourceList[corev1.ResourceCPU]
+	assignedCPU := tenantResourceQuota.Status.Assigned[corev1.ResourceCPU]
+	util.Equals(t, claimedCPU.Value(), assignedCPU.Value())
+	util.Equals(t, 1, len(tenantResourceQuota.Status.Namespaces))
+	util.Equals(t, randomString, tenantResourceQuota.Status.Namespaces[0].Name)
 	// TODO: Problem here
 	// exp: "Applied"
 	// got: ""
@@ -477,6 +484,172 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestScheduledTuning(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	randomString := util.GenerateRandomString(6)
+	g.CreateTenant(randomString)
+	tenantResourceQuota := g.tenantResourceQuotaObj.DeepCopy()
+	tenantResourceQuota.SetName(randomString)
+	tenantResourceQuota.Spec.Claim = make(map[string]corev1alpha.ResourceTuning)
+	tenantResourceQuota.Spec.Claim["initial"] = g.claimObj
+	scheduledClaim := g.claimObj
+	scheduledClaim.Start = &metav1.Time{
+		Time: time.Now().Add(500 * time.Millisecond),
+	}
+	tenantResourceQuota.Spec.Claim["scheduled"] = scheduledClaim
+	edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Create(context.TODO(), tenantResourceQuota, metav1.CreateOptions{})
+	defer edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Delete(context.TODO(), tenantResourceQuota.GetName(), metav1.DeleteOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	claimedCPU := g.claimObj.ResourceList[corev1.ResourceCPU]
+	tenantResourceQuotaCopy, err := edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), tenantResourceQuota.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	assignedCPU := tenantResourceQuotaCopy.Fetch()[corev1.ResourceCPU]
+	util.Equals(t, claimedCPU.MilliValue(), assignedCPU.MilliValue())
+
+	time.Sleep(500 * time.Millisecond)
+	tenantResourceQuotaCopy, err = edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), tenantResourceQuota.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	assignedCPU = tenantResourceQuotaCopy.Fetch()[corev1.ResourceCPU]
+	util.Equals(t, 2*claimedCPU.MilliValue(), assignedCPU.MilliValue())
+	statusCPU := tenantResourceQuotaCopy.Status.Assigned[corev1.ResourceCPU]
+	util.Equals(t, 2*claimedCPU.MilliValue(), statusCPU.MilliValue())
+}
+
+func TestEvictionOrder(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+
+	aggregation := newQuotaAggregation()
+	for i, name := range []string{"oldest", "middle", "newest"} {
+		subnamespace := g.subNamespaceObj.DeepCopy()
+		subnamespace.SetName(name)
+		subnamespace.SetCreationTimestamp(metav1.NewTime(time.Now().Add(time.Duration(i) * time.Hour)))
+		aggregation.subnamespaces = append(aggregation.subnamespaces, *subnamespace)
+	}
+	util.Equals(t, "newest", aggregation.newest().GetName())
+	util.Equals(t, "newest", aggregation.lowestPriority().GetName())
+
+	aggregation.subnamespaces[0].SetAnnotations(map[string]string{evictionPriority: "-1"})
+	aggregation.subnamespaces[2].SetAnnotations(map[string]string{evictionPriority: "10"})
+	util.Equals(t, "oldest", aggregation.lowestPriority().GetName())
+	aggregation.subnamespaces[0].SetAnnotations(nil)
+	util.Equals(t, "middle", aggregation.lowestPriority().GetName())
+}
+
+// evictionHierarchy builds a controller on its own clientsets, with a core namespace that holds the subnamespaces 'first' and 'second',
+// and a nested subnamespace under 'first'. The cpu quota is 4 in the core namespace, 6 and 4 under the subnamespaces, and 2 in the nested one.
+func (g *TestGroup) evictionHierarchy(policy string, assignedCPU string) (*Controller, *corev1alpha.TenantResourceQuota, map[string]*corev1alpha.SubNamespace) {
+	kubeclient := testclient.NewSimpleClientset()
+	edgenetclient := edgenettestclient.NewSimpleClientset()
+	c := &Controller{kubeclientset: kubeclient, edgenetclientset: edgenetclient, recorder: record.NewFakeRecorder(100)}
+
+	createQuota := func(namespace, name, cpu string) {
+		resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}}
+		kubeclient.CoreV1().ResourceQuotas(namespace).Create(context.TODO(), resourceQuota, metav1.CreateOptions{})
+	}
+	createSubNamespace := func(namespace, name, cpu string) *corev1alpha.SubNamespace {
+		subnamespace := g.subNamespaceObj.DeepCopy()
+		subnamespace.SetName(name)
+		subnamespace.SetNamespace(namespace)
+		subnamespace.Spec.Workspace.ResourceAllocation = map[corev1.ResourceName]resource.Quantity{corev1.ResourceCPU: resource.MustParse(cpu)}
+		edgenetclient.CoreV1alpha1().SubNamespaces(namespace).Create(context.TODO(), subnamespace, metav1.CreateOptions{})
+		return subnamespace
+	}
+
+	createQuota("core", "core-quota", "4")
+	subnamespaces := make(map[string]*corev1alpha.SubNamespace)
+	subnamespaces["first"] = createSubNamespace("core", "first", "8")
+	createQuota(subnamespaces["first"].GenerateChildName(""), "sub-quota", "6")
+	subnamespaces["second"] = createSubNamespace("core", "second", "4")
+	createQuota(subnamespaces["second"].GenerateChildName(""), "sub-quota", "4")
+	subnamespaces["nested"] = createSubNamespace(subnamespaces["first"].GenerateChildName(""), "nested", "2")
+	createQuota(subnamespaces["nested"].GenerateChildName(""), "sub-quota", "2")
+
+	tenantResourceQuota := g.tenantResourceQuotaObj.DeepCopy()
+	tenantResourceQuota.SetName("core")
+	tenantResourceQuota.Spec.EvictionPolicy = policy
+	tenantResourceQuota.Spec.Claim = map[string]corev1alpha.ResourceTuning{"initial": {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(assignedCPU)}}}
+	return c, tenantResourceQuota, subnamespaces
+}
+
+func TestScaleDown(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	// The hierarchy holds 16 cpu in total while 8 are assigned, so the allocations get halved
+	c, tenantResourceQuota, subnamespaces := g.evictionHierarchy(scaleDown, "8")
+	c.tuneResourceQuotaAcrossNamespaces("core", "", tenantResourceQuota)
+
+	cases := map[string]struct {
+		subnamespace *corev1alpha.SubNamespace
+		expected     string
+	}{
+		"first":  {subnamespaces["first"], "4"},
+		"second": {subnamespaces["second"], "2"},
+		"nested": {subnamespaces["nested"], "2"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			subnamespace, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces(tc.subnamespace.GetNamespace()).Get(context.TODO(), tc.subnamespace.GetName(), metav1.GetOptions{})
+			util.OK(t, err)
+			allocatedCPU := subnamespace.Spec.Workspace.ResourceAllocation[corev1.ResourceCPU]
+			expectedCPU := resource.MustParse(tc.expected)
+			util.Equals(t, expectedCPU.MilliValue(), allocatedCPU.MilliValue())
+		})
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	c, tenantResourceQuota, subnamespaces := g.evictionHierarchy(freeze, "8")
+	namespaces := []string{"core", subnamespaces["first"].GenerateChildName(""), subnamespaces["second"].GenerateChildName(""), subnamespaces["nested"].GenerateChildName("")}
+	c.tuneResourceQuotaAcrossNamespaces("core", "", tenantResourceQuota)
+
+	for _, namespace := range namespaces {
+		resourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).Get(context.TODO(), freezeQuota, metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, int64(0), resourceQuota.Spec.Hard.Pods().Value())
+	}
+	// The subnamespaces keep their allocation as the running workloads remain untouched
+	subnamespace, err := c.edgenetclientset.CoreV1alpha1().SubNamespaces("core").Get(context.TODO(), "first", metav1.GetOptions{})
+	util.OK(t, err)
+	allocatedCPU := subnamespace.Spec.Workspace.ResourceAllocation[corev1.ResourceCPU]
+	util.Equals(t, int64(8), allocatedCPU.Value())
+
+	t.Run("unfreeze", func(t *testing.T) {
+		tenantResourceQuota.Spec.Claim["extra"] = corev1alpha.ResourceTuning{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}}
+		c.tuneResourceQuotaAcrossNamespaces("core", "", tenantResourceQuota)
+		for _, namespace := range namespaces {
+			_, err := c.kubeclientset.CoreV1().ResourceQuotas(namespace).Get(context.TODO(), freezeQuota, metav1.GetOptions{})
+			util.Equals(t, true, errors.IsNotFound(err))
+		}
+		coreResourceQuota, err := c.kubeclientset.CoreV1().ResourceQuotas("core").Get(context.TODO(), "core-quota", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, int64(4), coreResourceQuota.Spec.Hard.Cpu().Value())
+	})
+}
+
+func TestSuspensionQuota(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	c, _, _ := g.evictionHierarchy(freeze, "16")
+	resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: suspensionQuota, Namespace: "core"},
+		Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0"), corev1.ResourceCPU: resource.MustParse("0")}}}
+	_, err := c.kubeclientset.CoreV1().ResourceQuotas("core").Create(context.TODO(), resourceQuota, metav1.CreateOptions{})
+	util.OK(t, err)
+
+	aggregation := newQuotaAggregation()
+	c.NamespaceTraversal("core", "", aggregation)
+	_, elementExists := aggregation.hard[corev1.ResourcePods]
+	util.Equals(t, false, elementExists)
+	hardCPU := aggregation.hard[corev1.ResourceCPU]
+	util.Equals(t, int64(16), hardCPU.Value())
+	util.Equals(t, 0, len(aggregation.frozen))
+}
+
 func getQuotas(claimRaw map[string]corev1alpha.ResourceTuning) (int64, int64) {
 	var cpuQuota int64
 	var memoryQuota int64
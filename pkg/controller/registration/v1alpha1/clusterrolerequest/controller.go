@@ -29,11 +29,14 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/registration/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -60,11 +63,43 @@ const (
 	messageRoleApproved    = "Requested Role / Cluster Role approved successfully"
 	failureBinding         = "Binding Failed"
 	messageBindingFailed   = "Role binding failed"
+	warningDenied          = "Denied"
+	messageRoleDenied      = "Requested Role / Cluster Role denied"
+	warningExpired         = "Expired"
+	messageExpired         = "Request expired before being approved"
+	warningGrantExpired    = "Grant Expired"
+	messageGrantExpired    = "Cluster role grant expired and the cluster role is revoked"
+	warningWithdrawn       = "Withdrawn"
+	messageWithdrawn       = "Cluster role approval withdrawn and the cluster role is revoked"
+	failureRevocation      = "Revocation Failed"
+	messageRevokeFailed    = "Cluster role revocation failed"
 	failure                = "Failure"
 	pending                = "Pending"
 	approved               = "Approved"
+	expired                = "Expired"
+	denied                 = "Denied"
+	revoked                = "Revoked"
 )
 
+// Reasons of the status conditions of the clusterrolerequest resource
+const (
+	reasonPending       = "Pending"
+	reasonApproved      = "Approved"
+	reasonRoleNotFound  = "RoleNotFound"
+	reasonRoleBound     = "RoleBound"
+	reasonBindingFailed = "BindingFailed"
+	reasonDenied        = "Denied"
+	reasonExpired       = "Expired"
+	reasonGrantExpired  = "GrantExpired"
+	reasonWithdrawn     = "Withdrawn"
+)
+
+// clusterRoleBindingFinalizer lets the controller remove the user from the cluster role binding before the request is deleted
+const clusterRoleBindingFinalizer = "registration.edgenet.io/cluster-role-binding"
+
+// grantExpiryNotice is how long before the revocation of a time-bound cluster role the user gets notified
+const grantExpiryNotice = 24 * time.Hour
+
 // Controller is the controller implementation for Cluster Role Request resources
 type Controller struct {
 	// kubeclientset is a standard kubernetes clientset
@@ -75,6 +110,11 @@ type Controller struct {
 	clusterrolerequestsLister listers.ClusterRoleRequestLister
 	clusterrolerequestsSynced cache.InformerSynced
 
+	// approvalTimeout is the period within which the request needs to be approved before it expires
+	approvalTimeout time.Duration
+	// retention is the period for which expired and denied requests are kept as records before deletion
+	retention time.Duration
+
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
@@ -90,7 +130,9 @@ type Controller struct {
 func NewController(
 	kubeclientset kubernetes.Interface,
 	edgenetclientset clientset.Interface,
-	clusterrolerequestInformer informers.ClusterRoleRequestInformer) *Controller {
+	clusterrolerequestInformer informers.ClusterRoleRequestInformer,
+	approvalTimeout time.Duration,
+	retention time.Duration) *Controller {
 
 	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
 	klog.V(4).Info("Creating event broadcaster")
@@ -105,6 +147,8 @@ func NewController(
 		clusterrolerequestsLister: clusterrolerequestInformer.Lister(),
 		clusterrolerequestsSynced: clusterrolerequestInformer.Informer().HasSynced,
 		workqueue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ClusterRoleRequests"),
+		approvalTimeout:           approvalTimeout,
+		retention:                 retention,
 		recorder:                  recorder,
 	}
 
@@ -115,8 +159,8 @@ func NewController(
 		UpdateFunc: func(old, new interface{}) {
 			newClusterRoleRequest := new.(*registrationv1alpha1.ClusterRoleRequest)
 			oldClusterRoleRequest := old.(*registrationv1alpha1.ClusterRoleRequest)
-			if (oldClusterRoleRequest.Status.Expiry == nil && newClusterRoleRequest.Status.Expiry != nil) ||
-				!oldClusterRoleRequest.Status.Expiry.Time.Equal(newClusterRoleRequest.Status.Expiry.Time) {
+			if newClusterRoleRequest.Status.Expiry != nil && (oldClusterRoleRequest.Status.Expiry == nil ||
+				!oldClusterRoleRequest.Status.Expiry.Time.Equal(newClusterRoleRequest.Status.Expiry.Time)) {
 				controller.enqueueClusterRoleRequestAfter(newClusterRoleRequest, time.Until(newClusterRoleRequest.Status.Expiry.Time))
 			}
 			controller.enqueueClusterRoleRequest(new)
@@ -222,8 +266,23 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
+	if clusterrolerequest.GetDeletionTimestamp() != nil {
+		return c.finalizeClusterRoleRequest(clusterrolerequest.DeepCopy())
+	}
+	if !hasFinalizer(clusterrolerequest) {
+		// The request is processed once the update event of the finalizer arrives
+		clusterrolerequestCopy := clusterrolerequest.DeepCopy()
+		clusterrolerequestCopy.SetFinalizers(append(clusterrolerequestCopy.GetFinalizers(), clusterRoleBindingFinalizer))
+		_, err := c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Update(context.TODO(), clusterrolerequestCopy, metav1.UpdateOptions{})
+		return err
+	}
+
 	if clusterrolerequest.Status.State != approved {
 		c.processClusterRoleRequest(clusterrolerequest.DeepCopy())
+	} else if !clusterrolerequest.Spec.Approved || clusterrolerequest.Spec.Denied {
+		c.withdrawClusterRoleRequest(clusterrolerequest.DeepCopy())
+	} else if clusterrolerequest.Spec.Duration != nil || clusterrolerequest.Spec.ValidUntil != nil {
+		c.processClusterRoleGrant(clusterrolerequest.DeepCopy())
 	}
 	c.recorder.Event(clusterrolerequest, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
@@ -256,25 +315,42 @@ func (c *Controller) enqueueClusterRoleRequestAfter(obj interface{}, after time.
 }
 
 func (c *Controller) processClusterRoleRequest(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) {
-	oldStatus := clusterRoleRequestCopy.Status
+	oldStatus := clusterRoleRequestCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, clusterRoleRequestCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, clusterRoleRequestCopy.Status) {
 			if _, err := c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().UpdateStatus(context.TODO(), clusterRoleRequestCopy, metav1.UpdateOptions{}); err != nil {
 				klog.V(4).Infoln(err)
 			}
 		}
 	}
 	if clusterRoleRequestCopy.Status.Expiry == nil {
-		// Set the approval timeout which is 72 hours
+		// Set the approval timeout which is 72 hours by default
 		clusterRoleRequestCopy.Status.Expiry = &metav1.Time{
-			Time: time.Now().Add(72 * time.Hour),
+			Time: time.Now().Add(c.approvalTimeout),
+		}
+	} else if time.Until(clusterRoleRequestCopy.Status.Expiry.Time) <= 0 || clusterRoleRequestCopy.Status.State == revoked {
+		if retained := util.RetainExpiredRequest(getRetentionStart(clusterRoleRequestCopy), c.retention, clusterRoleRequestCopy.Status.State == expired || clusterRoleRequestCopy.Status.State == denied || clusterRoleRequestCopy.Status.State == revoked,
+			func() {
+				c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, warningExpired, messageExpired)
+				clusterRoleRequestCopy.Status.State = expired
+				clusterRoleRequestCopy.Status.Message = messageExpired
+				util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonExpired, messageExpired)
+				statusUpdate()
+			},
+			func() {
+				c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Delete(context.TODO(), clusterRoleRequestCopy.GetName(), metav1.DeleteOptions{})
+			}); retained > 0 {
+			c.enqueueClusterRoleRequestAfter(clusterRoleRequestCopy, retained)
 		}
-	} else if time.Until(clusterRoleRequestCopy.Status.Expiry.Time) <= 0 {
-		c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Delete(context.TODO(), clusterRoleRequestCopy.GetName(), metav1.DeleteOptions{})
 		return
 	}
 	defer statusUpdate()
+	clusterRoleRequestCopy.Status.ObservedGeneration = clusterRoleRequestCopy.GetGeneration()
 
+	if clusterRoleRequestCopy.Spec.Denied {
+		c.denyClusterRoleRequest(clusterRoleRequestCopy)
+		return
+	}
 	// Below is to ensure that the requested Role / ClusterRole exists before moving forward in the procedure.
 	// If not, the status of the object falls into an error state.
 	roleExists := c.checkForRequestedRole(clusterRoleRequestCopy)
@@ -289,10 +365,12 @@ func (c *Controller) processClusterRoleRequest(clusterRoleRequestCopy *registrat
 		c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, warningApproved, messageRoleNotApproved)
 		clusterRoleRequestCopy.Status.State = pending
 		clusterRoleRequestCopy.Status.Message = messageRoleNotApproved
+		util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonPending, messageRoleNotApproved)
 	} else {
 		c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeNormal, successApproved, messageRoleApproved)
 		clusterRoleRequestCopy.Status.State = approved
 		clusterRoleRequestCopy.Status.Message = messageRoleApproved
+		util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonApproved, messageRoleApproved)
 
 		// The following section handles cluster role binding. There are two basic logical steps here.
 		// Check if cluster role binding already exists; if not, create a cluster role binding for the user.
@@ -306,6 +384,7 @@ func (c *Controller) processClusterRoleRequest(clusterRoleRequestCopy *registrat
 					clusterRoleBindingExists = true
 					for _, subjectRow := range clusterRoleBindingRow.Subjects {
 						if subjectRow.Kind == "User" && subjectRow.Name == clusterRoleRequestCopy.Spec.Email {
+							clusterRoleBound = true
 							break
 						}
 					}
@@ -316,7 +395,10 @@ func (c *Controller) processClusterRoleRequest(clusterRoleRequestCopy *registrat
 							c.recorder.Event(clusterRoleBindingCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 							clusterRoleRequestCopy.Status.State = failure
 							clusterRoleRequestCopy.Status.Message = messageBindingFailed
+							util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, err.Error())
 							klog.V(4).Infoln(err)
+						} else {
+							clusterRoleBound = true
 						}
 						break
 					}
@@ -333,11 +415,170 @@ func (c *Controller) processClusterRoleRequest(clusterRoleRequestCopy *registrat
 					c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 					clusterRoleRequestCopy.Status.State = failure
 					clusterRoleRequestCopy.Status.Message = messageBindingFailed
+					util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, err.Error())
 					klog.V(4).Infoln(err)
+				} else {
+					clusterRoleBound = true
 				}
 			}
+			if clusterRoleBound {
+				util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionTrue, reasonRoleBound, "Requested role is bound to the user")
+			}
+		}
+	}
+}
+
+// processClusterRoleGrant keeps track of the time-bound cluster role grants. It notifies the user before the grant expires,
+// and removes the user from the cluster role binding once it expires.
+func (c *Controller) processClusterRoleGrant(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) {
+	oldStatus := clusterRoleRequestCopy.Status.DeepCopy()
+	defer func() {
+		if !reflect.DeepEqual(*oldStatus, clusterRoleRequestCopy.Status) {
+			if _, err := c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().UpdateStatus(context.TODO(), clusterRoleRequestCopy, metav1.UpdateOptions{}); err != nil {
+				klog.V(4).Infoln(err)
+			}
+		}
+	}()
+
+	// The grant expiry follows the spec so that the administrators can extend or shorten a grant afterward
+	grantExpiry := getGrantExpiry(clusterRoleRequestCopy)
+	if grantExpiry == nil {
+		return
+	}
+	if clusterRoleRequestCopy.Status.GrantExpiry == nil || !clusterRoleRequestCopy.Status.GrantExpiry.Equal(grantExpiry) {
+		clusterRoleRequestCopy.Status.GrantExpiry = grantExpiry
+		clusterRoleRequestCopy.Status.GrantExpiryNotified = false
+	}
+
+	if time.Until(grantExpiry.Time) <= 0 {
+		if err := c.revokeClusterRole(clusterRoleRequestCopy); err != nil {
+			c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+			klog.V(4).Infoln(err)
+			c.enqueueClusterRoleRequestAfter(clusterRoleRequestCopy, time.Minute)
+			return
+		}
+		c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, warningGrantExpired, messageGrantExpired)
+		clusterRoleRequestCopy.Status.State = revoked
+		clusterRoleRequestCopy.Status.Message = messageGrantExpired
+		util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonGrantExpired, messageGrantExpired)
+		return
+	}
+
+	if !clusterRoleRequestCopy.Status.GrantExpiryNotified && time.Until(grantExpiry.Time) <= grantExpiryNotice {
+		if systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{}); err == nil {
+			access.SendEmailForClusterRoleRequest(clusterRoleRequestCopy, "clusterrole-grant-expiring", "[EdgeNet] Cluster role grant about to expire", string(systemNamespace.GetUID()), []string{clusterRoleRequestCopy.Spec.Email})
+			clusterRoleRequestCopy.Status.GrantExpiryNotified = true
+		}
+	}
+	if clusterRoleRequestCopy.Status.GrantExpiryNotified {
+		c.enqueueClusterRoleRequestAfter(clusterRoleRequestCopy, time.Until(grantExpiry.Time))
+	} else {
+		c.enqueueClusterRoleRequestAfter(clusterRoleRequestCopy, time.Until(grantExpiry.Add(-grantExpiryNotice)))
+	}
+}
+
+// withdrawClusterRoleRequest revokes the cluster role of a request that gets unapproved or denied after its approval.
+// The request then waits for approval again, unless it is denied.
+func (c *Controller) withdrawClusterRoleRequest(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) {
+	if err := c.revokeClusterRole(clusterRoleRequestCopy); err != nil {
+		c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+		klog.V(4).Infoln(err)
+		c.enqueueClusterRoleRequestAfter(clusterRoleRequestCopy, time.Minute)
+		return
+	}
+	c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, warningWithdrawn, messageWithdrawn)
+	util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonWithdrawn, messageWithdrawn)
+	clusterRoleRequestCopy.Status.Expiry = nil
+	clusterRoleRequestCopy.Status.GrantExpiry = nil
+	clusterRoleRequestCopy.Status.GrantExpiryNotified = false
+	c.processClusterRoleRequest(clusterRoleRequestCopy)
+}
+
+// finalizeClusterRoleRequest revokes the cluster role bound by the request before letting the request go
+func (c *Controller) finalizeClusterRoleRequest(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) error {
+	if !hasFinalizer(clusterRoleRequestCopy) {
+		return nil
+	}
+	if meta.IsStatusConditionTrue(clusterRoleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionRoleBound) {
+		if err := c.revokeClusterRole(clusterRoleRequestCopy); err != nil {
+			c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+			return err
+		}
+	}
+	finalizers := []string{}
+	for _, finalizer := range clusterRoleRequestCopy.GetFinalizers() {
+		if finalizer != clusterRoleBindingFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	clusterRoleRequestCopy.SetFinalizers(finalizers)
+	_, err := c.edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Update(context.TODO(), clusterRoleRequestCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// revokeClusterRole removes the user from the subjects of the cluster role binding that holds the requested cluster role, and
+// deletes the cluster role binding when no subject remains. The user keeps the cluster role if another approved request grants the same.
+func (c *Controller) revokeClusterRole(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) error {
+	if clusterRoleRequestRaw, err := c.clusterrolerequestsLister.List(labels.Everything()); err == nil {
+		for _, clusterRoleRequestRow := range clusterRoleRequestRaw {
+			if clusterRoleRequestRow.GetName() != clusterRoleRequestCopy.GetName() && clusterRoleRequestRow.GetDeletionTimestamp() == nil &&
+				clusterRoleRequestRow.Status.State == approved && clusterRoleRequestRow.Spec.Email == clusterRoleRequestCopy.Spec.Email &&
+				clusterRoleRequestRow.Spec.RoleName == clusterRoleRequestCopy.Spec.RoleName {
+				return nil
+			}
+		}
+	}
+	clusterRoleBinding, err := c.kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleRequestCopy.Spec.RoleName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if clusterRoleBinding.RoleRef.Name != clusterRoleRequestCopy.Spec.RoleName {
+		return nil
+	}
+	clusterRoleBindingCopy := clusterRoleBinding.DeepCopy()
+	clusterRoleBindingCopy.Subjects = []rbacv1.Subject{}
+	for _, subjectRow := range clusterRoleBinding.Subjects {
+		if subjectRow.Kind == "User" && subjectRow.Name == clusterRoleRequestCopy.Spec.Email {
+			continue
+		}
+		clusterRoleBindingCopy.Subjects = append(clusterRoleBindingCopy.Subjects, subjectRow)
+	}
+	if len(clusterRoleBindingCopy.Subjects) == len(clusterRoleBinding.Subjects) {
+		return nil
+	}
+	if len(clusterRoleBindingCopy.Subjects) == 0 {
+		return c.kubeclientset.RbacV1().ClusterRoleBindings().Delete(context.TODO(), clusterRoleBindingCopy.GetName(), metav1.DeleteOptions{})
+	}
+	_, err = c.kubeclientset.RbacV1().ClusterRoleBindings().Update(context.TODO(), clusterRoleBindingCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// getRetentionStart returns the date from which the request is retained before its removal. A revoked request is retained
+// from its revocation on, as the approval timeout may be long gone by the time the grant expires.
+func getRetentionStart(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) *metav1.Time {
+	if clusterRoleRequestCopy.Status.State == revoked {
+		if roleBoundCondition := meta.FindStatusCondition(clusterRoleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionRoleBound); roleBoundCondition != nil && roleBoundCondition.LastTransitionTime.After(clusterRoleRequestCopy.Status.Expiry.Time) {
+			return roleBoundCondition.LastTransitionTime.DeepCopy()
 		}
 	}
+	return clusterRoleRequestCopy.Status.Expiry
+}
+
+// getGrantExpiry returns the date when the cluster role grant expires, which is nil if the grant is not time-bound.
+// The duration of the grant is counted from the approval of the request.
+func getGrantExpiry(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) *metav1.Time {
+	if clusterRoleRequestCopy.Spec.ValidUntil != nil {
+		return clusterRoleRequestCopy.Spec.ValidUntil.DeepCopy()
+	}
+	if clusterRoleRequestCopy.Spec.Duration != nil {
+		if approvedCondition := meta.FindStatusCondition(clusterRoleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionApproved); approvedCondition != nil {
+			return &metav1.Time{Time: approvedCondition.LastTransitionTime.Add(clusterRoleRequestCopy.Spec.Duration.Duration)}
+		}
+	}
+	return nil
 }
 
 func (c *Controller) checkForRequestedRole(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) bool {
@@ -353,5 +594,31 @@ func (c *Controller) checkForRequestedRole(clusterRoleRequestCopy *registrationv
 	c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, failureFound, messageRoleNotFound)
 	clusterRoleRequestCopy.Status.State = failure
 	clusterRoleRequestCopy.Status.Message = messageRoleNotFound
+	util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonRoleNotFound, messageRoleNotFound)
+	return false
+}
+
+// denyClusterRoleRequest puts the cluster role request into the denied state along with the reason given by the administrators
+func (c *Controller) denyClusterRoleRequest(clusterRoleRequestCopy *registrationv1alpha1.ClusterRoleRequest) {
+	message := messageRoleDenied
+	if clusterRoleRequestCopy.Spec.Reason != "" {
+		message = fmt.Sprintf("%s: %s", messageRoleDenied, clusterRoleRequestCopy.Spec.Reason)
+	}
+	if clusterRoleRequestCopy.Status.State == denied && clusterRoleRequestCopy.Status.Message == message {
+		return
+	}
+	c.recorder.Event(clusterRoleRequestCopy, corev1.EventTypeWarning, warningDenied, message)
+	clusterRoleRequestCopy.Status.State = denied
+	clusterRoleRequestCopy.Status.Message = message
+	util.SetCondition(&clusterRoleRequestCopy.Status.Conditions, clusterRoleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonDenied, message)
+}
+
+// hasFinalizer checks whether the cluster role request holds the finalizer of the controller
+func hasFinalizer(clusterRoleRequest *registrationv1alpha1.ClusterRoleRequest) bool {
+	for _, finalizer := range clusterRoleRequest.GetFinalizers() {
+		if finalizer == clusterRoleBindingFinalizer {
+			return true
+		}
+	}
 	return false
 }
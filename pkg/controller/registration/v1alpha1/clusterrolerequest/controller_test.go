@@ -47,7 +47,9 @@ func TestMain(m *testing.M) {
 
 	controller := NewController(kubeclientset,
 		edgenetclientset,
-		edgenetInformerFactory.Registration().V1alpha1().ClusterRoleRequests())
+		edgenetInformerFactory.Registration().V1alpha1().ClusterRoleRequests(),
+		72*time.Hour,
+		0)
 
 	edgenetInformerFactory.Start(stopCh)
 
@@ -147,3 +149,42 @@ func TestTimeout(t *testing.T) {
 		util.Equals(t, true, errors.IsNotFound(err))
 	})
 }
+
+func TestRevocation(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	roleRequestTest := g.roleRequestObj.DeepCopy()
+	roleRequestTest.SetName("role-request-revocation-test")
+	roleRequestTest.Spec.Email = "collaborator@edge-net.org"
+	roleRequestTest.Spec.Approved = true
+	edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, true, isBound(roleRequestTest))
+
+	// The fake clientset ignores finalizers, so the deletion is simulated by setting the deletion timestamp
+	roleRequest, err := edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, []string{clusterRoleBindingFinalizer}, roleRequest.GetFinalizers())
+	roleRequest.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
+	time.Sleep(time.Millisecond * 500)
+
+	roleRequest, err = edgenetclientset.RegistrationV1alpha1().ClusterRoleRequests().Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, 0, len(roleRequest.GetFinalizers()))
+	util.Equals(t, false, isBound(roleRequestTest))
+}
+
+// isBound checks whether the cluster role binding of the requested cluster role holds the user
+func isBound(roleRequest *registrationv1alpha1.ClusterRoleRequest) bool {
+	clusterRoleBinding, err := kubeclientset.RbacV1().ClusterRoleBindings().Get(context.TODO(), roleRequest.Spec.RoleName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	for _, subject := range clusterRoleBinding.Subjects {
+		if subject.Kind == "User" && subject.Name == roleRequest.Spec.Email {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,493 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownershiptransferrequest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/registration/v1alpha1"
+	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+)
+
+const controllerAgentName = "ownershiptransferrequest-controller"
+
+// Definitions of the state of the ownershiptransferrequest resource
+const (
+	successSynced             = "Synced"
+	messageResourceSynced     = "Ownership Transfer Request synced successfully"
+	warningNotAccepted        = "Not Accepted"
+	messageNotAccepted        = "Waiting for the new owner to accept the ownership"
+	successAccepted           = "Accepted"
+	messageAccepted           = "Ownership accepted by the new owner"
+	failureNotPermitted       = "Not Permitted"
+	messageNotPermitted       = "Ownership transfer is only possible in the core namespace of an enabled tenant"
+	failureSameOwner          = "Same Owner"
+	messageSameOwner          = "New owner already owns the tenant"
+	failureRoleCreation       = "Not Created"
+	messageRoleCreationFailed = "Role creation for the new owner failed"
+	successTransferred        = "Transferred"
+	messageTransferred        = "Tenant ownership transferred successfully"
+	failureTransfer           = "Transfer Failed"
+	messageTransferFailed     = "Tenant ownership cannot be transferred"
+	warningExpired            = "Expired"
+	messageExpired            = "Request expired before being accepted"
+	failure                   = "Failure"
+	pending                   = "Pending"
+	transferred               = "Transferred"
+	expired                   = "Expired"
+)
+
+// Reasons of the status conditions of the ownershiptransferrequest resource
+const (
+	reasonPending        = "Pending"
+	reasonAccepted       = "Accepted"
+	reasonNotPermitted   = "NotPermitted"
+	reasonSameOwner      = "SameOwner"
+	reasonTransferred    = "Transferred"
+	reasonTransferFailed = "TransferFailed"
+	reasonExpired        = "Expired"
+)
+
+// Controller is the controller implementation for Ownership Transfer Request resources
+type Controller struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	// edgenetclientset is a clientset for the EdgeNet API groups
+	edgenetclientset clientset.Interface
+
+	ownershiptransferrequestsLister listers.OwnershipTransferRequestLister
+	ownershiptransferrequestsSynced cache.InformerSynced
+
+	// acceptanceTimeout is the period within which the request needs to be accepted before it expires
+	acceptanceTimeout time.Duration
+	// retention is the period for which expired requests are kept as records before deletion
+	retention time.Duration
+
+	// workqueue is a rate limited work queue. This is used to queue work to be
+	// processed instead of performing it as soon as a change happens. This
+	// means we can ensure we only process a fixed amount of resources at a
+	// time, and makes it easy to ensure we are never processing the same item
+	// simultaneously in two different workers.
+	workqueue workqueue.RateLimitingInterface
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
+}
+
+// NewController returns a new controller
+func NewController(
+	kubeclientset kubernetes.Interface,
+	edgenetclientset clientset.Interface,
+	ownershiptransferrequestInformer informers.OwnershipTransferRequestInformer,
+	acceptanceTimeout time.Duration,
+	retention time.Duration) *Controller {
+
+	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
+	klog.V(4).Info("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
+	controller := &Controller{
+		kubeclientset:                   kubeclientset,
+		edgenetclientset:                edgenetclientset,
+		ownershiptransferrequestsLister: ownershiptransferrequestInformer.Lister(),
+		ownershiptransferrequestsSynced: ownershiptransferrequestInformer.Informer().HasSynced,
+		workqueue:                       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "OwnershipTransferRequests"),
+		acceptanceTimeout:               acceptanceTimeout,
+		retention:                       retention,
+		recorder:                        recorder,
+	}
+
+	klog.V(4).Infoln("Setting up event handlers")
+	// Set up an event handler for when Ownership Transfer Request resources change
+	ownershiptransferrequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueOwnershipTransferRequest,
+		UpdateFunc: func(old, new interface{}) {
+			newOwnershipTransferRequest := new.(*registrationv1alpha1.OwnershipTransferRequest)
+			oldOwnershipTransferRequest := old.(*registrationv1alpha1.OwnershipTransferRequest)
+			if newOwnershipTransferRequest.Status.Expiry != nil && (oldOwnershipTransferRequest.Status.Expiry == nil ||
+				!oldOwnershipTransferRequest.Status.Expiry.Time.Equal(newOwnershipTransferRequest.Status.Expiry.Time)) {
+				controller.enqueueOwnershipTransferRequestAfter(newOwnershipTransferRequest, time.Until(newOwnershipTransferRequest.Status.Expiry.Time))
+			}
+			controller.enqueueOwnershipTransferRequest(new)
+		},
+	})
+
+	access.Clientset = kubeclientset
+	access.EdgenetClientset = edgenetclientset
+
+	return controller
+}
+
+// Run will set up the event handlers for the types of ownership transfer request, as well
+// as syncing informer caches and starting workers. It will block until stopCh
+// is closed, at which point it will shutdown the workqueue and wait for
+// workers to finish processing their current work items.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.V(4).Infoln("Starting Ownership Transfer Request controller")
+
+	klog.V(4).Infoln("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh,
+		c.ownershiptransferrequestsSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.V(4).Infoln("Starting workers")
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.V(4).Infoln("Started workers")
+	<-stopCh
+	klog.V(4).Infoln("Shutting down workers")
+
+	return nil
+}
+
+// runWorker is a long-running function that will continually call the
+// processNextWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workqueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.workqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.syncHandler(key); err != nil {
+			c.workqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.workqueue.Forget(obj)
+		klog.V(4).Infof("Successfully synced '%s'", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// syncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Ownership Transfer Request
+// resource with the current status of the resource.
+func (c *Controller) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	ownershiptransferrequest, err := c.ownershiptransferrequestsLister.OwnershipTransferRequests(namespace).Get(name)
+
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("ownershiptransferrequest '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	if ownershiptransferrequest.Status.State != transferred {
+		c.processOwnershipTransferRequest(ownershiptransferrequest.DeepCopy())
+	}
+	c.recorder.Event(ownershiptransferrequest, corev1.EventTypeNormal, successSynced, messageResourceSynced)
+	return nil
+}
+
+// enqueueOwnershipTransferRequest takes an OwnershipTransferRequest resource and converts it into a namespace/name
+// string which is then put onto the work queue. This method should *not* be
+// passed resources of any type other than OwnershipTransferRequest.
+func (c *Controller) enqueueOwnershipTransferRequest(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// enqueueOwnershipTransferRequestAfter takes an OwnershipTransferRequest resource and converts it into a namespace/name
+// string which is then put onto the work queue after the expiry date to be deleted. This method should *not* be
+// passed resources of any type other than OwnershipTransferRequest.
+func (c *Controller) enqueueOwnershipTransferRequestAfter(obj interface{}, after time.Duration) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, after)
+}
+
+func (c *Controller) processOwnershipTransferRequest(ownershipTransferRequestCopy *registrationv1alpha1.OwnershipTransferRequest) {
+	oldStatus := ownershipTransferRequestCopy.Status.DeepCopy()
+	statusUpdate := func() {
+		if !reflect.DeepEqual(*oldStatus, ownershipTransferRequestCopy.Status) {
+			if _, err := c.edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequestCopy.GetNamespace()).UpdateStatus(context.TODO(), ownershipTransferRequestCopy, metav1.UpdateOptions{}); err != nil {
+				klog.V(4).Infoln(err)
+			}
+		}
+	}
+	if ownershipTransferRequestCopy.Status.Expiry == nil {
+		// Set the acceptance timeout which is 72 hours by default
+		ownershipTransferRequestCopy.Status.Expiry = &metav1.Time{
+			Time: time.Now().Add(c.acceptanceTimeout),
+		}
+	} else if time.Until(ownershipTransferRequestCopy.Status.Expiry.Time) <= 0 {
+		if retained := util.RetainExpiredRequest(ownershipTransferRequestCopy.Status.Expiry, c.retention, ownershipTransferRequestCopy.Status.State == expired,
+			func() {
+				c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, warningExpired, messageExpired)
+				ownershipTransferRequestCopy.Status.State = expired
+				ownershipTransferRequestCopy.Status.Message = messageExpired
+				util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionAccepted, metav1.ConditionFalse, reasonExpired, messageExpired)
+				c.removeTransfereeRole(ownershipTransferRequestCopy)
+				statusUpdate()
+			},
+			func() {
+				c.edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequestCopy.GetNamespace()).Delete(context.TODO(), ownershipTransferRequestCopy.GetName(), metav1.DeleteOptions{})
+				c.removeTransfereeRole(ownershipTransferRequestCopy)
+			}); retained > 0 {
+			c.enqueueOwnershipTransferRequestAfter(ownershipTransferRequestCopy, retained)
+		}
+		return
+	}
+	defer statusUpdate()
+	ownershipTransferRequestCopy.Status.ObservedGeneration = ownershipTransferRequestCopy.GetGeneration()
+
+	systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
+	if err != nil {
+		klog.V(4).Infoln(err)
+		return
+	}
+	// A tenant can only be handed over from its core namespace, which the local cluster owns, while the tenant is enabled
+	tenant, permitted := c.getTenant(ownershipTransferRequestCopy.GetNamespace(), string(systemNamespace.GetUID()))
+	if !permitted {
+		c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, failureNotPermitted, messageNotPermitted)
+		ownershipTransferRequestCopy.Status.State = failure
+		ownershipTransferRequestCopy.Status.Message = messageNotPermitted
+		util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionOwnershipTransferred, metav1.ConditionFalse, reasonNotPermitted, messageNotPermitted)
+		c.removeTransfereeRole(ownershipTransferRequestCopy)
+		return
+	}
+	newOwner := ownershipTransferRequestCopy.Spec.NewOwner
+	// The tenant may already belong to the new owner if the status could not be updated after the transfer
+	alreadyTransferred := strings.EqualFold(tenant.Spec.Contact.Email, newOwner.Email)
+	if alreadyTransferred && ownershipTransferRequestCopy.Status.PreviousOwner == "" {
+		c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, failureSameOwner, messageSameOwner)
+		ownershipTransferRequestCopy.Status.State = failure
+		ownershipTransferRequestCopy.Status.Message = messageSameOwner
+		util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionOwnershipTransferred, metav1.ConditionFalse, reasonSameOwner, messageSameOwner)
+		c.removeTransfereeRole(ownershipTransferRequestCopy)
+		return
+	}
+
+	if !ownershipTransferRequestCopy.Spec.Accepted {
+		// The new owner is only allowed to accept this very request
+		if err := c.applyTransfereeRole(ownershipTransferRequestCopy, tenant); err != nil {
+			c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, failureRoleCreation, messageRoleCreationFailed)
+			ownershipTransferRequestCopy.Status.State = failure
+			ownershipTransferRequestCopy.Status.Message = messageRoleCreationFailed
+			util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionAccepted, metav1.ConditionFalse, reasonPending, err.Error())
+			klog.V(4).Infoln(err)
+			return
+		}
+		if ownershipTransferRequestCopy.Status.State == pending && ownershipTransferRequestCopy.Status.Message == messageNotAccepted {
+			return
+		}
+		c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, warningNotAccepted, messageNotAccepted)
+		ownershipTransferRequestCopy.Status.State = pending
+		ownershipTransferRequestCopy.Status.Message = messageNotAccepted
+		util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionAccepted, metav1.ConditionFalse, reasonPending, messageNotAccepted)
+		access.SendEmailForOwnershipTransferRequest(ownershipTransferRequestCopy, tenant.Spec.Contact.Email, "ownership-transfer-request-made", "[EdgeNet] Tenant ownership transfer",
+			string(systemNamespace.GetUID()), []string{newOwner.Email})
+		return
+	}
+
+	c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeNormal, successAccepted, messageAccepted)
+	util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionAccepted, metav1.ConditionTrue, reasonAccepted, messageAccepted)
+
+	if !alreadyTransferred {
+		// The contact changes in a single update, so the tenant controller swaps the owner cluster role binding
+		// and role binding at once and revokes the roles of the previous owner
+		previousOwner := tenant.Spec.Contact.Email
+		tenantCopy := tenant.DeepCopy()
+		tenantCopy.Spec.Contact = newOwner
+		tenantUpdated, err := c.edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenantCopy, metav1.UpdateOptions{})
+		if err != nil {
+			c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeWarning, failureTransfer, messageTransferFailed)
+			ownershipTransferRequestCopy.Status.State = failure
+			ownershipTransferRequestCopy.Status.Message = messageTransferFailed
+			util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionOwnershipTransferred, metav1.ConditionFalse, reasonTransferFailed, err.Error())
+			klog.Infoln(err)
+			return
+		}
+		ownershipTransferRequestCopy.Status.PreviousOwner = previousOwner
+		// The new owner takes the place of the previous one in the members of the tenant
+		tenantCopy = tenantUpdated.DeepCopy()
+		tenantCopy.Status.Members = []corev1alpha1.Member{{Email: newOwner.Email, Role: "edgenet:tenant-owner", Joined: metav1.Now()}}
+		for _, member := range tenantUpdated.Status.Members {
+			if strings.EqualFold(member.Email, previousOwner) || strings.EqualFold(member.Email, newOwner.Email) {
+				continue
+			}
+			tenantCopy.Status.Members = append(tenantCopy.Status.Members, member)
+		}
+		if _, err := c.edgenetclientset.CoreV1alpha1().Tenants().UpdateStatus(context.TODO(), tenantCopy, metav1.UpdateOptions{}); err != nil {
+			klog.Infoln(err)
+		}
+	}
+
+	c.recorder.Event(ownershipTransferRequestCopy, corev1.EventTypeNormal, successTransferred, messageTransferred)
+	ownershipTransferRequestCopy.Status.State = transferred
+	ownershipTransferRequestCopy.Status.Message = messageTransferred
+	util.SetCondition(&ownershipTransferRequestCopy.Status.Conditions, ownershipTransferRequestCopy.GetGeneration(), registrationv1alpha1.ConditionOwnershipTransferred, metav1.ConditionTrue, reasonTransferred, messageTransferred)
+	c.removeTransfereeRole(ownershipTransferRequestCopy)
+}
+
+// getTenant returns the tenant whose core namespace holds the request and tells whether its ownership can be transferred
+func (c *Controller) getTenant(namespace, clusterUID string) (*corev1alpha1.Tenant, bool) {
+	namespaceObj, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	if err != nil {
+		klog.V(4).Infoln(err)
+		return nil, false
+	}
+	namespaceLabels := namespaceObj.GetLabels()
+	if namespaceLabels["edge-net.io/kind"] != "core" || namespaceLabels["edge-net.io/cluster-uid"] != clusterUID {
+		return nil, false
+	}
+	tenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), strings.ToLower(namespaceLabels["edge-net.io/tenant"]), metav1.GetOptions{})
+	if err != nil {
+		klog.V(4).Infoln(err)
+		return nil, false
+	}
+	if tenant.GetUID() != types.UID(namespaceLabels["edge-net.io/tenant-uid"]) || !tenant.Spec.Enabled {
+		return nil, false
+	}
+	return tenant, true
+}
+
+// applyTransfereeRole creates the role and binding that let the new owner accept this very request in the core namespace of the tenant
+func (c *Controller) applyTransfereeRole(ownershipTransferRequestCopy *registrationv1alpha1.OwnershipTransferRequest, tenant *corev1alpha1.Tenant) error {
+	roleName := GenerateTransfereeRoleName(ownershipTransferRequestCopy)
+	namespace := ownershipTransferRequestCopy.GetNamespace()
+	ownerReferences := []metav1.OwnerReference{tenant.MakeOwnerReference()}
+	roleLabels := map[string]string{"edge-net.io/tenant": tenant.GetName()}
+	policyRules := []rbacv1.PolicyRule{{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"ownershiptransferrequests"}, ResourceNames: []string{ownershipTransferRequestCopy.GetName()}, Verbs: []string{"get", "update", "patch"}},
+		{APIGroups: []string{"registration.edgenet.io"}, Resources: []string{"ownershiptransferrequests/status"}, ResourceNames: []string{ownershipTransferRequestCopy.GetName()}, Verbs: []string{"get", "list", "watch"}},
+	}
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: namespace, Labels: roleLabels, OwnerReferences: ownerReferences}, Rules: policyRules}
+	if _, err := c.kubeclientset.RbacV1().Roles(namespace).Create(context.TODO(), role, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		currentRole, err := c.kubeclientset.RbacV1().Roles(namespace).Get(context.TODO(), roleName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		currentRole.Rules = policyRules
+		if _, err := c.kubeclientset.RbacV1().Roles(namespace).Update(context.TODO(), currentRole, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	subjects := []rbacv1.Subject{{Kind: "User", Name: ownershipTransferRequestCopy.Spec.NewOwner.Email, APIGroup: "rbac.authorization.k8s.io"}}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: namespace, Labels: roleLabels, OwnerReferences: ownerReferences},
+		Subjects: subjects, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: roleName}}
+	if _, err := c.kubeclientset.RbacV1().RoleBindings(namespace).Create(context.TODO(), roleBinding, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		currentRoleBinding, err := c.kubeclientset.RbacV1().RoleBindings(namespace).Get(context.TODO(), roleName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		currentRoleBinding.Subjects = subjects
+		if _, err := c.kubeclientset.RbacV1().RoleBindings(namespace).Update(context.TODO(), currentRoleBinding, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeTransfereeRole deletes the role and binding that let the new owner accept the request
+func (c *Controller) removeTransfereeRole(ownershipTransferRequestCopy *registrationv1alpha1.OwnershipTransferRequest) {
+	roleName := GenerateTransfereeRoleName(ownershipTransferRequestCopy)
+	namespace := ownershipTransferRequestCopy.GetNamespace()
+	if err := c.kubeclientset.RbacV1().RoleBindings(namespace).Delete(context.TODO(), roleName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		klog.V(4).Infoln(err)
+	}
+	if err := c.kubeclientset.RbacV1().Roles(namespace).Delete(context.TODO(), roleName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		klog.V(4).Infoln(err)
+	}
+}
+
+// GenerateTransfereeRoleName returns the name of the role and binding that allow the new owner to accept the request
+func GenerateTransfereeRoleName(ownershipTransferRequestCopy *registrationv1alpha1.OwnershipTransferRequest) string {
+	return fmt.Sprintf("edgenet:ownershiptransferrequests:%s-transferee", ownershipTransferRequestCopy.GetName())
+}
@@ -0,0 +1,222 @@
+package ownershiptransferrequest
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+	"github.com/EdgeNet-project/edgenet/pkg/signals"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog"
+)
+
+type TestGroup struct {
+	tenantObj                   corev1alpha.Tenant
+	ownershipTransferRequestObj registrationv1alpha1.OwnershipTransferRequest
+}
+
+var kubeclientset kubernetes.Interface = testclient.NewSimpleClientset()
+var edgenetclientset versioned.Interface = edgenettestclient.NewSimpleClientset()
+
+func TestMain(m *testing.M) {
+	klog.SetOutput(ioutil.Discard)
+	log.SetOutput(ioutil.Discard)
+	logrus.SetOutput(ioutil.Discard)
+
+	flag.String("dir", "../../../../..", "Override the directory.")
+	flag.String("smtp-path", "../../../../../configs/smtp_test.yaml", "Set SMTP path.")
+	flag.Parse()
+
+	stopCh := signals.SetupSignalHandler()
+
+	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, time.Second*30)
+
+	controller := NewController(kubeclientset,
+		edgenetclientset,
+		edgenetInformerFactory.Registration().V1alpha1().OwnershipTransferRequests(),
+		72*time.Hour,
+		0)
+
+	edgenetInformerFactory.Start(stopCh)
+
+	go func() {
+		if err := controller.Run(2, stopCh); err != nil {
+			klog.Fatalf("Error running controller: %s", err.Error())
+		}
+	}()
+
+	kubeSystemNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "cluster-uid"}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), kubeSystemNamespace, metav1.CreateOptions{})
+
+	time.Sleep(500 * time.Millisecond)
+
+	os.Exit(m.Run())
+	<-stopCh
+}
+
+// Init syncs the test group
+func (g *TestGroup) Init() {
+	tenantObj := corev1alpha.Tenant{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Tenant",
+			APIVersion: "core.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "edgenet",
+			UID:  "edgenet-uid",
+		},
+		Spec: corev1alpha.TenantSpec{
+			FullName:  "EdgeNet",
+			ShortName: "EdgeNet",
+			URL:       "https://www.edge-net.org",
+			Address: corev1alpha.Address{
+				City:    "Paris - NY - CA",
+				Country: "France - US",
+				Street:  "4 place Jussieu, boite 169",
+				ZIP:     "75005",
+			},
+			Contact: corev1alpha.Contact{
+				Email:     "joe.public@edge-net.org",
+				FirstName: "Joe",
+				LastName:  "Public",
+				Phone:     "+33NUMBER",
+			},
+			Members: []corev1alpha.Member{
+				{Email: "joe.public@edge-net.org", Role: "edgenet:tenant-owner", Joined: metav1.Now()},
+				{Email: "john.smith@edge-net.org", Role: "edgenet:tenant-collaborator", Joined: metav1.Now()},
+				{Email: "jane.doe@edge-net.org", Role: "edgenet:tenant-admin", Joined: metav1.Now()},
+			},
+			Enabled: true,
+		},
+	}
+	ownershipTransferRequestObj := registrationv1alpha1.OwnershipTransferRequest{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "OwnershipTransferRequest",
+			APIVersion: "registration.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "handover",
+			Namespace: "edgenet",
+		},
+		Spec: registrationv1alpha1.OwnershipTransferRequestSpec{
+			NewOwner: corev1alpha.Contact{
+				Email:     "jane.doe@edge-net.org",
+				FirstName: "Jane",
+				LastName:  "Doe",
+				Phone:     "+33NUMBER",
+			},
+		},
+	}
+	g.tenantObj = tenantObj
+	g.ownershipTransferRequestObj = ownershipTransferRequestObj
+
+	edgenetclientset.CoreV1alpha1().Tenants().Delete(context.TODO(), g.tenantObj.GetName(), metav1.DeleteOptions{})
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), g.tenantObj.DeepCopy(), metav1.CreateOptions{})
+	tenantCoreNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: g.tenantObj.GetName()}}
+	namespaceLabels := map[string]string{"edge-net.io/kind": "core", "edge-net.io/tenant": g.tenantObj.GetName(),
+		"edge-net.io/tenant-uid": string(g.tenantObj.GetUID()), "edge-net.io/cluster-uid": "cluster-uid"}
+	tenantCoreNamespace.SetLabels(namespaceLabels)
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), tenantCoreNamespace, metav1.CreateOptions{})
+}
+
+func TestStartController(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	ownershipTransferRequest := g.ownershipTransferRequestObj.DeepCopy()
+	ownershipTransferRequest.SetName("ownership-transfer-request-controller-test")
+
+	edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Create(context.TODO(), ownershipTransferRequest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	ownershipTransferRequest, err := edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), ownershipTransferRequest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, pending, ownershipTransferRequest.Status.State)
+	util.Equals(t, true, meta.IsStatusConditionFalse(ownershipTransferRequest.Status.Conditions, registrationv1alpha1.ConditionAccepted))
+
+	// The new owner can accept the request through the transferee role
+	roleName := GenerateTransfereeRoleName(ownershipTransferRequest)
+	role, err := kubeclientset.RbacV1().Roles(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, []string{ownershipTransferRequest.GetName()}, role.Rules[0].ResourceNames)
+	roleBinding, err := kubeclientset.RbacV1().RoleBindings(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, rbacv1.RoleRef{Kind: "Role", Name: roleName}, roleBinding.RoleRef)
+	util.Equals(t, []rbacv1.Subject{{Kind: "User", Name: ownershipTransferRequest.Spec.NewOwner.Email, APIGroup: "rbac.authorization.k8s.io"}}, roleBinding.Subjects)
+
+	ownershipTransferRequest.Spec.Accepted = true
+	edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Update(context.TODO(), ownershipTransferRequest, metav1.UpdateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	ownershipTransferRequest, err = edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), ownershipTransferRequest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, transferred, ownershipTransferRequest.Status.State)
+	util.Equals(t, g.tenantObj.Spec.Contact.Email, ownershipTransferRequest.Status.PreviousOwner)
+	util.Equals(t, true, meta.IsStatusConditionTrue(ownershipTransferRequest.Status.Conditions, registrationv1alpha1.ConditionOwnershipTransferred))
+
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, ownershipTransferRequest.Spec.NewOwner, tenant.Spec.Contact)
+	util.Equals(t, false, access.IsTenantMember(tenant, g.tenantObj.Spec.Contact.Email))
+	util.Equals(t, 2, len(tenant.Status.Members))
+	for _, member := range tenant.Status.Members {
+		if member.Email == ownershipTransferRequest.Spec.NewOwner.Email {
+			util.Equals(t, "edgenet:tenant-owner", member.Role)
+		}
+	}
+	_, err = kubeclientset.RbacV1().Roles(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	_, err = kubeclientset.RbacV1().RoleBindings(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), roleName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+}
+
+func TestSameOwner(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	ownershipTransferRequest := g.ownershipTransferRequestObj.DeepCopy()
+	ownershipTransferRequest.SetName("same-owner")
+	ownershipTransferRequest.Spec.NewOwner = g.tenantObj.Spec.Contact
+
+	edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Create(context.TODO(), ownershipTransferRequest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	ownershipTransferRequest, err := edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), ownershipTransferRequest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, failure, ownershipTransferRequest.Status.State)
+	util.Equals(t, messageSameOwner, ownershipTransferRequest.Status.Message)
+}
+
+func TestNotPermitted(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	tenant, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	tenant.Spec.Enabled = false
+	edgenetclientset.CoreV1alpha1().Tenants().Update(context.TODO(), tenant, metav1.UpdateOptions{})
+
+	ownershipTransferRequest := g.ownershipTransferRequestObj.DeepCopy()
+	ownershipTransferRequest.SetName("disabled-tenant")
+	edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Create(context.TODO(), ownershipTransferRequest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	ownershipTransferRequest, err = edgenetclientset.RegistrationV1alpha1().OwnershipTransferRequests(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), ownershipTransferRequest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, failure, ownershipTransferRequest.Status.State)
+	util.Equals(t, messageNotPermitted, ownershipTransferRequest.Status.Message)
+	_, err = kubeclientset.RbacV1().Roles(ownershipTransferRequest.GetNamespace()).Get(context.TODO(), GenerateTransfereeRoleName(ownershipTransferRequest), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotarequest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/registration/v1alpha1"
+	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+)
+
+const controllerAgentName = "quotarequest-controller"
+
+// Definitions of the state of the quotarequest resource
+const (
+	successSynced           = "Synced"
+	messageResourceSynced   = "Quota Request synced successfully"
+	warningApproved         = "Not Approved"
+	messageQuotaNotApproved = "Waiting for Requested Quota to be approved"
+	successApproved         = "Approved"
+	messageQuotaApproved    = "Requested Quota approved successfully"
+	failureFound            = "Not Found"
+	messageQuotaNotFound    = "Tenant Resource Quota does not exist"
+	successClaimed          = "Claimed"
+	messageQuotaClaimed     = "Requested Quota claimed in Tenant Resource Quota"
+	failureClaim            = "Claim Failed"
+	messageClaimFailed      = "Requested Quota cannot be claimed in Tenant Resource Quota"
+	warningExpired          = "Expired"
+	messageExpired          = "Request expired before being approved"
+	failure                 = "Failure"
+	pending                 = "Pending"
+	approved                = "Approved"
+	expired                 = "Expired"
+)
+
+// Reasons of the status conditions of the quotarequest resource
+const (
+	reasonPending       = "Pending"
+	reasonApproved      = "Approved"
+	reasonQuotaNotFound = "QuotaNotFound"
+	reasonClaimed       = "Claimed"
+	reasonClaimFailed   = "ClaimFailed"
+	reasonExpired       = "Expired"
+)
+
+// Controller is the controller implementation for Quota Request resources
+type Controller struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	// edgenetclientset is a clientset for the EdgeNet API groups
+	edgenetclientset clientset.Interface
+
+	quotarequestsLister listers.QuotaRequestLister
+	quotarequestsSynced cache.InformerSynced
+
+	// approvalTimeout is the period within which the request needs to be approved before it expires
+	approvalTimeout time.Duration
+	// retention is the period for which expired and denied requests are kept as records before deletion
+	retention time.Duration
+
+	// workqueue is a rate limited work queue. This is used to queue work to be
+	// processed instead of performing it as soon as a change happens. This
+	// means we can ensure we only process a fixed amount of resources at a
+	// time, and makes it easy to ensure we are never processing the same item
+	// simultaneously in two different workers.
+	workqueue workqueue.RateLimitingInterface
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
+}
+
+// NewController returns a new controller
+func NewController(
+	kubeclientset kubernetes.Interface,
+	edgenetclientset clientset.Interface,
+	quotarequestInformer informers.QuotaRequestInformer,
+	approvalTimeout time.Duration,
+	retention time.Duration) *Controller {
+
+	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
+	klog.V(4).Info("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
+	controller := &Controller{
+		kubeclientset:       kubeclientset,
+		edgenetclientset:    edgenetclientset,
+		quotarequestsLister: quotarequestInformer.Lister(),
+		quotarequestsSynced: quotarequestInformer.Informer().HasSynced,
+		workqueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "QuotaRequests"),
+		approvalTimeout:     approvalTimeout,
+		retention:           retention,
+		recorder:            recorder,
+	}
+
+	klog.V(4).Infoln("Setting up event handlers")
+	// Set up an event handler for when Quota Request resources change
+	quotarequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueQuotaRequest,
+		UpdateFunc: func(old, new interface{}) {
+			newQuotaRequest := new.(*registrationv1alpha1.QuotaRequest)
+			oldQuotaRequest := old.(*registrationv1alpha1.QuotaRequest)
+			if newQuotaRequest.Status.Expiry != nil && (oldQuotaRequest.Status.Expiry == nil ||
+				!oldQuotaRequest.Status.Expiry.Time.Equal(newQuotaRequest.Status.Expiry.Time)) {
+				controller.enqueueQuotaRequestAfter(newQuotaRequest, time.Until(newQuotaRequest.Status.Expiry.Time))
+			}
+			controller.enqueueQuotaRequest(new)
+		},
+	})
+
+	access.Clientset = kubeclientset
+	access.EdgenetClientset = edgenetclientset
+
+	return controller
+}
+
+// Run will set up the event handlers for the types of quota request, as well
+// as syncing informer caches and starting workers. It will block until stopCh
+// is closed, at which point it will shutdown the workqueue and wait for
+// workers to finish processing their current work items.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.V(4).Infoln("Starting Quota Request controller")
+
+	klog.V(4).Infoln("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh,
+		c.quotarequestsSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.V(4).Infoln("Starting workers")
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.V(4).Infoln("Started workers")
+	<-stopCh
+	klog.V(4).Infoln("Shutting down workers")
+
+	return nil
+}
+
+// runWorker is a long-running function that will continually call the
+// processNextWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workqueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.workqueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.syncHandler(key); err != nil {
+			c.workqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.workqueue.Forget(obj)
+		klog.V(4).Infof("Successfully synced '%s'", key)
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+// syncHandler compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Quota Request
+// resource with the current status of the resource.
+func (c *Controller) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	quotarequest, err := c.quotarequestsLister.QuotaRequests(namespace).Get(name)
+
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("quotarequest '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	if quotarequest.Status.State != approved {
+		c.processQuotaRequest(quotarequest.DeepCopy())
+	}
+	c.recorder.Event(quotarequest, corev1.EventTypeNormal, successSynced, messageResourceSynced)
+	return nil
+}
+
+// enqueueQuotaRequest takes a QuotaRequest resource and converts it into a namespace/name
+// string which is then put onto the work queue. This method should *not* be
+// passed resources of any type other than QuotaRequest.
+func (c *Controller) enqueueQuotaRequest(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// enqueueQuotaRequestAfter takes a QuotaRequest resource and converts it into a namespace/name
+// string which is then put onto the work queue after the expiry date to be deleted. This method should *not* be
+// passed resources of any type other than QuotaRequest.
+func (c *Controller) enqueueQuotaRequestAfter(obj interface{}, after time.Duration) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, after)
+}
+
+func (c *Controller) processQuotaRequest(quotaRequestCopy *registrationv1alpha1.QuotaRequest) {
+	oldStatus := quotaRequestCopy.Status.DeepCopy()
+	statusUpdate := func() {
+		if !reflect.DeepEqual(*oldStatus, quotaRequestCopy.Status) {
+			if _, err := c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).UpdateStatus(context.TODO(), quotaRequestCopy, metav1.UpdateOptions{}); err != nil {
+				klog.V(4).Infoln(err)
+			}
+		}
+	}
+	if quotaRequestCopy.Status.Expiry == nil {
+		// Set the approval timeout which is 72 hours by default
+		quotaRequestCopy.Status.Expiry = &metav1.Time{
+			Time: time.Now().Add(c.approvalTimeout),
+		}
+	} else if time.Until(quotaRequestCopy.Status.Expiry.Time) <= 0 {
+		if retained := util.RetainExpiredRequest(quotaRequestCopy.Status.Expiry, c.retention, quotaRequestCopy.Status.State == expired,
+			func() {
+				c.recorder.Event(quotaRequestCopy, corev1.EventTypeWarning, warningExpired, messageExpired)
+				quotaRequestCopy.Status.State = expired
+				quotaRequestCopy.Status.Message = messageExpired
+				util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonExpired, messageExpired)
+				statusUpdate()
+			},
+			func() {
+				c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).Delete(context.TODO(), quotaRequestCopy.GetName(), metav1.DeleteOptions{})
+			}); retained > 0 {
+			c.enqueueQuotaRequestAfter(quotaRequestCopy, retained)
+		}
+		return
+	}
+	defer statusUpdate()
+	quotaRequestCopy.Status.ObservedGeneration = quotaRequestCopy.GetGeneration()
+
+	// Below code checks whether namespace, where quota request made, is local to the cluster or is propagated along with a federated deployment.
+	// If another cluster propagates the namespace, we skip checking the owner tenant's status as the Selective Deployment entity manages this life-cycle.
+	permitted := false
+	systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
+	if err != nil {
+		klog.V(4).Infoln(err)
+		c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).Delete(context.TODO(), quotaRequestCopy.GetName(), metav1.DeleteOptions{})
+		return
+	}
+	namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), quotaRequestCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		klog.V(4).Infoln(err)
+		c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).Delete(context.TODO(), quotaRequestCopy.GetName(), metav1.DeleteOptions{})
+		return
+	}
+	namespaceLabels := namespace.GetLabels()
+	tenantName := strings.ToLower(namespaceLabels["edge-net.io/tenant"])
+	if systemNamespace.GetUID() != types.UID(namespaceLabels["edge-net.io/cluster-uid"]) {
+		permitted = true
+	} else {
+		tenant, err := c.edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenantName, metav1.GetOptions{})
+		if err != nil {
+			klog.V(4).Infoln(err)
+			c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).Delete(context.TODO(), quotaRequestCopy.GetName(), metav1.DeleteOptions{})
+			return
+		}
+		if tenant.GetUID() == types.UID(namespaceLabels["edge-net.io/tenant-uid"]) && tenant.Spec.Enabled {
+			permitted = true
+		}
+	}
+
+	if permitted {
+		if !quotaRequestCopy.Spec.Approved {
+			if quotaRequestCopy.Status.State == pending && quotaRequestCopy.Status.Message == messageQuotaNotApproved {
+				return
+			}
+			c.recorder.Event(quotaRequestCopy, corev1.EventTypeWarning, warningApproved, messageQuotaNotApproved)
+			quotaRequestCopy.Status.State = pending
+			quotaRequestCopy.Status.Message = messageQuotaNotApproved
+			util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonPending, messageQuotaNotApproved)
+			return
+		}
+
+		c.recorder.Event(quotaRequestCopy, corev1.EventTypeNormal, successApproved, messageQuotaApproved)
+		util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonApproved, messageQuotaApproved)
+
+		// The tenant resource quota carries the name of the tenant. The requested resources go into a claim
+		// named after the quota request so that it is possible to trace back who asked for what and why.
+		tenantResourceQuota, err := c.edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), tenantName, metav1.GetOptions{})
+		if err != nil {
+			c.recorder.Event(quotaRequestCopy, corev1.EventTypeWarning, failureFound, messageQuotaNotFound)
+			quotaRequestCopy.Status.State = failure
+			quotaRequestCopy.Status.Message = messageQuotaNotFound
+			util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionQuotaClaimed, metav1.ConditionFalse, reasonQuotaNotFound, messageQuotaNotFound)
+			klog.V(4).Infoln(err)
+			return
+		}
+		claimName := GenerateClaimName(quotaRequestCopy)
+		if claim, elementExists := tenantResourceQuota.Spec.Claim[claimName]; !elementExists || !reflect.DeepEqual(claim, quotaRequestCopy.Spec.Claim) {
+			tenantResourceQuotaCopy := tenantResourceQuota.DeepCopy()
+			if tenantResourceQuotaCopy.Spec.Claim == nil {
+				tenantResourceQuotaCopy.Spec.Claim = make(map[string]corev1alpha1.ResourceTuning)
+			}
+			tenantResourceQuotaCopy.Spec.Claim[claimName] = *quotaRequestCopy.Spec.Claim.DeepCopy()
+			if _, err := c.edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Update(context.TODO(), tenantResourceQuotaCopy, metav1.UpdateOptions{}); err != nil {
+				c.recorder.Event(quotaRequestCopy, corev1.EventTypeWarning, failureClaim, messageClaimFailed)
+				quotaRequestCopy.Status.State = failure
+				quotaRequestCopy.Status.Message = messageClaimFailed
+				util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionQuotaClaimed, metav1.ConditionFalse, reasonClaimFailed, err.Error())
+				klog.V(4).Infoln(err)
+				return
+			}
+		}
+		c.recorder.Event(quotaRequestCopy, corev1.EventTypeNormal, successClaimed, messageQuotaClaimed)
+		quotaRequestCopy.Status.State = approved
+		quotaRequestCopy.Status.Message = messageQuotaApproved
+		util.SetCondition(&quotaRequestCopy.Status.Conditions, quotaRequestCopy.GetGeneration(), registrationv1alpha1.ConditionQuotaClaimed, metav1.ConditionTrue, reasonClaimed, messageQuotaClaimed)
+	} else {
+		c.edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestCopy.GetNamespace()).Delete(context.TODO(), quotaRequestCopy.GetName(), metav1.DeleteOptions{})
+	}
+}
+
+// GenerateClaimName returns the name of the claim that the quota request inserts into the tenant resource quota
+func GenerateClaimName(quotaRequestCopy *registrationv1alpha1.QuotaRequest) string {
+	return fmt.Sprintf("%s-%s", quotaRequestCopy.GetNamespace(), quotaRequestCopy.GetName())
+}
@@ -0,0 +1,201 @@
+package quotarequest
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/access"
+	corev1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	registrationv1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+	"github.com/EdgeNet-project/edgenet/pkg/signals"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog"
+)
+
+type TestGroup struct {
+	tenantObj       corev1alpha.Tenant
+	quotaRequestObj registrationv1alpha1.QuotaRequest
+}
+
+var kubeclientset kubernetes.Interface = testclient.NewSimpleClientset()
+var edgenetclientset versioned.Interface = edgenettestclient.NewSimpleClientset()
+
+func TestMain(m *testing.M) {
+	klog.SetOutput(ioutil.Discard)
+	log.SetOutput(ioutil.Discard)
+	logrus.SetOutput(ioutil.Discard)
+
+	flag.String("dir", "../../../../..", "Override the directory.")
+	flag.String("smtp-path", "../../../../../configs/smtp_test.yaml", "Set SMTP path.")
+	flag.Parse()
+
+	stopCh := signals.SetupSignalHandler()
+
+	edgenetInformerFactory := informers.NewSharedInformerFactory(edgenetclientset, time.Second*30)
+
+	controller := NewController(kubeclientset,
+		edgenetclientset,
+		edgenetInformerFactory.Registration().V1alpha1().QuotaRequests(),
+		72*time.Hour,
+		0)
+
+	edgenetInformerFactory.Start(stopCh)
+
+	go func() {
+		if err := controller.Run(2, stopCh); err != nil {
+			klog.Fatalf("Error running controller: %s", err.Error())
+		}
+	}()
+
+	access.Clientset = kubeclientset
+	access.CreateClusterRoles()
+	kubeSystemNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), kubeSystemNamespace, metav1.CreateOptions{})
+
+	time.Sleep(500 * time.Millisecond)
+
+	os.Exit(m.Run())
+	<-stopCh
+}
+
+// Init syncs the test group
+func (g *TestGroup) Init() {
+	tenantObj := corev1alpha.Tenant{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Tenant",
+			APIVersion: "apps.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "edgenet",
+		},
+		Spec: corev1alpha.TenantSpec{
+			FullName:  "EdgeNet",
+			ShortName: "EdgeNet",
+			URL:       "https://www.edge-net.org",
+			Address: corev1alpha.Address{
+				City:    "Paris - NY - CA",
+				Country: "France - US",
+				Street:  "4 place Jussieu, boite 169",
+				ZIP:     "75005",
+			},
+			Contact: corev1alpha.Contact{
+				Email:     "joe.public@edge-net.org",
+				FirstName: "Joe",
+				LastName:  "Public",
+				Phone:     "+33NUMBER",
+			},
+			Enabled: true,
+		},
+	}
+	quotaRequestObj := registrationv1alpha1.QuotaRequest{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "QuotaRequest",
+			APIVersion: "apps.edgenet.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "johnsmith",
+			Namespace: "edgenet",
+		},
+		Spec: registrationv1alpha1.QuotaRequestSpec{
+			FirstName: "John",
+			LastName:  "Smith",
+			Email:     "john.smith@edge-net.org",
+			Claim: corev1alpha.ResourceTuning{
+				ResourceList: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4000m"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			Justification: "Running a measurement campaign",
+		},
+	}
+	g.tenantObj = tenantObj
+	g.quotaRequestObj = quotaRequestObj
+
+	edgenetclientset.CoreV1alpha1().Tenants().Create(context.TODO(), g.tenantObj.DeepCopy(), metav1.CreateOptions{})
+	tenantCoreNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: g.tenantObj.GetName()}}
+	namespaceLabels := map[string]string{"edge-net.io/kind": "core", "edge-net.io/tenant": g.tenantObj.GetName()}
+	tenantCoreNamespace.SetLabels(namespaceLabels)
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), tenantCoreNamespace, metav1.CreateOptions{})
+	tenantResourceQuota := &corev1alpha.TenantResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: g.tenantObj.GetName()}}
+	edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Create(context.TODO(), tenantResourceQuota, metav1.CreateOptions{})
+}
+
+func TestStartController(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	quotaRequestTest := g.quotaRequestObj.DeepCopy()
+	quotaRequestTest.SetName("quota-request-controller-test")
+
+	// Create a quota request object
+	edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Create(context.TODO(), quotaRequestTest, metav1.CreateOptions{})
+	// Wait for the status update of created object
+	time.Sleep(time.Millisecond * 500)
+	// Get the object and check the status
+	quotaRequest, err := edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Get(context.TODO(), quotaRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	expected := metav1.Time{
+		Time: time.Now().Add(72 * time.Hour),
+	}
+	util.Equals(t, expected.Day(), quotaRequest.Status.Expiry.Day())
+	util.Equals(t, expected.Month(), quotaRequest.Status.Expiry.Month())
+	util.Equals(t, expected.Year(), quotaRequest.Status.Expiry.Year())
+
+	util.Equals(t, pending, quotaRequest.Status.State)
+	util.Equals(t, messageQuotaNotApproved, quotaRequest.Status.Message)
+	util.Equals(t, true, meta.IsStatusConditionFalse(quotaRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
+
+	quotaRequest.Spec.Approved = true
+	edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Update(context.TODO(), quotaRequest, metav1.UpdateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	quotaRequest, err = edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Get(context.TODO(), quotaRequestTest.GetName(), metav1.GetOptions{})
+
+	util.OK(t, err)
+	util.Equals(t, approved, quotaRequest.Status.State)
+	util.Equals(t, messageQuotaApproved, quotaRequest.Status.Message)
+	util.Equals(t, true, meta.IsStatusConditionTrue(quotaRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
+	util.Equals(t, true, meta.IsStatusConditionTrue(quotaRequest.Status.Conditions, registrationv1alpha1.ConditionQuotaClaimed))
+
+	tenantResourceQuota, err := edgenetclientset.CoreV1alpha1().TenantResourceQuotas().Get(context.TODO(), g.tenantObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	claim, elementExists := tenantResourceQuota.Spec.Claim[GenerateClaimName(quotaRequest)]
+	util.Equals(t, true, elementExists)
+	util.Equals(t, quotaRequestTest.Spec.Claim.ResourceList, claim.ResourceList)
+}
+
+func TestTimeout(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	quotaRequestTest := g.quotaRequestObj.DeepCopy()
+	quotaRequestTest.SetName("quota-request-timeout-test")
+	edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Create(context.TODO(), quotaRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+
+	t.Run("timeout", func(t *testing.T) {
+		quotaRequest, _ := edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Get(context.TODO(), quotaRequestTest.GetName(), metav1.GetOptions{})
+		quotaRequest.Status.Expiry = &metav1.Time{
+			Time: time.Now().Add(10 * time.Millisecond),
+		}
+		edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).UpdateStatus(context.TODO(), quotaRequest, metav1.UpdateOptions{})
+		time.Sleep(100 * time.Millisecond)
+		_, err := edgenetclientset.RegistrationV1alpha1().QuotaRequests(quotaRequestTest.GetNamespace()).Get(context.TODO(), quotaRequest.GetName(), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+}
@@ -30,11 +30,14 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/registration/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -56,17 +59,54 @@ const (
 	messageRoleFound       = "Requested Role / Cluster Role found successfully"
 	failureFound           = "Not Found"
 	messageRoleNotFound    = "Requested Role / Cluster Role does not exist"
+	failureSubject         = "Subject Not Allowed"
+	messageSubjectInvalid  = "Requested service account does not belong to the tenant"
 	warningApproved        = "Not Approved"
 	messageRoleNotApproved = "Waiting for Requested Role / Cluster Role to be approved"
 	successApproved        = "Approved"
 	messageRoleApproved    = "Requested Role / Cluster Role approved successfully"
+	messageAutoApproved    = "Requested Role / Cluster Role approved automatically by approval policy %s"
 	failureBinding         = "Binding Failed"
 	messageBindingFailed   = "Role binding failed"
+	warningDenied          = "Denied"
+	messageRoleDenied      = "Requested Role / Cluster Role denied"
+	warningExpired         = "Expired"
+	messageExpired         = "Request expired before being approved"
+	warningGrantExpired    = "Grant Expired"
+	messageGrantExpired    = "Role grant expired and the role is revoked"
+	warningWithdrawn       = "Withdrawn"
+	messageWithdrawn       = "Role approval withdrawn and the role is revoked"
+	failureRevocation      = "Revocation Failed"
+	messageRevokeFailed    = "Role revocation failed"
 	failure                = "Failure"
 	pending                = "Pending"
 	approved               = "Approved"
+	expired                = "Expired"
+	denied                 = "Denied"
+	revoked                = "Revoked"
 )
 
+// Reasons of the status conditions of the rolerequest resource
+const (
+	reasonPending       = "Pending"
+	reasonApproved      = "Approved"
+	reasonAutoApproved  = "AutoApproved"
+	reasonRoleNotFound  = "RoleNotFound"
+	reasonSubjectDenied = "SubjectNotAllowed"
+	reasonRoleBound     = "RoleBound"
+	reasonBindingFailed = "BindingFailed"
+	reasonDenied        = "Denied"
+	reasonExpired       = "Expired"
+	reasonGrantExpired  = "GrantExpired"
+	reasonWithdrawn     = "Withdrawn"
+)
+
+// roleBindingFinalizer lets the controller remove the subject from the role binding before the request is deleted
+const roleBindingFinalizer = "registration.edgenet.io/role-binding"
+
+// grantExpiryNotice is how long before the revocation of a time-bound role the user gets notified
+const grantExpiryNotice = 24 * time.Hour
+
 // Controller is the controller implementation for Role Request resources
 type Controller struct {
 	// kubeclientset is a standard kubernetes clientset
@@ -77,6 +117,11 @@ type Controller struct {
 	rolerequestsLister listers.RoleRequestLister
 	rolerequestsSynced cache.InformerSynced
 
+	// approvalTimeout is the period within which the request needs to be approved before it expires
+	approvalTimeout time.Duration
+	// retention is the period for which expired and denied requests are kept as records before deletion
+	retention time.Duration
+
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
@@ -92,7 +137,9 @@ type Controller struct {
 func NewController(
 	kubeclientset kubernetes.Interface,
 	edgenetclientset clientset.Interface,
-	rolerequestInformer informers.RoleRequestInformer) *Controller {
+	rolerequestInformer informers.RoleRequestInformer,
+	approvalTimeout time.Duration,
+	retention time.Duration) *Controller {
 
 	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
 	klog.V(4).Info("Creating event broadcaster")
@@ -107,6 +154,8 @@ func NewController(
 		rolerequestsLister: rolerequestInformer.Lister(),
 		rolerequestsSynced: rolerequestInformer.Informer().HasSynced,
 		workqueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RoleRequests"),
+		approvalTimeout:    approvalTimeout,
+		retention:          retention,
 		recorder:           recorder,
 	}
 
@@ -117,8 +166,8 @@ func NewController(
 		UpdateFunc: func(old, new interface{}) {
 			newRoleRequest := new.(*registrationv1alpha1.RoleRequest)
 			oldRoleRequest := old.(*registrationv1alpha1.RoleRequest)
-			if (oldRoleRequest.Status.Expiry == nil && newRoleRequest.Status.Expiry != nil) ||
-				!oldRoleRequest.Status.Expiry.Time.Equal(newRoleRequest.Status.Expiry.Time) {
+			if newRoleRequest.Status.Expiry != nil && (oldRoleRequest.Status.Expiry == nil ||
+				!oldRoleRequest.Status.Expiry.Time.Equal(newRoleRequest.Status.Expiry.Time)) {
 				controller.enqueueRoleRequestAfter(newRoleRequest, time.Until(newRoleRequest.Status.Expiry.Time))
 			}
 			controller.enqueueRoleRequest(new)
@@ -224,8 +273,23 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
+	if rolerequest.GetDeletionTimestamp() != nil {
+		return c.finalizeRoleRequest(rolerequest.DeepCopy())
+	}
+	if !hasFinalizer(rolerequest) {
+		// The request is processed once the update event of the finalizer arrives
+		rolerequestCopy := rolerequest.DeepCopy()
+		rolerequestCopy.SetFinalizers(append(rolerequestCopy.GetFinalizers(), roleBindingFinalizer))
+		_, err := c.edgenetclientset.RegistrationV1alpha1().RoleRequests(rolerequestCopy.GetNamespace()).Update(context.TODO(), rolerequestCopy, metav1.UpdateOptions{})
+		return err
+	}
+
 	if rolerequest.Status.State != approved {
 		c.processRoleRequest(rolerequest.DeepCopy())
+	} else if (!rolerequest.Spec.Approved && rolerequest.Status.ApprovedBy == "") || rolerequest.Spec.Denied {
+		c.withdrawRoleRequest(rolerequest.DeepCopy())
+	} else if rolerequest.Spec.Duration != nil || rolerequest.Spec.ValidUntil != nil {
+		c.processRoleGrant(rolerequest.DeepCopy())
 	}
 	c.recorder.Event(rolerequest, corev1.EventTypeNormal, successSynced, messageResourceSynced)
 	return nil
@@ -258,24 +322,37 @@ func (c *Controller) enqueueRoleRequestAfter(obj interface{}, after time.Duratio
 }
 
 func (c *Controller) processRoleRequest(roleRequestCopy *registrationv1alpha1.RoleRequest) {
-	oldStatus := roleRequestCopy.Status
+	oldStatus := roleRequestCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, roleRequestCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, roleRequestCopy.Status) {
 			if _, err := c.edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestCopy.GetNamespace()).UpdateStatus(context.TODO(), roleRequestCopy, metav1.UpdateOptions{}); err != nil {
 				klog.V(4).Infoln(err)
 			}
 		}
 	}
 	if roleRequestCopy.Status.Expiry == nil {
-		// Set the approval timeout which is 72 hours
+		// Set the approval timeout which is 72 hours by default
 		roleRequestCopy.Status.Expiry = &metav1.Time{
-			Time: time.Now().Add(72 * time.Hour),
+			Time: time.Now().Add(c.approvalTimeout),
+		}
+	} else if time.Until(roleRequestCopy.Status.Expiry.Time) <= 0 || roleRequestCopy.Status.State == revoked {
+		if retained := util.RetainExpiredRequest(getRetentionStart(roleRequestCopy), c.retention, roleRequestCopy.Status.State == expired || roleRequestCopy.Status.State == denied || roleRequestCopy.Status.State == revoked,
+			func() {
+				c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, warningExpired, messageExpired)
+				roleRequestCopy.Status.State = expired
+				roleRequestCopy.Status.Message = messageExpired
+				util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonExpired, messageExpired)
+				statusUpdate()
+			},
+			func() {
+				c.edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestCopy.GetNamespace()).Delete(context.TODO(), roleRequestCopy.GetName(), metav1.DeleteOptions{})
+			}); retained > 0 {
+			c.enqueueRoleRequestAfter(roleRequestCopy, retained)
 		}
-	} else if time.Until(roleRequestCopy.Status.Expiry.Time) <= 0 {
-		c.edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestCopy.GetNamespace()).Delete(context.TODO(), roleRequestCopy.GetName(), metav1.DeleteOptions{})
 		return
 	}
 	defer statusUpdate()
+	roleRequestCopy.Status.ObservedGeneration = roleRequestCopy.GetGeneration()
 
 	// Below code checks whether namespace, where role request made, is local to the cluster or is propagated along with a federated deployment.
 	// If another cluster propagates the namespace, we skip checking the owner tenant's status as the Selective Deployment entity manages this life-cycle.
@@ -308,57 +385,83 @@ func (c *Controller) processRoleRequest(roleRequestCopy *registrationv1alpha1.Ro
 	}
 
 	if permitted {
+		if roleRequestCopy.Spec.Denied {
+			c.denyRoleRequest(roleRequestCopy)
+			return
+		}
 		// Below is to ensure that the requested Role / ClusterRole exists before moving forward in the procedure.
 		// If not, the status of the object falls into an error state.
 		roleExists := c.checkForRequestedRole(roleRequestCopy)
 		if !roleExists {
 			return
 		}
+		// Service accounts can only be bound to a role if they belong to the tenant that owns the namespace
+		if !c.checkForRequestedSubject(roleRequestCopy, namespaceLabels["edge-net.io/tenant"]) {
+			return
+		}
+
+		// Approval policies let the requests meeting the criteria of administrators be approved automatically.
+		// Policies do not apply once a human withdraws the approval, which would otherwise get the role back at the next sync.
+		roleRequestCopy.Status.ApprovedBy = ""
+		if !roleRequestCopy.Spec.Approved && !isWithdrawn(roleRequestCopy) {
+			roleRequestCopy.Status.ApprovedBy = access.GetApprovalPolicyForRoleRequest(roleRequestCopy)
+		}
 
-		if !roleRequestCopy.Spec.Approved {
+		if !roleRequestCopy.Spec.Approved && roleRequestCopy.Status.ApprovedBy == "" {
 			if roleRequestCopy.Status.State == pending && roleRequestCopy.Status.Message == messageRoleNotApproved {
 				return
 			}
 			c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, warningApproved, messageRoleNotApproved)
 			roleRequestCopy.Status.State = pending
 			roleRequestCopy.Status.Message = messageRoleNotApproved
+			util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonPending, messageRoleNotApproved)
 		} else {
 			c.recorder.Event(roleRequestCopy, corev1.EventTypeNormal, successApproved, messageRoleApproved)
 			roleRequestCopy.Status.State = approved
 			roleRequestCopy.Status.Message = messageRoleApproved
+			if roleRequestCopy.Status.ApprovedBy != "" {
+				util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonAutoApproved, fmt.Sprintf(messageAutoApproved, roleRequestCopy.Status.ApprovedBy))
+			} else {
+				util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonApproved, messageRoleApproved)
+			}
 
 			// The following section handles role binding. There are two basic logical steps here.
-			// Check if role binding already exists; if not, create a role binding for the user.
-			// If role binding exists, check if the user already holds the role. If not, pin the role to the user.
+			// Check if role binding already exists; if not, create a role binding for the subject.
+			// If role binding exists, check if the subject already holds the role. If not, pin the role to the subject.
+			subject := getSubject(roleRequestCopy)
+			roleRef := getRoleRef(roleRequestCopy)
 			if roleBindingRaw, err := c.kubeclientset.RbacV1().RoleBindings(roleRequestCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{LabelSelector: "edge-net.io/generated=true"}); err == nil {
 				// TODO: Simplfy below
 				roleBindingExists := false
 				roleBound := false
 				for _, roleBindingRow := range roleBindingRaw.Items {
-					if roleBindingRow.GetName() == roleRequestCopy.Spec.RoleRef.Name && roleBindingRow.RoleRef.Name == roleRequestCopy.Spec.RoleRef.Name && roleBindingRow.RoleRef.Kind == roleRequestCopy.Spec.RoleRef.Kind {
+					if roleBindingRow.GetName() == roleRef.Name && roleBindingRow.RoleRef.Name == roleRef.Name && roleBindingRow.RoleRef.Kind == roleRef.Kind {
 						roleBindingExists = true
 						for _, subjectRow := range roleBindingRow.Subjects {
-							if subjectRow.Kind == "User" && subjectRow.Name == roleRequestCopy.Spec.Email {
+							if isSameSubject(subjectRow, subject) {
+								roleBound = true
 								break
 							}
 						}
 						if !roleBound {
 							roleBindingCopy := roleBindingRow.DeepCopy()
-							roleBindingCopy.Subjects = append(roleBindingCopy.Subjects, rbacv1.Subject{Kind: "User", Name: roleRequestCopy.Spec.Email, APIGroup: "rbac.authorization.k8s.io"})
+							roleBindingCopy.Subjects = append(roleBindingCopy.Subjects, subject)
 							if _, err := c.kubeclientset.RbacV1().RoleBindings(roleBindingCopy.GetNamespace()).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{}); err != nil {
 								c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 								roleRequestCopy.Status.State = failure
 								roleRequestCopy.Status.Message = messageBindingFailed
+								util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, err.Error())
 								klog.V(4).Infoln(err)
+							} else {
+								roleBound = true
 							}
 							break
 						}
 					}
 				}
 				if !roleBindingExists {
-					roleRef := rbacv1.RoleRef{Kind: roleRequestCopy.Spec.RoleRef.Kind, Name: roleRequestCopy.Spec.RoleRef.Name}
-					rbSubjects := []rbacv1.Subject{{Kind: "User", Name: roleRequestCopy.Spec.Email, APIGroup: "rbac.authorization.k8s.io"}}
-					roleBind := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleRequestCopy.Spec.RoleRef.Name, Namespace: roleRequestCopy.GetNamespace()},
+					rbSubjects := []rbacv1.Subject{subject}
+					roleBind := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleRef.Name, Namespace: roleRequestCopy.GetNamespace()},
 						Subjects: rbSubjects, RoleRef: roleRef}
 					roleBindLabels := map[string]string{"edge-net.io/generated": "true"}
 					roleBind.SetLabels(roleBindLabels)
@@ -366,7 +469,19 @@ func (c *Controller) processRoleRequest(roleRequestCopy *registrationv1alpha1.Ro
 						c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureBinding, messageBindingFailed)
 						roleRequestCopy.Status.State = failure
 						roleRequestCopy.Status.Message = messageBindingFailed
+						util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonBindingFailed, err.Error())
 						klog.V(4).Infoln(err)
+					} else {
+						roleBound = true
+					}
+				}
+				if roleBound {
+					util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionTrue, reasonRoleBound, "Requested role is bound to the subject")
+					// Users holding a role in any namespace of a local tenant are listed in the members of the tenant
+					if subject.Kind == "User" && systemNamespace.GetUID() == types.UID(namespaceLabels["edge-net.io/cluster-uid"]) {
+						if err := access.AddTenantMember(namespaceLabels["edge-net.io/tenant"], subject.Name, roleRequestCopy.Spec.RoleRef.Name); err != nil {
+							klog.Infoln(err)
+						}
 					}
 				}
 			}
@@ -376,6 +491,194 @@ func (c *Controller) processRoleRequest(roleRequestCopy *registrationv1alpha1.Ro
 	}
 }
 
+// processRoleGrant keeps track of the time-bound role grants. It notifies the user before the grant expires,
+// and removes the subject from the role binding once it expires.
+func (c *Controller) processRoleGrant(roleRequestCopy *registrationv1alpha1.RoleRequest) {
+	oldStatus := roleRequestCopy.Status.DeepCopy()
+	defer func() {
+		if !reflect.DeepEqual(*oldStatus, roleRequestCopy.Status) {
+			if _, err := c.edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestCopy.GetNamespace()).UpdateStatus(context.TODO(), roleRequestCopy, metav1.UpdateOptions{}); err != nil {
+				klog.V(4).Infoln(err)
+			}
+		}
+	}()
+
+	// The grant expiry follows the spec so that the administrators can extend or shorten a grant afterward
+	grantExpiry := getGrantExpiry(roleRequestCopy)
+	if grantExpiry == nil {
+		return
+	}
+	if roleRequestCopy.Status.GrantExpiry == nil || !roleRequestCopy.Status.GrantExpiry.Equal(grantExpiry) {
+		roleRequestCopy.Status.GrantExpiry = grantExpiry
+		roleRequestCopy.Status.GrantExpiryNotified = false
+	}
+
+	if time.Until(grantExpiry.Time) <= 0 {
+		if err := c.revokeRole(roleRequestCopy); err != nil {
+			c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+			klog.V(4).Infoln(err)
+			c.enqueueRoleRequestAfter(roleRequestCopy, time.Minute)
+			return
+		}
+		c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, warningGrantExpired, messageGrantExpired)
+		roleRequestCopy.Status.State = revoked
+		roleRequestCopy.Status.Message = messageGrantExpired
+		util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonGrantExpired, messageGrantExpired)
+		return
+	}
+
+	if !roleRequestCopy.Status.GrantExpiryNotified && time.Until(grantExpiry.Time) <= grantExpiryNotice {
+		if systemNamespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{}); err == nil {
+			access.SendEmailForRoleRequest(roleRequestCopy, "role-grant-expiring", "[EdgeNet] Role grant about to expire", string(systemNamespace.GetUID()), []string{roleRequestCopy.Spec.Email})
+			roleRequestCopy.Status.GrantExpiryNotified = true
+		}
+	}
+	if roleRequestCopy.Status.GrantExpiryNotified {
+		c.enqueueRoleRequestAfter(roleRequestCopy, time.Until(grantExpiry.Time))
+	} else {
+		c.enqueueRoleRequestAfter(roleRequestCopy, time.Until(grantExpiry.Add(-grantExpiryNotice)))
+	}
+}
+
+// withdrawRoleRequest revokes the role of a request that gets unapproved or denied after its approval.
+// The request then waits for approval again, unless it is denied.
+func (c *Controller) withdrawRoleRequest(roleRequestCopy *registrationv1alpha1.RoleRequest) {
+	if err := c.revokeRole(roleRequestCopy); err != nil {
+		c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+		klog.V(4).Infoln(err)
+		c.enqueueRoleRequestAfter(roleRequestCopy, time.Minute)
+		return
+	}
+	c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, warningWithdrawn, messageWithdrawn)
+	util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonWithdrawn, messageWithdrawn)
+	roleRequestCopy.Status.Expiry = nil
+	roleRequestCopy.Status.GrantExpiry = nil
+	roleRequestCopy.Status.GrantExpiryNotified = false
+	c.processRoleRequest(roleRequestCopy)
+}
+
+// finalizeRoleRequest revokes the role bound by the request before letting the request go
+func (c *Controller) finalizeRoleRequest(roleRequestCopy *registrationv1alpha1.RoleRequest) error {
+	if !hasFinalizer(roleRequestCopy) {
+		return nil
+	}
+	if meta.IsStatusConditionTrue(roleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionRoleBound) {
+		if err := c.revokeRole(roleRequestCopy); err != nil {
+			c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureRevocation, messageRevokeFailed)
+			return err
+		}
+	}
+	finalizers := []string{}
+	for _, finalizer := range roleRequestCopy.GetFinalizers() {
+		if finalizer != roleBindingFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	roleRequestCopy.SetFinalizers(finalizers)
+	_, err := c.edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestCopy.GetNamespace()).Update(context.TODO(), roleRequestCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// revokeRole removes the subject from the subjects of the role binding that holds the requested role, and deletes
+// the role binding when no subject remains. The subject keeps the role if another approved request grants the same.
+func (c *Controller) revokeRole(roleRequestCopy *registrationv1alpha1.RoleRequest) error {
+	subject := getSubject(roleRequestCopy)
+	if roleRequestRaw, err := c.rolerequestsLister.RoleRequests(roleRequestCopy.GetNamespace()).List(labels.Everything()); err == nil {
+		for _, roleRequestRow := range roleRequestRaw {
+			if roleRequestRow.GetName() != roleRequestCopy.GetName() && roleRequestRow.GetDeletionTimestamp() == nil &&
+				roleRequestRow.Status.State == approved && isSameSubject(getSubject(roleRequestRow), subject) &&
+				roleRequestRow.Spec.RoleRef == roleRequestCopy.Spec.RoleRef {
+				return nil
+			}
+		}
+	}
+	roleRef := getRoleRef(roleRequestCopy)
+	roleBinding, err := c.kubeclientset.RbacV1().RoleBindings(roleRequestCopy.GetNamespace()).Get(context.TODO(), roleRef.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if roleBinding.RoleRef.Kind != roleRef.Kind || roleBinding.RoleRef.Name != roleRef.Name {
+		return nil
+	}
+	roleBindingCopy := roleBinding.DeepCopy()
+	roleBindingCopy.Subjects = []rbacv1.Subject{}
+	for _, subjectRow := range roleBinding.Subjects {
+		if isSameSubject(subjectRow, subject) {
+			continue
+		}
+		roleBindingCopy.Subjects = append(roleBindingCopy.Subjects, subjectRow)
+	}
+	if len(roleBindingCopy.Subjects) == len(roleBinding.Subjects) {
+		return nil
+	}
+	if len(roleBindingCopy.Subjects) == 0 {
+		err = c.kubeclientset.RbacV1().RoleBindings(roleBindingCopy.GetNamespace()).Delete(context.TODO(), roleBindingCopy.GetName(), metav1.DeleteOptions{})
+	} else {
+		_, err = c.kubeclientset.RbacV1().RoleBindings(roleBindingCopy.GetNamespace()).Update(context.TODO(), roleBindingCopy, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	c.leaveTenant(roleRequestCopy, subject)
+	return nil
+}
+
+// leaveTenant removes the user from the members of the tenant once the user holds no role in any namespace of the tenant
+func (c *Controller) leaveTenant(roleRequestCopy *registrationv1alpha1.RoleRequest, subject rbacv1.Subject) {
+	if subject.Kind != "User" {
+		return
+	}
+	namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), roleRequestCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		klog.Infoln(err)
+		return
+	}
+	tenant := namespace.GetLabels()["edge-net.io/tenant"]
+	if tenant == "" {
+		return
+	}
+	if bound, err := access.HasTenantRoleBinding(tenant, subject.Name); err != nil || bound {
+		return
+	}
+	if err := access.RemoveTenantMember(tenant, subject.Name); err != nil {
+		klog.Infoln(err)
+	}
+}
+
+// isWithdrawn returns true if the role of the request is revoked because its approval has been withdrawn
+func isWithdrawn(roleRequestCopy *registrationv1alpha1.RoleRequest) bool {
+	roleBoundCondition := meta.FindStatusCondition(roleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionRoleBound)
+	return roleBoundCondition != nil && roleBoundCondition.Reason == reasonWithdrawn
+}
+
+// getRetentionStart returns the date from which the request is retained before its removal. A revoked request is retained
+// from its revocation on, as the approval timeout may be long gone by the time the grant expires.
+func getRetentionStart(roleRequestCopy *registrationv1alpha1.RoleRequest) *metav1.Time {
+	if roleRequestCopy.Status.State == revoked {
+		if roleBoundCondition := meta.FindStatusCondition(roleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionRoleBound); roleBoundCondition != nil && roleBoundCondition.LastTransitionTime.After(roleRequestCopy.Status.Expiry.Time) {
+			return roleBoundCondition.LastTransitionTime.DeepCopy()
+		}
+	}
+	return roleRequestCopy.Status.Expiry
+}
+
+// getGrantExpiry returns the date when the role grant expires, which is nil if the grant is not time-bound.
+// The duration of the grant is counted from the approval of the request.
+func getGrantExpiry(roleRequestCopy *registrationv1alpha1.RoleRequest) *metav1.Time {
+	if roleRequestCopy.Spec.ValidUntil != nil {
+		return roleRequestCopy.Spec.ValidUntil.DeepCopy()
+	}
+	if roleRequestCopy.Spec.Duration != nil {
+		if approvedCondition := meta.FindStatusCondition(roleRequestCopy.Status.Conditions, registrationv1alpha1.ConditionApproved); approvedCondition != nil {
+			return &metav1.Time{Time: approvedCondition.LastTransitionTime.Add(roleRequestCopy.Spec.Duration.Duration)}
+		}
+	}
+	return nil
+}
+
 func (c *Controller) checkForRequestedRole(roleRequestCopy *registrationv1alpha1.RoleRequest) bool {
 	if roleRequestCopy.Spec.RoleRef.Kind == "ClusterRole" {
 		if clusterRoleRaw, err := c.kubeclientset.RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{}); err == nil {
@@ -386,10 +689,12 @@ func (c *Controller) checkForRequestedRole(roleRequestCopy *registrationv1alpha1
 				}
 			}
 		}
-	} else if roleRequestCopy.Spec.RoleRef.Kind == "Role" {
+	} else if roleRequestCopy.Spec.RoleRef.Kind == "Role" || roleRequestCopy.Spec.RoleRef.Kind == "RoleTemplate" {
+		// A role template is available once its role is in the namespace, either materialized there or inherited from the parent
+		roleRef := getRoleRef(roleRequestCopy)
 		if roleRaw, err := c.kubeclientset.RbacV1().Roles(roleRequestCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{}); err == nil {
 			for _, roleRow := range roleRaw.Items {
-				if roleRow.GetName() == roleRequestCopy.Spec.RoleRef.Name {
+				if roleRow.GetName() == roleRef.Name {
 					c.recorder.Event(roleRequestCopy, corev1.EventTypeNormal, successFound, messageRoleFound)
 					return true
 				}
@@ -400,5 +705,79 @@ func (c *Controller) checkForRequestedRole(roleRequestCopy *registrationv1alpha1
 	c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureFound, messageRoleNotFound)
 	roleRequestCopy.Status.State = failure
 	roleRequestCopy.Status.Message = messageRoleNotFound
+	util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonRoleNotFound, messageRoleNotFound)
+	return false
+}
+
+// checkForRequestedSubject ensures that the service account to bind the role to is in a namespace of the given tenant
+func (c *Controller) checkForRequestedSubject(roleRequestCopy *registrationv1alpha1.RoleRequest, tenant string) bool {
+	subject := getSubject(roleRequestCopy)
+	if subject.Kind != "ServiceAccount" || subject.Namespace == roleRequestCopy.GetNamespace() {
+		return true
+	}
+	if namespace, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), subject.Namespace, metav1.GetOptions{}); err == nil {
+		if namespace.GetLabels()["edge-net.io/tenant"] == tenant {
+			return true
+		}
+	}
+
+	c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, failureSubject, messageSubjectInvalid)
+	roleRequestCopy.Status.State = failure
+	roleRequestCopy.Status.Message = messageSubjectInvalid
+	util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonSubjectDenied, messageSubjectInvalid)
+	return false
+}
+
+// denyRoleRequest puts the role request into the denied state along with the reason given by the administrators
+func (c *Controller) denyRoleRequest(roleRequestCopy *registrationv1alpha1.RoleRequest) {
+	message := messageRoleDenied
+	if roleRequestCopy.Spec.Reason != "" {
+		message = fmt.Sprintf("%s: %s", messageRoleDenied, roleRequestCopy.Spec.Reason)
+	}
+	if roleRequestCopy.Status.State == denied && roleRequestCopy.Status.Message == message {
+		return
+	}
+	c.recorder.Event(roleRequestCopy, corev1.EventTypeWarning, warningDenied, message)
+	roleRequestCopy.Status.State = denied
+	roleRequestCopy.Status.Message = message
+	util.SetCondition(&roleRequestCopy.Status.Conditions, roleRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonDenied, message)
+}
+
+// getSubject returns the subject that the role request binds the role to, which is the user
+// making the request unless a group or service account is requested.
+func getSubject(roleRequest *registrationv1alpha1.RoleRequest) rbacv1.Subject {
+	if roleRequest.Spec.Subject == nil {
+		return rbacv1.Subject{Kind: "User", Name: roleRequest.Spec.Email, APIGroup: "rbac.authorization.k8s.io"}
+	}
+	if roleRequest.Spec.Subject.Kind == "ServiceAccount" {
+		namespace := roleRequest.Spec.Subject.Namespace
+		if namespace == "" {
+			namespace = roleRequest.GetNamespace()
+		}
+		return rbacv1.Subject{Kind: "ServiceAccount", Name: roleRequest.Spec.Subject.Name, Namespace: namespace}
+	}
+	return rbacv1.Subject{Kind: roleRequest.Spec.Subject.Kind, Name: roleRequest.Spec.Subject.Name, APIGroup: "rbac.authorization.k8s.io"}
+}
+
+// getRoleRef returns the reference of the role to bind, where a role template refers to the role that materializes it
+func getRoleRef(roleRequest *registrationv1alpha1.RoleRequest) rbacv1.RoleRef {
+	if roleRequest.Spec.RoleRef.Kind == "RoleTemplate" {
+		return rbacv1.RoleRef{Kind: "Role", Name: access.GetRoleTemplateRoleName(roleRequest.Spec.RoleRef.Name)}
+	}
+	return rbacv1.RoleRef{Kind: roleRequest.Spec.RoleRef.Kind, Name: roleRequest.Spec.RoleRef.Name}
+}
+
+// isSameSubject checks whether two subjects refer to the same entity
+func isSameSubject(subject, other rbacv1.Subject) bool {
+	return subject.Kind == other.Kind && subject.Name == other.Name && subject.Namespace == other.Namespace
+}
+
+// hasFinalizer checks whether the role request holds the finalizer of the controller
+func hasFinalizer(roleRequest *registrationv1alpha1.RoleRequest) bool {
+	for _, finalizer := range roleRequest.GetFinalizers() {
+		if finalizer == roleBindingFinalizer {
+			return true
+		}
+	}
 	return false
 }
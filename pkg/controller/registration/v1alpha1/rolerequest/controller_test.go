@@ -3,6 +3,7 @@ package rolerequest
 import (
 	"context"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
@@ -21,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -50,7 +52,9 @@ func TestMain(m *testing.M) {
 
 	controller := NewController(kubeclientset,
 		edgenetclientset,
-		edgenetInformerFactory.Registration().V1alpha1().RoleRequests())
+		edgenetInformerFactory.Registration().V1alpha1().RoleRequests(),
+		72*time.Hour,
+		0)
 
 	edgenetInformerFactory.Start(stopCh)
 
@@ -151,6 +155,7 @@ func TestStartController(t *testing.T) {
 
 	util.Equals(t, pending, roleRequest.Status.State)
 	util.Equals(t, messageRoleNotApproved, roleRequest.Status.Message)
+	util.Equals(t, true, meta.IsStatusConditionFalse(roleRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
 
 	roleRequest.Spec.Approved = true
 	edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
@@ -160,6 +165,8 @@ func TestStartController(t *testing.T) {
 	util.OK(t, err)
 	util.Equals(t, approved, roleRequest.Status.State)
 	util.Equals(t, messageRoleApproved, roleRequest.Status.Message)
+	util.Equals(t, true, meta.IsStatusConditionTrue(roleRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
+	util.Equals(t, true, meta.IsStatusConditionTrue(roleRequest.Status.Conditions, registrationv1alpha1.ConditionRoleBound))
 }
 
 func TestTimeout(t *testing.T) {
@@ -190,3 +197,240 @@ func TestTimeout(t *testing.T) {
 		util.Equals(t, true, errors.IsNotFound(err))
 	})
 }
+
+func TestDenial(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	roleRequestTest := g.roleRequestObj.DeepCopy()
+	roleRequestTest.SetName("role-request-denial-test")
+	roleRequestTest.Spec.Denied = true
+	roleRequestTest.Spec.Reason = "Unknown requester"
+	roleRequestTest.Spec.Approved = true
+	edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+
+	roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, denied, roleRequest.Status.State)
+	util.Equals(t, fmt.Sprintf("%s: %s", messageRoleDenied, roleRequestTest.Spec.Reason), roleRequest.Status.Message)
+	util.Equals(t, true, meta.IsStatusConditionFalse(roleRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
+	util.Equals(t, false, meta.IsStatusConditionTrue(roleRequest.Status.Conditions, registrationv1alpha1.ConditionRoleBound))
+}
+
+func TestApprovalPolicy(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	approvalPolicy := &registrationv1alpha1.ApprovalPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "univ-collaborators",
+		},
+		Spec: registrationv1alpha1.ApprovalPolicySpec{
+			RequestKind: "RoleRequest",
+			Rules: []registrationv1alpha1.ApprovalRule{
+				{
+					EmailDomains: []string{"*.univ.fr"},
+					Roles:        []registrationv1alpha1.RoleRefSpec{{Kind: "ClusterRole", Name: "edgenet:tenant-collaborator"}},
+				},
+			},
+		},
+	}
+	edgenetclientset.RegistrationV1alpha1().ApprovalPolicies().Create(context.TODO(), approvalPolicy, metav1.CreateOptions{})
+
+	roleRequestTest := g.roleRequestObj.DeepCopy()
+	roleRequestTest.SetName("role-request-approval-policy-test")
+	roleRequestTest.Spec.Email = "jane.doe@lip6.univ.fr"
+	roleRequestTest.Spec.RoleRef.Name = "edgenet:tenant-collaborator"
+	roleRequestTest.Status.EmailVerified = true
+	edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+
+	roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, approvalPolicy.GetName(), roleRequest.Status.ApprovedBy)
+	util.Equals(t, approved, roleRequest.Status.State)
+	util.Equals(t, true, meta.IsStatusConditionTrue(roleRequest.Status.Conditions, registrationv1alpha1.ConditionApproved))
+
+	t.Run("no matching rule", func(t *testing.T) {
+		roleRequestTest := g.roleRequestObj.DeepCopy()
+		roleRequestTest.SetName("role-request-approval-policy-mismatch-test")
+		roleRequestTest.Spec.Email = "jane.doe@lip6.univ.fr"
+		roleRequestTest.Status.EmailVerified = true
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "", roleRequest.Status.ApprovedBy)
+		util.Equals(t, pending, roleRequest.Status.State)
+	})
+	t.Run("withdrawal", func(t *testing.T) {
+		roleRequestTest := g.roleRequestObj.DeepCopy()
+		roleRequestTest.SetName("role-request-approval-policy-withdrawal-test")
+		roleRequestTest.Spec.Email = "john.doe@lip6.univ.fr"
+		roleRequestTest.Spec.RoleRef.Name = "edgenet:tenant-collaborator"
+		roleRequestTest.Spec.Approved = true
+		roleRequestTest.Status.EmailVerified = true
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, approved, roleRequest.Status.State)
+		roleRequest.Spec.Approved = false
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err = edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "", roleRequest.Status.ApprovedBy)
+		util.Equals(t, pending, roleRequest.Status.State)
+	})
+}
+
+func TestGrantExpiry(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	roleRequestTest := g.roleRequestObj.DeepCopy()
+	roleRequestTest.SetName("role-request-grant-expiry-test")
+	roleRequestTest.Spec.Email = "intern@edge-net.org"
+	roleRequestTest.Spec.Approved = true
+	roleRequestTest.Spec.ValidUntil = &metav1.Time{
+		Time: time.Now().Add(-time.Minute),
+	}
+	edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+
+	roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, revoked, roleRequest.Status.State)
+	util.Equals(t, messageGrantExpired, roleRequest.Status.Message)
+	util.Equals(t, true, roleRequest.Status.GrantExpiry.Equal(roleRequestTest.Spec.ValidUntil))
+	util.Equals(t, true, meta.IsStatusConditionFalse(roleRequest.Status.Conditions, registrationv1alpha1.ConditionRoleBound))
+
+	util.Equals(t, false, isBound(roleRequestTest))
+}
+
+func TestRevocation(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	roleRequestTest := g.roleRequestObj.DeepCopy()
+	roleRequestTest.SetName("role-request-revocation-test")
+	roleRequestTest.Spec.Email = "collaborator@edge-net.org"
+	roleRequestTest.Spec.Approved = true
+	edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, true, isBound(roleRequestTest))
+	util.Equals(t, true, isMember(g.tenantObj.GetName(), roleRequestTest.Spec.Email))
+
+	t.Run("unapproval", func(t *testing.T) {
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		roleRequest.Spec.Approved = false
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err = edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, pending, roleRequest.Status.State)
+		util.Equals(t, true, meta.IsStatusConditionFalse(roleRequest.Status.Conditions, registrationv1alpha1.ConditionRoleBound))
+		util.Equals(t, false, isBound(roleRequestTest))
+		util.Equals(t, false, isMember(g.tenantObj.GetName(), roleRequestTest.Spec.Email))
+	})
+	t.Run("deletion", func(t *testing.T) {
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		roleRequest.Spec.Approved = true
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
+		time.Sleep(time.Millisecond * 500)
+		util.Equals(t, true, isBound(roleRequestTest))
+
+		// The fake clientset ignores finalizers, so the deletion is simulated by setting the deletion timestamp
+		roleRequest, err = edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []string{roleBindingFinalizer}, roleRequest.GetFinalizers())
+		roleRequest.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Update(context.TODO(), roleRequest, metav1.UpdateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err = edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 0, len(roleRequest.GetFinalizers()))
+		util.Equals(t, false, isBound(roleRequestTest))
+	})
+}
+
+// isBound checks whether the role binding of the requested role holds the user
+func isBound(roleRequest *registrationv1alpha1.RoleRequest) bool {
+	roleBinding, err := kubeclientset.RbacV1().RoleBindings(roleRequest.GetNamespace()).Get(context.TODO(), roleRequest.Spec.RoleRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	for _, subject := range roleBinding.Subjects {
+		if subject.Kind == "User" && subject.Name == roleRequest.Spec.Email {
+			return true
+		}
+	}
+	return false
+}
+
+// isMember checks whether the user is in the members of the tenant
+func isMember(tenant, email string) bool {
+	tenantObj, err := edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenant, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return access.IsTenantMember(tenantObj, email)
+}
+
+func TestSubject(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	otherNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	otherNamespace.SetLabels(map[string]string{"edge-net.io/kind": "core", "edge-net.io/tenant": "other"})
+	kubeclientset.CoreV1().Namespaces().Create(context.TODO(), otherNamespace, metav1.CreateOptions{})
+
+	t.Run("service account of the tenant", func(t *testing.T) {
+		roleRequestTest := g.roleRequestObj.DeepCopy()
+		roleRequestTest.SetName("role-request-service-account-test")
+		roleRequestTest.Spec.Subject = &registrationv1alpha1.RoleSubjectSpec{Kind: "ServiceAccount", Name: "ci"}
+		roleRequestTest.Spec.Approved = true
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, approved, roleRequest.Status.State)
+		roleBinding, err := kubeclientset.RbacV1().RoleBindings(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.Spec.RoleRef.Name, metav1.GetOptions{})
+		util.OK(t, err)
+		bound := false
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind == "ServiceAccount" && subject.Name == "ci" && subject.Namespace == roleRequestTest.GetNamespace() {
+				bound = true
+			}
+		}
+		util.Equals(t, true, bound)
+	})
+	t.Run("service account of another tenant", func(t *testing.T) {
+		roleRequestTest := g.roleRequestObj.DeepCopy()
+		roleRequestTest.SetName("role-request-foreign-service-account-test")
+		roleRequestTest.Spec.Subject = &registrationv1alpha1.RoleSubjectSpec{Kind: "ServiceAccount", Name: "ci", Namespace: otherNamespace.GetName()}
+		roleRequestTest.Spec.Approved = true
+		edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Create(context.TODO(), roleRequestTest, metav1.CreateOptions{})
+		time.Sleep(time.Millisecond * 500)
+
+		roleRequest, err := edgenetclientset.RegistrationV1alpha1().RoleRequests(roleRequestTest.GetNamespace()).Get(context.TODO(), roleRequestTest.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, failure, roleRequest.Status.State)
+		util.Equals(t, messageSubjectInvalid, roleRequest.Status.Message)
+	})
+}
+
+func TestRetentionStart(t *testing.T) {
+	roleRequest := &registrationv1alpha1.RoleRequest{}
+	roleRequest.Status.Expiry = &metav1.Time{Time: time.Now().Add(-96 * time.Hour)}
+	util.SetCondition(&roleRequest.Status.Conditions, 0, registrationv1alpha1.ConditionRoleBound, metav1.ConditionFalse, reasonGrantExpired, messageGrantExpired)
+	util.Equals(t, roleRequest.Status.Expiry, getRetentionStart(roleRequest))
+
+	roleRequest.Status.State = revoked
+	util.Equals(t, true, time.Since(getRetentionStart(roleRequest).Time) < time.Minute)
+}
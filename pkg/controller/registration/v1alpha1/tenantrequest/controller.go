@@ -29,6 +29,7 @@ import (
 	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
 	informers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/registration/v1alpha1"
 	listers "github.com/EdgeNet-project/edgenet/pkg/generated/listers/registration/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -53,13 +54,32 @@ const (
 	messageNotApproved          = "Waiting for Requested Tenant to be approved"
 	successApproved             = "Approved"
 	messageRoleApproved         = "Requested Tenant approved successfully"
+	messageAutoApproved         = "Requested Tenant approved automatically by approval policy %s"
 	failureTenantCreation       = "Creation Failed"
 	messageTenantCreationFailed = "Tenant creation failed"
 	failureTenantExists         = "Conflicting"
 	messageTenantExists         = "Tenant already exists"
+	warningDenied               = "Denied"
+	messageDenied               = "Requested Tenant denied"
+	warningExpired              = "Expired"
+	messageExpired              = "Request expired before being approved"
 	failure                     = "Failure"
 	pending                     = "Pending"
 	approved                    = "Approved"
+	expired                     = "Expired"
+	denied                      = "Denied"
+)
+
+// Reasons of the status conditions of the tenantrequest resource
+const (
+	reasonPending        = "Pending"
+	reasonApproved       = "Approved"
+	reasonAutoApproved   = "AutoApproved"
+	reasonTenantCreated  = "TenantCreated"
+	reasonCreationFailed = "CreationFailed"
+	reasonTenantExists   = "TenantExists"
+	reasonDenied         = "Denied"
+	reasonExpired        = "Expired"
 )
 
 // Controller is the controller implementation for Tenant Request resources
@@ -72,6 +92,11 @@ type Controller struct {
 	tenantrequestsLister listers.TenantRequestLister
 	tenantrequestsSynced cache.InformerSynced
 
+	// approvalTimeout is the period within which the request needs to be approved before it expires
+	approvalTimeout time.Duration
+	// retention is the period for which expired and denied requests are kept as records before deletion
+	retention time.Duration
+
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
@@ -87,7 +112,9 @@ type Controller struct {
 func NewController(
 	kubeclientset kubernetes.Interface,
 	edgenetclientset clientset.Interface,
-	tenantrequestInformer informers.TenantRequestInformer) *Controller {
+	tenantrequestInformer informers.TenantRequestInformer,
+	approvalTimeout time.Duration,
+	retention time.Duration) *Controller {
 
 	utilruntime.Must(edgenetscheme.AddToScheme(scheme.Scheme))
 	klog.V(4).Info("Creating event broadcaster")
@@ -102,6 +129,8 @@ func NewController(
 		tenantrequestsLister: tenantrequestInformer.Lister(),
 		tenantrequestsSynced: tenantrequestInformer.Informer().HasSynced,
 		workqueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TenantRequests"),
+		approvalTimeout:      approvalTimeout,
+		retention:            retention,
 		recorder:             recorder,
 	}
 
@@ -256,9 +285,9 @@ func (c *Controller) enqueueTenantRequestAfter(obj interface{}, after time.Durat
 }
 
 func (c *Controller) processTenantRequest(tenantRequestCopy *registrationv1alpha1.TenantRequest) {
-	oldStatus := tenantRequestCopy.Status
+	oldStatus := tenantRequestCopy.Status.DeepCopy()
 	statusUpdate := func() {
-		if !reflect.DeepEqual(oldStatus, tenantRequestCopy.Status) {
+		if !reflect.DeepEqual(*oldStatus, tenantRequestCopy.Status) {
 			c.edgenetclientset.RegistrationV1alpha1().TenantRequests().UpdateStatus(context.TODO(), tenantRequestCopy, metav1.UpdateOptions{})
 		}
 	}
@@ -266,18 +295,32 @@ func (c *Controller) processTenantRequest(tenantRequestCopy *registrationv1alpha
 		c.recorder.Event(tenantRequestCopy, corev1.EventTypeWarning, failureTenantExists, messageTenantExists)
 		tenantRequestCopy.Status.State = failure
 		tenantRequestCopy.Status.Message = messageTenantExists
+		util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionTenantCreated, metav1.ConditionFalse, reasonTenantExists, messageTenantExists)
 		return
 	}
 	if tenantRequestCopy.Status.Expiry == nil {
-		// Set the approval timeout which is 72 hours
+		// Set the approval timeout which is 72 hours by default
 		tenantRequestCopy.Status.Expiry = &metav1.Time{
-			Time: time.Now().Add(72 * time.Hour),
+			Time: time.Now().Add(c.approvalTimeout),
 		}
 	} else if time.Until(tenantRequestCopy.Status.Expiry.Time) <= 0 {
-		c.edgenetclientset.RegistrationV1alpha1().TenantRequests().Delete(context.TODO(), tenantRequestCopy.GetName(), metav1.DeleteOptions{})
+		if retained := util.RetainExpiredRequest(tenantRequestCopy.Status.Expiry, c.retention, tenantRequestCopy.Status.State == expired || tenantRequestCopy.Status.State == denied,
+			func() {
+				c.recorder.Event(tenantRequestCopy, corev1.EventTypeWarning, warningExpired, messageExpired)
+				tenantRequestCopy.Status.State = expired
+				tenantRequestCopy.Status.Message = messageExpired
+				util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonExpired, messageExpired)
+				statusUpdate()
+			},
+			func() {
+				c.edgenetclientset.RegistrationV1alpha1().TenantRequests().Delete(context.TODO(), tenantRequestCopy.GetName(), metav1.DeleteOptions{})
+			}); retained > 0 {
+			c.enqueueTenantRequestAfter(tenantRequestCopy, retained)
+		}
 		return
 	}
 	defer statusUpdate()
+	tenantRequestCopy.Status.ObservedGeneration = tenantRequestCopy.GetGeneration()
 
 	_, err := c.kubeclientset.CoreV1().Namespaces().Get(context.TODO(), "kube-system", metav1.GetOptions{})
 	if err != nil {
@@ -286,24 +329,55 @@ func (c *Controller) processTenantRequest(tenantRequestCopy *registrationv1alpha
 		return
 	}
 
-	if !tenantRequestCopy.Spec.Approved {
+	// Approval policies let the requests meeting the criteria of administrators be approved automatically
+	tenantRequestCopy.Status.ApprovedBy = ""
+	if !tenantRequestCopy.Spec.Approved && !tenantRequestCopy.Spec.Denied {
+		tenantRequestCopy.Status.ApprovedBy = access.GetApprovalPolicyForTenantRequest(tenantRequestCopy)
+	}
+
+	if tenantRequestCopy.Spec.Denied {
+		c.denyTenantRequest(tenantRequestCopy)
+	} else if !tenantRequestCopy.Spec.Approved && tenantRequestCopy.Status.ApprovedBy == "" {
 		if tenantRequestCopy.Status.State == pending && tenantRequestCopy.Status.Message == messageNotApproved {
 			return
 		}
 		c.recorder.Event(tenantRequestCopy, corev1.EventTypeWarning, warningNotApproved, messageNotApproved)
 		tenantRequestCopy.Status.State = pending
 		tenantRequestCopy.Status.Message = messageNotApproved
+		util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonPending, messageNotApproved)
 	} else {
 		c.recorder.Event(tenantRequestCopy, corev1.EventTypeNormal, successApproved, messageRoleApproved)
 		tenantRequestCopy.Status.State = approved
 		tenantRequestCopy.Status.Message = messageRoleApproved
+		if tenantRequestCopy.Status.ApprovedBy != "" {
+			util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonAutoApproved, fmt.Sprintf(messageAutoApproved, tenantRequestCopy.Status.ApprovedBy))
+		} else {
+			util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionTrue, reasonApproved, messageRoleApproved)
+		}
 
 		if err := access.CreateTenant(tenantRequestCopy); err == nil {
 			c.recorder.Event(tenantRequestCopy, corev1.EventTypeNormal, successApproved, messageRoleApproved)
+			util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionTenantCreated, metav1.ConditionTrue, reasonTenantCreated, "Tenant is created out of the request")
 		} else {
 			c.recorder.Event(tenantRequestCopy, corev1.EventTypeWarning, failureTenantCreation, messageTenantCreationFailed)
 			tenantRequestCopy.Status.State = failure
 			tenantRequestCopy.Status.Message = messageTenantCreationFailed
+			util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionTenantCreated, metav1.ConditionFalse, reasonCreationFailed, err.Error())
 		}
 	}
 }
+
+// denyTenantRequest puts the tenant request into the denied state along with the reason given by the administrators
+func (c *Controller) denyTenantRequest(tenantRequestCopy *registrationv1alpha1.TenantRequest) {
+	message := messageDenied
+	if tenantRequestCopy.Spec.Reason != "" {
+		message = fmt.Sprintf("%s: %s", messageDenied, tenantRequestCopy.Spec.Reason)
+	}
+	if tenantRequestCopy.Status.State == denied && tenantRequestCopy.Status.Message == message {
+		return
+	}
+	c.recorder.Event(tenantRequestCopy, corev1.EventTypeWarning, warningDenied, message)
+	tenantRequestCopy.Status.State = denied
+	tenantRequestCopy.Status.Message = message
+	util.SetCondition(&tenantRequestCopy.Status.Conditions, tenantRequestCopy.GetGeneration(), registrationv1alpha1.ConditionApproved, metav1.ConditionFalse, reasonDenied, message)
+}
@@ -50,7 +50,9 @@ func TestMain(m *testing.M) {
 
 	controller := NewController(kubeclientset,
 		edgenetclientset,
-		edgenetInformerFactory.Registration().V1alpha1().TenantRequests())
+		edgenetInformerFactory.Registration().V1alpha1().TenantRequests(),
+		72*time.Hour,
+		0)
 
 	edgenetInformerFactory.Start(stopCh)
 
@@ -195,3 +197,21 @@ func TestUpdate(t *testing.T) {
 		})
 	})
 }
+
+func TestDenial(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	tenantRequestTest := g.tenantRequestObj.DeepCopy()
+	tenantRequestTest.SetName("tenant-request-denial-test")
+	tenantRequestTest.Spec.Denied = true
+	tenantRequestTest.Spec.Approved = true
+	edgenetclientset.RegistrationV1alpha1().TenantRequests().Create(context.TODO(), tenantRequestTest, metav1.CreateOptions{})
+	time.Sleep(250 * time.Millisecond)
+
+	tenantRequest, err := edgenetclientset.RegistrationV1alpha1().TenantRequests().Get(context.TODO(), tenantRequestTest.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, denied, tenantRequest.Status.State)
+	util.Equals(t, messageDenied, tenantRequest.Status.Message)
+	_, err = edgenetclientset.CoreV1alpha1().Tenants().Get(context.TODO(), tenantRequestTest.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+}
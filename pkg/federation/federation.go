@@ -0,0 +1,241 @@
+/*
+Copyright 2022 Contributors to the EdgeNet project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeconfigKey is the key of the kubeconfig in the secrets that cluster peers refer to
+const KubeconfigKey = "kubeconfig"
+
+// FederatedLabel marks the objects that a federated workspace has in a member cluster
+const FederatedLabel = "edge-net.io/federated"
+
+// OriginLabel holds the UID of the cluster that mirrors a federated workspace into a member cluster
+const OriginLabel = "edge-net.io/origin-cluster-uid"
+
+// The namespace where the kubeconfig secrets of cluster peers are stored
+const secretNamespace = "edgenet"
+
+// CreateMemberClientset generates the clientset to interact with a member cluster from its kubeconfig.
+// It is a variable so that tests can hand over the clientset of a fake member cluster.
+var CreateMemberClientset = func(kubeconfig []byte) (kubernetes.Interface, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// GetMemberClientset returns the clientset of the member cluster that the cluster peer registers
+func GetMemberClientset(kubeclientset kubernetes.Interface, clusterPeer *corev1alpha1.ClusterPeer) (kubernetes.Interface, error) {
+	secret, err := kubeclientset.CoreV1().Secrets(secretNamespace).Get(context.TODO(), clusterPeer.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig, elementExists := secret.Data[KubeconfigKey]
+	if !elementExists {
+		return nil, fmt.Errorf("secret %s does not have a %s key", secret.GetName(), KubeconfigKey)
+	}
+	return CreateMemberClientset(kubeconfig)
+}
+
+// MirrorNamespace creates or updates the namespace along with its resource quota, roles, and role bindings in the member cluster.
+// The roles and role bindings of an earlier mirror that no longer exist in the namespace are removed.
+func MirrorNamespace(memberclientset kubernetes.Interface, clusterUID string, namespace *corev1.Namespace, resourceQuota *corev1.ResourceQuota, roles []*rbacv1.Role, roleBindings []*rbacv1.RoleBinding) error {
+	mirrorLabels := map[string]string{FederatedLabel: "true", OriginLabel: clusterUID}
+	namespaceLabels := mergeLabels(namespace.GetLabels(), mirrorLabels)
+
+	mirrorNamespace, err := memberclientset.CoreV1().Namespaces().Get(context.TODO(), namespace.GetName(), metav1.GetOptions{})
+	if err == nil {
+		if !isMirror(mirrorNamespace, clusterUID) {
+			return fmt.Errorf("namespace %s already exists in the member cluster", namespace.GetName())
+		}
+		if !reflect.DeepEqual(mirrorNamespace.GetLabels(), namespaceLabels) || !reflect.DeepEqual(mirrorNamespace.GetAnnotations(), namespace.GetAnnotations()) {
+			mirrorNamespaceCopy := mirrorNamespace.DeepCopy()
+			mirrorNamespaceCopy.SetLabels(namespaceLabels)
+			mirrorNamespaceCopy.SetAnnotations(namespace.GetAnnotations())
+			if _, err := memberclientset.CoreV1().Namespaces().Update(context.TODO(), mirrorNamespaceCopy, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+		}
+	} else if errors.IsNotFound(err) {
+		mirrorNamespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace.GetName(), Labels: namespaceLabels, Annotations: namespace.GetAnnotations()}}
+		if _, err := memberclientset.CoreV1().Namespaces().Create(context.TODO(), mirrorNamespace, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+
+	if resourceQuota != nil {
+		if err := mirrorResourceQuota(memberclientset, resourceQuota, mirrorLabels); err != nil {
+			return err
+		}
+	}
+	if err := mirrorRoles(memberclientset, namespace.GetName(), roles, mirrorLabels); err != nil {
+		return err
+	}
+	return mirrorRoleBindings(memberclientset, namespace.GetName(), roleBindings, mirrorLabels)
+}
+
+// RemoveNamespace deletes the mirror of the namespace from the member cluster if there is any
+func RemoveNamespace(memberclientset kubernetes.Interface, clusterUID, name string) error {
+	mirrorNamespace, err := memberclientset.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isMirror(mirrorNamespace, clusterUID) {
+		return nil
+	}
+	err = memberclientset.CoreV1().Namespaces().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isMirror checks whether this cluster has created the object in the member cluster
+func isMirror(object metav1.Object, clusterUID string) bool {
+	objectLabels := object.GetLabels()
+	return objectLabels[FederatedLabel] == "true" && objectLabels[OriginLabel] == clusterUID
+}
+
+func mirrorResourceQuota(memberclientset kubernetes.Interface, resourceQuota *corev1.ResourceQuota, mirrorLabels map[string]string) error {
+	mirrorResourceQuota, err := memberclientset.CoreV1().ResourceQuotas(resourceQuota.GetNamespace()).Get(context.TODO(), resourceQuota.GetName(), metav1.GetOptions{})
+	if err == nil {
+		if !reflect.DeepEqual(mirrorResourceQuota.Spec.Hard, resourceQuota.Spec.Hard) {
+			mirrorResourceQuotaCopy := mirrorResourceQuota.DeepCopy()
+			mirrorResourceQuotaCopy.Spec.Hard = resourceQuota.Spec.Hard
+			_, err = memberclientset.CoreV1().ResourceQuotas(resourceQuota.GetNamespace()).Update(context.TODO(), mirrorResourceQuotaCopy, metav1.UpdateOptions{})
+		}
+	} else if errors.IsNotFound(err) {
+		mirrorResourceQuota = &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: resourceQuota.GetName(), Namespace: resourceQuota.GetNamespace(), Labels: mirrorLabels}}
+		mirrorResourceQuota.Spec.Hard = resourceQuota.Spec.Hard
+		_, err = memberclientset.CoreV1().ResourceQuotas(resourceQuota.GetNamespace()).Create(context.TODO(), mirrorResourceQuota, metav1.CreateOptions{})
+	}
+	return err
+}
+
+func mirrorRoles(memberclientset kubernetes.Interface, namespace string, roles []*rbacv1.Role, mirrorLabels map[string]string) error {
+	roleNames := make(map[string]bool)
+	for _, role := range roles {
+		roleNames[role.GetName()] = true
+		roleLabels := mergeLabels(role.GetLabels(), mirrorLabels)
+		mirrorRole, err := memberclientset.RbacV1().Roles(namespace).Get(context.TODO(), role.GetName(), metav1.GetOptions{})
+		if err == nil {
+			if !reflect.DeepEqual(mirrorRole.Rules, role.Rules) || !reflect.DeepEqual(mirrorRole.GetLabels(), roleLabels) {
+				mirrorRoleCopy := mirrorRole.DeepCopy()
+				mirrorRoleCopy.Rules = role.Rules
+				mirrorRoleCopy.SetLabels(roleLabels)
+				_, err = memberclientset.RbacV1().Roles(namespace).Update(context.TODO(), mirrorRoleCopy, metav1.UpdateOptions{})
+			}
+		} else if errors.IsNotFound(err) {
+			mirrorRole = &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: role.GetName(), Namespace: namespace, Labels: roleLabels}, Rules: role.Rules}
+			_, err = memberclientset.RbacV1().Roles(namespace).Create(context.TODO(), mirrorRole, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only the objects that this cluster has mirrored are removed
+	mirrorRoleRaw, err := memberclientset.RbacV1().Roles(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=true,%s=%s", FederatedLabel, OriginLabel, mirrorLabels[OriginLabel])})
+	if err != nil {
+		return err
+	}
+	for _, mirrorRoleRow := range mirrorRoleRaw.Items {
+		if !roleNames[mirrorRoleRow.GetName()] {
+			if err := memberclientset.RbacV1().Roles(namespace).Delete(context.TODO(), mirrorRoleRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func mirrorRoleBindings(memberclientset kubernetes.Interface, namespace string, roleBindings []*rbacv1.RoleBinding, mirrorLabels map[string]string) error {
+	roleBindingNames := make(map[string]bool)
+	for _, roleBinding := range roleBindings {
+		roleBindingNames[roleBinding.GetName()] = true
+		roleBindingLabels := mergeLabels(roleBinding.GetLabels(), mirrorLabels)
+		mirrorRoleBinding, err := memberclientset.RbacV1().RoleBindings(namespace).Get(context.TODO(), roleBinding.GetName(), metav1.GetOptions{})
+		if err == nil && !reflect.DeepEqual(mirrorRoleBinding.RoleRef, roleBinding.RoleRef) {
+			// The role reference of a binding is immutable, so the binding is created again
+			if err := memberclientset.RbacV1().RoleBindings(namespace).Delete(context.TODO(), roleBinding.GetName(), metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+			err = errors.NewNotFound(rbacv1.Resource("rolebindings"), roleBinding.GetName())
+		}
+		if err == nil {
+			if !reflect.DeepEqual(mirrorRoleBinding.Subjects, roleBinding.Subjects) || !reflect.DeepEqual(mirrorRoleBinding.GetLabels(), roleBindingLabels) {
+				mirrorRoleBindingCopy := mirrorRoleBinding.DeepCopy()
+				mirrorRoleBindingCopy.Subjects = roleBinding.Subjects
+				mirrorRoleBindingCopy.SetLabels(roleBindingLabels)
+				_, err = memberclientset.RbacV1().RoleBindings(namespace).Update(context.TODO(), mirrorRoleBindingCopy, metav1.UpdateOptions{})
+			}
+		} else if errors.IsNotFound(err) {
+			mirrorRoleBinding = &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: roleBinding.GetName(), Namespace: namespace, Labels: roleBindingLabels},
+				Subjects: roleBinding.Subjects, RoleRef: roleBinding.RoleRef}
+			_, err = memberclientset.RbacV1().RoleBindings(namespace).Create(context.TODO(), mirrorRoleBinding, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only the objects that this cluster has mirrored are removed
+	mirrorRoleBindingRaw, err := memberclientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=true,%s=%s", FederatedLabel, OriginLabel, mirrorLabels[OriginLabel])})
+	if err != nil {
+		return err
+	}
+	for _, mirrorRoleBindingRow := range mirrorRoleBindingRaw.Items {
+		if !roleBindingNames[mirrorRoleBindingRow.GetName()] {
+			if err := memberclientset.RbacV1().RoleBindings(namespace).Delete(context.TODO(), mirrorRoleBindingRow.GetName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeLabels returns the labels of the object with the labels that mark the mirror
+func mergeLabels(objectLabels, mirrorLabels map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for key, value := range objectLabels {
+		merged[key] = value
+	}
+	for key, value := range mirrorLabels {
+		merged[key] = value
+	}
+	return merged
+}
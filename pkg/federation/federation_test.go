@@ -0,0 +1,101 @@
+package federation
+
+import (
+	"context"
+	"testing"
+
+	corev1alpha1 "github.com/EdgeNet-project/edgenet/pkg/apis/core/v1alpha1"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetMemberClientset(t *testing.T) {
+	kubeclientset := testclient.NewSimpleClientset()
+	memberclientset := testclient.NewSimpleClientset()
+	CreateMemberClientset = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return memberclientset, nil
+	}
+
+	clusterPeer := &corev1alpha1.ClusterPeer{ObjectMeta: metav1.ObjectMeta{Name: "member"}, Spec: corev1alpha1.ClusterPeerSpec{SecretName: "member-kubeconfig", Enabled: true}}
+	_, err := GetMemberClientset(kubeclientset, clusterPeer)
+	util.Equals(t, true, errors.IsNotFound(err))
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "member-kubeconfig", Namespace: "edgenet"}, Data: map[string][]byte{"config": []byte("")}}
+	kubeclientset.CoreV1().Secrets("edgenet").Create(context.TODO(), secret, metav1.CreateOptions{})
+	_, err = GetMemberClientset(kubeclientset, clusterPeer)
+	util.Equals(t, true, err != nil)
+
+	secret.Data = map[string][]byte{KubeconfigKey: []byte("")}
+	kubeclientset.CoreV1().Secrets("edgenet").Update(context.TODO(), secret, metav1.UpdateOptions{})
+	clientset, err := GetMemberClientset(kubeclientset, clusterPeer)
+	util.OK(t, err)
+	util.Equals(t, memberclientset, clientset)
+}
+
+func TestMirrorNamespace(t *testing.T) {
+	memberclientset := testclient.NewSimpleClientset()
+	clusterUID := "cluster-uid"
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "workspace", Labels: map[string]string{"edge-net.io/tenant": "edgenet"}}}
+	resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "sub-quota", Namespace: "workspace"}}
+	resourceQuota.Spec.Hard = map[corev1.ResourceName]resource.Quantity{corev1.ResourceCPU: resource.MustParse("2")}
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "pod-reader", Namespace: "workspace"},
+		Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "pod-reader", Namespace: "workspace"},
+		Subjects: []rbacv1.Subject{{Kind: "User", Name: "johndoe@edge-net.org", APIGroup: "rbac.authorization.k8s.io"}},
+		RoleRef:  rbacv1.RoleRef{Kind: "Role", Name: "pod-reader"}}
+
+	err := MirrorNamespace(memberclientset, clusterUID, namespace, resourceQuota, []*rbacv1.Role{role}, []*rbacv1.RoleBinding{roleBinding})
+	util.OK(t, err)
+	mirrorNamespace, err := memberclientset.CoreV1().Namespaces().Get(context.TODO(), "workspace", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, "edgenet", mirrorNamespace.GetLabels()["edge-net.io/tenant"])
+	util.Equals(t, clusterUID, mirrorNamespace.GetLabels()[OriginLabel])
+	mirrorResourceQuota, err := memberclientset.CoreV1().ResourceQuotas("workspace").Get(context.TODO(), "sub-quota", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, resourceQuota.Spec.Hard, mirrorResourceQuota.Spec.Hard)
+	mirrorRole, err := memberclientset.RbacV1().Roles("workspace").Get(context.TODO(), "pod-reader", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, role.Rules, mirrorRole.Rules)
+	mirrorRoleBinding, err := memberclientset.RbacV1().RoleBindings("workspace").Get(context.TODO(), "pod-reader", metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, roleBinding.Subjects, mirrorRoleBinding.Subjects)
+
+	t.Run("update", func(t *testing.T) {
+		// A role that another cluster has mirrored into the same namespace is left alone
+		foreignRole := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "workspace", Labels: map[string]string{FederatedLabel: "true", OriginLabel: "another-cluster-uid"}}}
+		memberclientset.RbacV1().Roles("workspace").Create(context.TODO(), foreignRole, metav1.CreateOptions{})
+		roleBindingCopy := roleBinding.DeepCopy()
+		roleBindingCopy.RoleRef = rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}
+		err := MirrorNamespace(memberclientset, clusterUID, namespace, resourceQuota, []*rbacv1.Role{}, []*rbacv1.RoleBinding{roleBindingCopy})
+		util.OK(t, err)
+		_, err = memberclientset.RbacV1().Roles("workspace").Get(context.TODO(), "pod-reader", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		_, err = memberclientset.RbacV1().Roles("workspace").Get(context.TODO(), foreignRole.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		mirrorRoleBinding, err := memberclientset.RbacV1().RoleBindings("workspace").Get(context.TODO(), "pod-reader", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, roleBindingCopy.RoleRef, mirrorRoleBinding.RoleRef)
+	})
+	t.Run("collision", func(t *testing.T) {
+		err := MirrorNamespace(memberclientset, "another-cluster-uid", namespace, nil, []*rbacv1.Role{}, []*rbacv1.RoleBinding{})
+		util.Equals(t, true, err != nil)
+		err = RemoveNamespace(memberclientset, "another-cluster-uid", "workspace")
+		util.OK(t, err)
+		_, err = memberclientset.CoreV1().Namespaces().Get(context.TODO(), "workspace", metav1.GetOptions{})
+		util.OK(t, err)
+	})
+	t.Run("remove", func(t *testing.T) {
+		err := RemoveNamespace(memberclientset, clusterUID, "workspace")
+		util.OK(t, err)
+		_, err = memberclientset.CoreV1().Namespaces().Get(context.TODO(), "workspace", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+}
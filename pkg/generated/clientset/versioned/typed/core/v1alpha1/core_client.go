@@ -26,7 +26,9 @@ import (
 
 type CoreV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterPeersGetter
 	NodeContributionsGetter
+	RoleTemplatesGetter
 	SlicesGetter
 	SliceClaimsGetter
 	SubNamespacesGetter
@@ -39,10 +41,18 @@ type CoreV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *CoreV1alpha1Client) ClusterPeers() ClusterPeerInterface {
+	return newClusterPeers(c)
+}
+
 func (c *CoreV1alpha1Client) NodeContributions() NodeContributionInterface {
 	return newNodeContributions(c)
 }
 
+func (c *CoreV1alpha1Client) RoleTemplates(namespace string) RoleTemplateInterface {
+	return newRoleTemplates(c, namespace)
+}
+
 func (c *CoreV1alpha1Client) Slices() SliceInterface {
 	return newSlices(c)
 }
@@ -28,10 +28,18 @@ type FakeCoreV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeCoreV1alpha1) ClusterPeers() v1alpha1.ClusterPeerInterface {
+	return &FakeClusterPeers{c}
+}
+
 func (c *FakeCoreV1alpha1) NodeContributions() v1alpha1.NodeContributionInterface {
 	return &FakeNodeContributions{c}
 }
 
+func (c *FakeCoreV1alpha1) RoleTemplates(namespace string) v1alpha1.RoleTemplateInterface {
+	return &FakeRoleTemplates{c, namespace}
+}
+
 func (c *FakeCoreV1alpha1) Slices() v1alpha1.SliceInterface {
 	return &FakeSlices{c}
 }
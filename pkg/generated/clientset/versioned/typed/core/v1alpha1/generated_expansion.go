@@ -18,8 +18,12 @@ limitations under the License.
 
 package v1alpha1
 
+type ClusterPeerExpansion interface{}
+
 type NodeContributionExpansion interface{}
 
+type RoleTemplateExpansion interface{}
+
 type SliceExpansion interface{}
 
 type SliceClaimExpansion interface{}
@@ -28,10 +28,22 @@ type FakeRegistrationV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeRegistrationV1alpha1) ApprovalPolicies() v1alpha1.ApprovalPolicyInterface {
+	return &FakeApprovalPolicies{c}
+}
+
 func (c *FakeRegistrationV1alpha1) ClusterRoleRequests() v1alpha1.ClusterRoleRequestInterface {
 	return &FakeClusterRoleRequests{c}
 }
 
+func (c *FakeRegistrationV1alpha1) OwnershipTransferRequests(namespace string) v1alpha1.OwnershipTransferRequestInterface {
+	return &FakeOwnershipTransferRequests{c, namespace}
+}
+
+func (c *FakeRegistrationV1alpha1) QuotaRequests(namespace string) v1alpha1.QuotaRequestInterface {
+	return &FakeQuotaRequests{c, namespace}
+}
+
 func (c *FakeRegistrationV1alpha1) RoleRequests(namespace string) v1alpha1.RoleRequestInterface {
 	return &FakeRoleRequests{c, namespace}
 }
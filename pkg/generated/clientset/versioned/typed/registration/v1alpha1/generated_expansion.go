@@ -18,8 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+type ApprovalPolicyExpansion interface{}
+
 type ClusterRoleRequestExpansion interface{}
 
+type OwnershipTransferRequestExpansion interface{}
+
+type QuotaRequestExpansion interface{}
+
 type RoleRequestExpansion interface{}
 
 type TenantRequestExpansion interface{}
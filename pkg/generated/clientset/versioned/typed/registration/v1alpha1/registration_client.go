@@ -26,7 +26,10 @@ import (
 
 type RegistrationV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ApprovalPoliciesGetter
 	ClusterRoleRequestsGetter
+	OwnershipTransferRequestsGetter
+	QuotaRequestsGetter
 	RoleRequestsGetter
 	TenantRequestsGetter
 }
@@ -36,10 +39,22 @@ type RegistrationV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *RegistrationV1alpha1Client) ApprovalPolicies() ApprovalPolicyInterface {
+	return newApprovalPolicies(c)
+}
+
 func (c *RegistrationV1alpha1Client) ClusterRoleRequests() ClusterRoleRequestInterface {
 	return newClusterRoleRequests(c)
 }
 
+func (c *RegistrationV1alpha1Client) OwnershipTransferRequests(namespace string) OwnershipTransferRequestInterface {
+	return newOwnershipTransferRequests(c, namespace)
+}
+
+func (c *RegistrationV1alpha1Client) QuotaRequests(namespace string) QuotaRequestInterface {
+	return newQuotaRequests(c, namespace)
+}
+
 func (c *RegistrationV1alpha1Client) RoleRequests(namespace string) RoleRequestInterface {
 	return newRoleRequests(c, namespace)
 }
@@ -24,8 +24,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterPeers returns a ClusterPeerInformer.
+	ClusterPeers() ClusterPeerInformer
 	// NodeContributions returns a NodeContributionInformer.
 	NodeContributions() NodeContributionInformer
+	// RoleTemplates returns a RoleTemplateInformer.
+	RoleTemplates() RoleTemplateInformer
 	// Slices returns a SliceInformer.
 	Slices() SliceInformer
 	// SliceClaims returns a SliceClaimInformer.
@@ -49,11 +53,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterPeers returns a ClusterPeerInformer.
+func (v *version) ClusterPeers() ClusterPeerInformer {
+	return &clusterPeerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // NodeContributions returns a NodeContributionInformer.
 func (v *version) NodeContributions() NodeContributionInformer {
 	return &nodeContributionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// RoleTemplates returns a RoleTemplateInformer.
+func (v *version) RoleTemplates() RoleTemplateInformer {
+	return &roleTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Slices returns a SliceInformer.
 func (v *version) Slices() SliceInformer {
 	return &sliceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
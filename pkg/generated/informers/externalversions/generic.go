@@ -60,8 +60,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().SelectiveDeployments().Informer()}, nil
 
 		// Group=core.edgenet.io, Version=v1alpha1
+	case corev1alpha1.SchemeGroupVersion.WithResource("clusterpeers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().ClusterPeers().Informer()}, nil
 	case corev1alpha1.SchemeGroupVersion.WithResource("nodecontributions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().NodeContributions().Informer()}, nil
+	case corev1alpha1.SchemeGroupVersion.WithResource("roletemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().RoleTemplates().Informer()}, nil
 	case corev1alpha1.SchemeGroupVersion.WithResource("slices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1alpha1().Slices().Informer()}, nil
 	case corev1alpha1.SchemeGroupVersion.WithResource("sliceclaims"):
@@ -78,8 +82,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().VPNPeers().Informer()}, nil
 
 		// Group=registration.edgenet.io, Version=v1alpha1
+	case registrationv1alpha1.SchemeGroupVersion.WithResource("approvalpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Registration().V1alpha1().ApprovalPolicies().Informer()}, nil
 	case registrationv1alpha1.SchemeGroupVersion.WithResource("clusterrolerequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Registration().V1alpha1().ClusterRoleRequests().Informer()}, nil
+	case registrationv1alpha1.SchemeGroupVersion.WithResource("ownershiptransferrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Registration().V1alpha1().OwnershipTransferRequests().Informer()}, nil
+	case registrationv1alpha1.SchemeGroupVersion.WithResource("quotarequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Registration().V1alpha1().QuotaRequests().Informer()}, nil
 	case registrationv1alpha1.SchemeGroupVersion.WithResource("rolerequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Registration().V1alpha1().RoleRequests().Informer()}, nil
 	case registrationv1alpha1.SchemeGroupVersion.WithResource("tenantrequests"):
@@ -24,8 +24,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ApprovalPolicies returns a ApprovalPolicyInformer.
+	ApprovalPolicies() ApprovalPolicyInformer
 	// ClusterRoleRequests returns a ClusterRoleRequestInformer.
 	ClusterRoleRequests() ClusterRoleRequestInformer
+	// OwnershipTransferRequests returns a OwnershipTransferRequestInformer.
+	OwnershipTransferRequests() OwnershipTransferRequestInformer
+	// QuotaRequests returns a QuotaRequestInformer.
+	QuotaRequests() QuotaRequestInformer
 	// RoleRequests returns a RoleRequestInformer.
 	RoleRequests() RoleRequestInformer
 	// TenantRequests returns a TenantRequestInformer.
@@ -43,11 +49,26 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ApprovalPolicies returns a ApprovalPolicyInformer.
+func (v *version) ApprovalPolicies() ApprovalPolicyInformer {
+	return &approvalPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterRoleRequests returns a ClusterRoleRequestInformer.
 func (v *version) ClusterRoleRequests() ClusterRoleRequestInformer {
 	return &clusterRoleRequestInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// OwnershipTransferRequests returns a OwnershipTransferRequestInformer.
+func (v *version) OwnershipTransferRequests() OwnershipTransferRequestInformer {
+	return &ownershipTransferRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// QuotaRequests returns a QuotaRequestInformer.
+func (v *version) QuotaRequests() QuotaRequestInformer {
+	return &quotaRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // RoleRequests returns a RoleRequestInformer.
 func (v *version) RoleRequests() RoleRequestInformer {
 	return &roleRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,10 +18,22 @@ limitations under the License.
 
 package v1alpha1
 
+// ClusterPeerListerExpansion allows custom methods to be added to
+// ClusterPeerLister.
+type ClusterPeerListerExpansion interface{}
+
 // NodeContributionListerExpansion allows custom methods to be added to
 // NodeContributionLister.
 type NodeContributionListerExpansion interface{}
 
+// RoleTemplateListerExpansion allows custom methods to be added to
+// RoleTemplateLister.
+type RoleTemplateListerExpansion interface{}
+
+// RoleTemplateNamespaceListerExpansion allows custom methods to be added to
+// RoleTemplateNamespaceLister.
+type RoleTemplateNamespaceListerExpansion interface{}
+
 // SliceListerExpansion allows custom methods to be added to
 // SliceLister.
 type SliceListerExpansion interface{}
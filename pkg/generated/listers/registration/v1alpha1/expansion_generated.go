@@ -18,10 +18,30 @@ limitations under the License.
 
 package v1alpha1
 
+// ApprovalPolicyListerExpansion allows custom methods to be added to
+// ApprovalPolicyLister.
+type ApprovalPolicyListerExpansion interface{}
+
 // ClusterRoleRequestListerExpansion allows custom methods to be added to
 // ClusterRoleRequestLister.
 type ClusterRoleRequestListerExpansion interface{}
 
+// OwnershipTransferRequestListerExpansion allows custom methods to be added to
+// OwnershipTransferRequestLister.
+type OwnershipTransferRequestListerExpansion interface{}
+
+// OwnershipTransferRequestNamespaceListerExpansion allows custom methods to be added to
+// OwnershipTransferRequestNamespaceLister.
+type OwnershipTransferRequestNamespaceListerExpansion interface{}
+
+// QuotaRequestListerExpansion allows custom methods to be added to
+// QuotaRequestLister.
+type QuotaRequestListerExpansion interface{}
+
+// QuotaRequestNamespaceListerExpansion allows custom methods to be added to
+// QuotaRequestNamespaceLister.
+type QuotaRequestNamespaceListerExpansion interface{}
+
 // RoleRequestListerExpansion allows custom methods to be added to
 // RoleRequestLister.
 type RoleRequestListerExpansion interface{}
@@ -42,26 +42,47 @@ type smtpServer struct {
 }
 
 type Content struct {
-	Cluster            string
-	User               string
-	FirstName          string
-	LastName           string
-	Subject            string
-	Recipient          []string
-	RoleRequest        *RoleRequest
-	TenantRequest      *TenantRequest
-	ClusterRoleRequest *ClusterRoleRequest
+	Cluster             string
+	User                string
+	FirstName           string
+	LastName            string
+	Subject             string
+	Recipient           []string
+	Reason              string
+	RoleRequest         *RoleRequest
+	TenantRequest       *TenantRequest
+	ClusterRoleRequest  *ClusterRoleRequest
+	TenantResourceQuota *TenantResourceQuota
+	QuotaRequest        *QuotaRequest
+	OwnershipTransfer   *OwnershipTransfer
 }
 type RoleRequest struct {
-	Name      string
-	Namespace string
+	Name        string
+	Namespace   string
+	Subject     string
+	GrantExpiry string
 }
 type ClusterRoleRequest struct {
-	Name string
+	Name        string
+	GrantExpiry string
 }
 type TenantRequest struct {
 	Tenant string
 }
+type TenantResourceQuota struct {
+	Tenant     string
+	Namespaces []string
+}
+type QuotaRequest struct {
+	Name      string
+	Namespace string
+}
+type OwnershipTransfer struct {
+	Name          string
+	Namespace     string
+	Tenant        string
+	PreviousOwner string
+}
 
 var dir = "../.."
 
@@ -2,6 +2,7 @@ package slack
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/slack-go/slack"
@@ -17,6 +18,7 @@ const (
 	CLUSTER_ROLE_REQUEST_MADE = "kubectl patch clusterrolerequest %s --type='json' -p='[{\"op\": \"replace\", \"path\": \"/spec/approved\", \"value\":true}]' --kubeconfig ./edgenet-kubeconfig.cfg"
 	ROLE_REQUEST_MADE         = "kubectl patch rolerequest %s -n %s --type='json' -p='[{\"op\": \"replace\", \"path\": \"/spec/approved\", \"value\":true}]' --kubeconfig ./edgenet-kubeconfig.cfg"
 	TENANT_REQUEST_MADE       = "kubectl patch tenantrequest %s --type='json' -p='[{\"op\": \"replace\", \"path\": \"/spec/approved\", \"value\":true}]' --kubeconfig ./admin.cfg"
+	QUOTA_REQUEST_MADE        = "kubectl patch quotarequest %s -n %s --type='json' -p='[{\"op\": \"replace\", \"path\": \"/spec/approved\", \"value\":true}]' --kubeconfig ./admin.cfg"
 )
 
 // // "clusterrole-request-made"
@@ -36,12 +38,14 @@ type Content struct {
 	Subject   string
 	AuthToken string
 	ChannelId string
+	Reason    string
 	// There are not recipient since this the notification will only be sent to a channel.
 	// If requested this may be changed to support multiple Slack channels.
 	// Recipient     []string
 	ClusterRolerequest *ClusterRoleRequest
 	RoleRequest        *RoleRequest
 	TenantRequest      *TenantRequest
+	QuotaRequest       *QuotaRequest
 }
 
 type ClusterRoleRequest struct {
@@ -51,12 +55,18 @@ type ClusterRoleRequest struct {
 type RoleRequest struct {
 	Name      string
 	Namespace string
+	Subject   string
 }
 
 type TenantRequest struct {
 	Tenant string
 }
 
+type QuotaRequest struct {
+	Name      string
+	Namespace string
+}
+
 func (c *Content) Send(purpose string) error {
 	client := slack.New(c.AuthToken)
 
@@ -97,6 +107,26 @@ func (c *Content) Send(purpose string) error {
 			Title: "Console Command",
 			Value: fmt.Sprintf(TENANT_REQUEST_MADE, c.TenantRequest.Tenant),
 		})
+	} else if purpose == "quota-request-made" {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Console Command",
+			Value: fmt.Sprintf(QUOTA_REQUEST_MADE, c.QuotaRequest.Name, c.QuotaRequest.Namespace),
+		})
+	}
+
+	// Roles requested for a group or service account need to be reviewed along with the subject
+	if c.RoleRequest != nil && c.RoleRequest.Subject != "" {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Subject",
+			Value: c.RoleRequest.Subject,
+		})
+	}
+	// The reason for the denial given by the administrators is also shared with the channel
+	if strings.HasSuffix(purpose, "-denied") {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Reason",
+			Value: c.Reason,
+		})
 	}
 
 	// Set edgenet colors
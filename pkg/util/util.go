@@ -30,6 +30,8 @@ import (
 	"time"
 
 	yaml "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	//cmdconfig "k8s.io/kubernetes/pkg/kubectl/cmd/config"
@@ -329,3 +331,29 @@ func Hash(strs ...string) string {
 	adler32 := adler32.Checksum([]byte(str))
 	return fmt.Sprintf("%x", adler32)
 }
+
+// SetCondition records the outcome of a reconciliation step in the conditions of a resource status
+func SetCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// RetainExpiredRequest handles a request past its expiry date. Requests that are not handled in time are kept in the expired state
+// during the retention period, which allows using them as records, and removed afterwards. Settled requests, such as the denied ones,
+// remain as they are until the end of the same period. It returns how long the request is retained, zero once it is removed.
+func RetainExpiredRequest(expiry *metav1.Time, retention time.Duration, settled bool, expire, remove func()) time.Duration {
+	remaining := time.Until(expiry.Add(retention))
+	if remaining <= 0 {
+		remove()
+		return 0
+	}
+	if !settled {
+		expire()
+	}
+	return remaining
+}
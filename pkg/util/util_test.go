@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetOperations(t *testing.T) {
@@ -44,3 +46,26 @@ func TestGenerateRandomString(t *testing.T) {
 		codes = append(codes, task)
 	}
 }
+
+func TestRetainExpiredRequest(t *testing.T) {
+	cases := map[string]struct {
+		expiry   time.Time
+		settled  bool
+		expired  bool
+		removed  bool
+		retained bool
+	}{
+		"within retention":         {time.Now().Add(-time.Minute), false, true, false, true},
+		"settled within retention": {time.Now().Add(-time.Minute), true, false, false, true},
+		"retention over":           {time.Now().Add(-time.Hour), false, false, true, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			expired, removed := false, false
+			retained := RetainExpiredRequest(&metav1.Time{Time: tc.expiry}, 30*time.Minute, tc.settled, func() { expired = true }, func() { removed = true })
+			Equals(t, tc.expired, expired)
+			Equals(t, tc.removed, removed)
+			Equals(t, tc.retained, retained > 0)
+		})
+	}
+}